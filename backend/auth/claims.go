@@ -7,9 +7,20 @@ import (
 	guuid "github.com/google/uuid"
 )
 
+// Области действия промежуточных токенов двухфакторной аутентификации.
+// Обычный токен доступа выдаётся с пустой областью.
+const (
+	ScopeTwoFactorLogin  = "2fa_login"
+	ScopeTwoFactorEnroll = "2fa_enroll"
+)
+
 type Claims struct {
 	ID       guuid.UUID `json:"id"`
 	Username string     `json:"username"`
 	Role     model.Role `json:"role"`
+	Scope    string     `json:"scope,omitempty"`
+	// Заполняется, если запрос аутентифицирован API-ключом, а не JWT
+	APIKeyID    *guuid.UUID `json:"apiKeyId,omitempty"`
+	Permissions []string    `json:"permissions,omitempty"`
 	jwt.StandardClaims
 }
@@ -55,8 +55,10 @@ func ConnectDB() {
 		}
 	}
 
-	err = DB.AutoMigrate(&model.User{}, &model.Client{}, &model.Category{}, &model.Product{}, &model.ProductionLog{}, &model.Order{}, &model.OrderItem{})
+	err = DB.AutoMigrate(&model.User{}, &model.Client{}, &model.Category{}, &model.Product{}, &model.ProductionLog{}, &model.Order{}, &model.OrderItem{}, &model.AuditEntry{}, &model.APIKey{}, &model.Warehouse{}, &model.WarehouseStock{}, &model.StockTransfer{}, &model.StockTransferItem{}, &model.StockAlert{}, &model.Notification{}, &model.StockTake{}, &model.StockTakeItem{}, &model.StockAdjustment{}, &model.Supplier{}, &model.SupplierPrice{}, &model.PurchaseOrder{}, &model.PurchaseOrderItem{}, &model.PurchaseReceipt{}, &model.PurchaseReceiptItem{}, &model.BOMLine{}, &model.CommissionRule{}, &model.CommissionTier{}, &model.CommissionStatement{}, &model.SalesTarget{}, &model.DailySalesRollup{}, &model.SavedReport{}, &model.ReportSchedule{}, &model.GeneratedReport{}, &model.Job{}, &model.CompanySettings{}, &model.OrderDocument{}, &model.DocumentSequence{}, &model.DocumentTemplate{}, &model.DocumentTemplateVersion{}, &model.ClientPayment{})
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	ensureDefaultWarehouse()
 }
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"backend/model"
+	"log"
+
+	guuid "github.com/google/uuid"
+)
+
+// ensureDefaultWarehouse создаёт основной склад при первом запуске и переносит на него
+// существующие остатки продуктов, чтобы сумма по складам совпадала с Product.Amount.
+func ensureDefaultWarehouse() {
+	var count int64
+	if err := DB.Model(&model.Warehouse{}).Unscoped().Count(&count).Error; err != nil {
+		log.Fatal(err)
+	}
+	if count > 0 {
+		return
+	}
+
+	warehouse := model.Warehouse{
+		ID:        guuid.New(),
+		Name:      "Основной склад",
+		IsDefault: true,
+	}
+	if err := DB.Create(&warehouse).Error; err != nil {
+		log.Fatal(err)
+	}
+
+	err := DB.Exec(`
+		INSERT INTO warehouse_stocks (id, warehouse_id, product_id, amount, updated_at)
+		SELECT gen_random_uuid(), ?, id, amount, NOW()
+		FROM products
+		WHERE deleted_at IS NULL
+	`, warehouse.ID).Error
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Created default warehouse %s with existing product stock", warehouse.ID)
+}
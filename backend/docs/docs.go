@@ -16,49 +16,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/categories": {
-            "get": {
-                "description": "Эта функция позволяет получить список всех категорий с поддержкой пагинации.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Categories"
-                ],
-                "summary": "Получить все категории",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Номер страницы",
-                        "name": "page",
-                        "in": "query"
-                    },
+        "/2fa/disable": {
+            "post": {
+                "security": [
                     {
-                        "type": "integer",
-                        "description": "Размер страницы",
-                        "name": "pageSize",
-                        "in": "query"
+                        "BearerAuth": []
                     }
                 ],
-                "responses": {
-                    "200": {
-                        "description": "Список категорий",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "500": {
-                        "description": "Ошибка сервера при получении категорий",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            },
-            "post": {
-                "description": "Эта функция позволяет создать новую категорию.",
+                "description": "Отключает 2FA после проверки текущего кода. Недоступно, если 2FA обязательна для роли пользователя.",
                 "consumes": [
                     "application/json"
                 ],
@@ -66,104 +31,105 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Auth"
                 ],
-                "summary": "Создать категорию",
+                "summary": "Отключить 2FA",
                 "parameters": [
                     {
-                        "description": "Данные категории",
-                        "name": "category",
+                        "description": "Код из приложения-аутентификатора",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Category"
+                            "$ref": "#/definitions/handlers.TwoFactorCodeRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Категория успешно создана",
+                    "200": {
+                        "description": "2FA отключена",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.ResponseSuccess"
                         }
                     },
                     "400": {
-                        "description": "Некорректные данные запроса",
+                        "description": "2FA не подключена",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
-                    "409": {
-                        "description": "Категория с таким именем уже существует",
+                    "401": {
+                        "description": "Неверный код",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "2FA обязательна для роли",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании категории",
+                        "description": "Ошибка на сервере",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/categories/{id}": {
-            "get": {
-                "description": "Эта функция позволяет получить информацию о категории по её уникальному идентификатору.",
+        "/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Генерирует новый секрет TOTP и ссылку otpauth:// для QR-кода. 2FA включается только после подтверждения кодом через /2fa/verify.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
-                ],
-                "summary": "Получить категорию",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "UUID категории",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Auth"
                 ],
+                "summary": "Начать подключение 2FA",
                 "responses": {
                     "200": {
-                        "description": "Информация о категории",
+                        "description": "Секрет и ссылка для приложения-аутентификатора",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.TwoFactorEnrollResponse"
                         }
                     },
-                    "400": {
-                        "description": "Некорректный формат UUID",
+                    "404": {
+                        "description": "Пользователь не найден",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
-                    "404": {
-                        "description": "Категория не найдена",
+                    "409": {
+                        "description": "2FA уже подключена",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении категории",
+                        "description": "Ошибка на сервере",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Эта функция позволяет обновить данные категории по её уникальному идентификатору.",
+            }
+        },
+        "/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет все резервные коды новыми после проверки текущего кода из приложения",
                 "consumes": [
                     "application/json"
                 ],
@@ -171,123 +137,120 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Auth"
                 ],
-                "summary": "Обновить категорию",
+                "summary": "Перевыпустить резервные коды",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID категории",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Новые данные категории",
-                        "name": "category",
+                        "description": "Код из приложения-аутентификатора",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.Category"
+                            "$ref": "#/definitions/handlers.TwoFactorCodeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Категория успешно обновлена",
+                        "description": "Новые резервные коды",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.TwoFactorVerifyResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректные данные запроса",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "404": {
-                        "description": "Категория не найдена",
+                        "description": "2FA не подключена",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
-                    "409": {
-                        "description": "Имя категории уже используется",
+                    "401": {
+                        "description": "Неверный код",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при обновлении категории",
+                        "description": "Ошибка на сервере",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Эта функция позволяет удалить категорию из базы данных по её уникальному идентификатору.",
+            }
+        },
+        "/2fa/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Проверяет первый код из приложения, включает 2FA и возвращает резервные коды (показываются один раз). Если запрос сделан с промежуточным токеном подключения, дополнительно возвращается JWT-токен.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Auth"
                 ],
-                "summary": "Удалить категорию",
+                "summary": "Подтвердить подключение 2FA",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID категории",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Код из приложения-аутентификатора",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TwoFactorCodeRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Категория успешно удалена",
+                        "description": "2FA включена",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.TwoFactorVerifyResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректный формат UUID",
+                        "description": "Подключение не начато",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный код",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении категории",
+                        "description": "Ошибка на сервере",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/clients": {
+        "/api-keys": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Эта функция возвращает список всех клиентов с поддержкой пагинации. Продавцы видят только своих клиентов.",
+                "description": "Возвращает выданные API-ключи (без секретной части) с датой последнего использования",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "API Keys"
                 ],
-                "summary": "Получить список клиентов",
+                "summary": "Список API-ключей",
                 "parameters": [
                     {
                         "type": "integer",
@@ -299,23 +262,23 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 10,
-                        "description": "Количество элементов на странице",
-                        "name": "limit",
+                        "description": "Размер страницы",
+                        "name": "pageSize",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список клиентов с информацией о пагинации",
+                        "description": "Список ключей с информацией о пагинации",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Client"
+                                "$ref": "#/definitions/model.APIKey"
                             }
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении списка клиентов",
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -328,7 +291,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Эта функция позволяет продавцам и администраторам добавить нового клиента. Уникальные контактные данные обязательны.",
+                "description": "Создаёт ключ для интеграций. Ключ действует от имени пользователя userId и только в пределах прав вида \"\u003cресурс\u003e:read\" / \"\u003cресурс\u003e:write\".\nКлюч в открытом виде возвращается только в этом ответе, в БД хранится его хэш.",
                 "consumes": [
                     "application/json"
                 ],
@@ -336,41 +299,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "API Keys"
                 ],
-                "summary": "Создать клиента",
+                "summary": "Создать API-ключ",
                 "parameters": [
                     {
-                        "description": "Данные нового клиента",
-                        "name": "client",
+                        "description": "Параметры ключа",
+                        "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.CreateClientRequest"
+                            "$ref": "#/definitions/handlers.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Информация о созданном клиенте",
+                        "description": "Ключ создан",
                         "schema": {
-                            "$ref": "#/definitions/model.Client"
+                            "$ref": "#/definitions/handlers.CreateAPIKeyResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректные данные запроса",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    },
-                    "403": {
-                        "description": "Недостаточно прав для создания клиента",
+                        "description": "Некорректный JSON",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
-                    "409": {
-                        "description": "Контактные данные уже используются другим клиентом",
+                    "404": {
+                        "description": "Пользователь не найден",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -382,7 +339,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании клиента",
+                        "description": "Ошибка на сервере",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -390,108 +347,193 @@ const docTemplate = `{
                 }
             }
         },
-        "/clients/search": {
-            "get": {
-                "description": "Эта функция позволяет искать клиентов по их имени, фамилии или контактным данным.",
+        "/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отзывает ключ, после чего запросы с ним отклоняются",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "API Keys"
                 ],
-                "summary": "Поиск клиентов",
+                "summary": "Отозвать API-ключ",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Текстовый запрос для поиска",
-                        "name": "q",
-                        "in": "query",
+                        "description": "ID ключа",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список найденных клиентов",
+                        "description": "Ключ отозван",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.APIKey"
                         }
                     },
                     "400": {
-                        "description": "Параметр запроса 'q' отсутствует",
+                        "description": "Неверный формат ID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Ключ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при поиске продуктов",
+                        "description": "Ошибка на сервере",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/clients/{id}": {
+        "/audit": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Эта функция возвращает данные клиента по его уникальному идентификатору. Продавцы могут видеть только своих клиентов.",
+                "description": "Возвращает записи журнала изменений с фильтрами по автору, сущности, действию и дате",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Audit"
                 ],
-                "summary": "Получить клиента",
+                "summary": "Журнал изменений",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID клиента",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "ID пользователя, выполнившего действие",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип сущности (user, client, category, product, order)",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID сущности",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Действие (create, update, delete)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Не раньше даты (YYYY-MM-DD)",
+                        "name": "dateGte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Не позже даты (YYYY-MM-DD)",
+                        "name": "dateLte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Информация о клиенте",
+                        "description": "Записи журнала с информацией о пагинации",
                         "schema": {
-                            "$ref": "#/definitions/model.Client"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AuditEntry"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Некорректный формат UUID",
+                        "description": "Ошибка валидации параметров запроса",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
-                    "404": {
-                        "description": "Клиент не найден",
+                    "500": {
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Эта функция позволяет получить список всех категорий с поддержкой пагинации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Получить все категории",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список категорий",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении данных клиента",
+                        "description": "Ошибка сервера при получении категорий",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             },
-            "put": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Эта функция позволяет обновить информацию о клиенте, включая имя, контактные данные и адрес.",
+            "post": {
+                "description": "Эта функция позволяет создать новую категорию.",
                 "consumes": [
                     "application/json"
                 ],
@@ -499,84 +541,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Categories"
                 ],
-                "summary": "Обновить клиента",
+                "summary": "Создать категорию",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "UUID клиента",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для обновления клиента",
-                        "name": "client",
+                        "description": "Данные категории",
+                        "name": "category",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateClientRequest"
+                            "$ref": "#/definitions/model.Category"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Информация об обновлённом клиенте",
+                    "201": {
+                        "description": "Категория успешно создана",
                         "schema": {
-                            "$ref": "#/definitions/model.Client"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Некорректный формат UUID или запроса",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    },
-                    "404": {
-                        "description": "Клиент не найден",
+                        "description": "Некорректные данные запроса",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "409": {
-                        "description": "Контактные данные уже используются другим клиентом",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    },
-                    "422": {
-                        "description": "Ошибка валидации данных",
+                        "description": "Категория с таким именем уже существует",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при обновлении клиента",
+                        "description": "Ошибка сервера при создании категории",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Эта функция позволяет удалить клиента из базы данных по его уникальному идентификатору.",
+            }
+        },
+        "/categories/{id}": {
+            "get": {
+                "description": "Эта функция позволяет получить информацию о категории по её уникальному идентификатору.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Clients"
+                    "Categories"
                 ],
-                "summary": "Удалить клиента",
+                "summary": "Получить категорию",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID клиента",
+                        "description": "UUID категории",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -584,153 +608,161 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Клиент успешно удалён",
+                        "description": "Информация о категории",
                         "schema": {
-                            "$ref": "#/definitions/model.Client"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
                         "description": "Некорректный формат UUID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера при удалении клиента",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    }
-                }
-            }
-        },
-        "/exports/clients": {
-            "get": {
-                "description": "Эта функция позволяет экспортировать список клиентов в формате Excel",
-                "produces": [
-                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
-                ],
-                "tags": [
-                    "Exports"
-                ],
-                "summary": "Экспорт клиентов в Excel",
-                "responses": {
-                    "200": {
-                        "description": "Excel-файл с клиентами",
+                    "404": {
+                        "description": "Категория не найдена",
                         "schema": {
-                            "type": "file"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Ошибка при поиске клиентов",
+                        "description": "Ошибка сервера при получении категории",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/exports/products": {
-            "get": {
-                "description": "Эта функция позволяет экспортировать список продуктов в формате Excel",
+            },
+            "put": {
+                "description": "Эта функция позволяет обновить данные категории по её уникальному идентификатору.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
-                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                    "application/json"
                 ],
                 "tags": [
-                    "Exports"
+                    "Categories"
                 ],
-                "summary": "Экспорт продуктов в Excel",
-                "responses": {
-                    "200": {
-                        "description": "Excel-файл с продуктами",
+                "summary": "Обновить категорию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные категории",
+                        "name": "category",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "file"
+                            "$ref": "#/definitions/model.Category"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Категория успешно обновлена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректные данные запроса",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "404": {
+                        "description": "Категория не найдена",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "409": {
+                        "description": "Имя категории уже используется",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Ошибка при поиске продуктов",
+                        "description": "Ошибка сервера при обновлении категории",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
                         }
                     }
                 }
-            }
-        },
-        "/login": {
-            "post": {
-                "description": "Эта функция позволяет пользователю войти в систему с помощью имени пользователя и пароля, и получить JWT-токен для дальнейшей аутентификации.",
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "description": "Эта функция позволяет удалить категорию из базы данных по её уникальному идентификатору.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Categories"
                 ],
-                "summary": "Авторизация пользователя",
+                "summary": "Удалить категорию",
                 "parameters": [
                     {
-                        "description": "Данные для авторизации",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "UUID категории",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешная авторизация, возвращается JWT-токен",
+                        "description": "Категория успешно удалена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "Некорректный формат JSON",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    },
-                    "401": {
-                        "description": "Неверный пароль",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    },
-                    "404": {
-                        "description": "Имя пользователя не найдено",
+                        "description": "Некорректный формат UUID",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Ошибка при генерации токена",
+                        "description": "Ошибка сервера при удалении категории",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
             }
         },
-        "/orders": {
+        "/clients": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Возвращает список заказов с возможностью фильтрации по дате и пагинации.",
+                "description": "Эта функция возвращает список всех клиентов с поддержкой пагинации. Продавцы видят только своих клиентов.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Clients"
                 ],
-                "summary": "Получить список заказов с поддержкой фильтрации и пагинации",
+                "summary": "Получить список клиентов",
                 "parameters": [
                     {
                         "type": "integer",
@@ -745,35 +777,20 @@ const docTemplate = `{
                         "description": "Количество элементов на странице",
                         "name": "limit",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по дате (не раньше, чем) в формате YYYY-MM-DD",
-                        "name": "dateGte",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Фильтр по дате (не позже, чем) в формате YYYY-MM-DD",
-                        "name": "dateLte",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список заказов с информацией о пагинации",
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateOrderRequest"
-                        }
-                    },
-                    "400": {
-                        "description": "Ошибка валидации параметров запроса",
+                        "description": "Список клиентов с информацией о пагинации",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Client"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении списка заказов",
+                        "description": "Ошибка сервера при получении списка клиентов",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -786,7 +803,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Эта функция позволяет продавцам и администраторам создать новый заказ. Заказ должен содержать как минимум один продукт.",
+                "description": "Эта функция позволяет продавцам и администраторам добавить нового клиента. Уникальные контактные данные обязательны.",
                 "consumes": [
                     "application/json"
                 ],
@@ -794,41 +811,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Clients"
                 ],
-                "summary": "Создать заказ",
+                "summary": "Создать клиента",
                 "parameters": [
                     {
-                        "description": "Данные нового заказа",
-                        "name": "order",
+                        "description": "Данные нового клиента",
+                        "name": "client",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateOrderRequest"
+                            "$ref": "#/definitions/handlers.CreateClientRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Информация о созданном заказе",
+                        "description": "Информация о созданном клиенте",
                         "schema": {
-                            "$ref": "#/definitions/model.Order"
+                            "$ref": "#/definitions/model.Client"
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос или некорректные данные заказа",
+                        "description": "Некорректные данные запроса",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "403": {
-                        "description": "Недостаточно прав для создания заказа",
+                        "description": "Недостаточно прав для создания клиента",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
-                    "404": {
-                        "description": "Один из указанных продуктов не найден",
+                    "409": {
+                        "description": "Контактные данные уже используются другим клиентом",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -840,79 +857,50 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании заказа",
+                        "description": "Ошибка сервера при создании клиента",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
-            },
-            "patch": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
+            }
+        },
+        "/clients/search": {
+            "get": {
+                "description": "Эта функция позволяет искать клиентов по их имени, фамилии или контактным данным.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Clients"
                 ],
-                "summary": "Изменить можно любой",
+                "summary": "Поиск клиентов",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID продукта",
-                        "name": "id",
-                        "in": "path",
+                        "description": "Текстовый запрос для поиска",
+                        "name": "q",
+                        "in": "query",
                         "required": true
-                    },
-                    {
-                        "description": "Данные для обновления заказа",
-                        "name": "order",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.CreateOrderRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Измененный объект заказа",
+                        "description": "Список найденных клиентов",
                         "schema": {
-                            "$ref": "#/definitions/model.Order"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сервера при обновлении данных",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    }
-                }
-            }
-        },
-        "/orders/pdf/{id}": {
-            "get": {
-                "description": "Эта функция позволяет экспортировать информацию о заказе в формате PDF",
-                "produces": [
-                    "application/pdf"
-                ],
-                "tags": [
-                    "Orders"
-                ],
-                "summary": "Экспорт заказа в PDF",
-                "responses": {
-                    "200": {
-                        "description": "PDF-файл с информацией о заказе",
+                    "400": {
+                        "description": "Параметр запроса 'q' отсутствует",
                         "schema": {
-                            "type": "file"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
-                        "description": "Ошибка генерации PDF",
+                        "description": "Ошибка при поиске продуктов",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -921,25 +909,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
+        "/clients/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Эта функция возвращает информацию о заказе по его уникальному идентификатору. Заказ включает все продукты в нём.",
+                "description": "Эта функция возвращает данные клиента по его уникальному идентификатору. Продавцы могут видеть только своих клиентов.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Clients"
                 ],
-                "summary": "Получить заказ",
+                "summary": "Получить клиента",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID заказа",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -947,9 +935,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Информация о заказе",
+                        "description": "Информация о клиенте",
                         "schema": {
-                            "$ref": "#/definitions/model.Order"
+                            "$ref": "#/definitions/model.Client"
                         }
                     },
                     "400": {
@@ -959,90 +947,131 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Заказ не найден",
+                        "description": "Клиент не найден",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении данных заказа",
+                        "description": "Ошибка сервера при получении данных клиента",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
             },
-            "delete": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Эта функция позволяет обновить информацию о клиенте, включая имя, контактные данные и адрес.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Clients"
                 ],
-                "summary": "Удаление заказа",
+                "summary": "Обновить клиента",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "UUID заказа",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные для обновления клиента",
+                        "name": "client",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateClientRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешно",
+                        "description": "Информация об обновлённом клиенте",
                         "schema": {
-                            "$ref": "#/definitions/model.Order"
+                            "$ref": "#/definitions/model.Client"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный формат UUID или запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Клиент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Контактные данные уже используются другим клиентом",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при удалении",
+                        "description": "Ошибка сервера при обновлении клиента",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
-            }
-        },
-        "/orders/{id}/accept": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Принимает заказ и изменяет его статус на \"в производстве\"",
+                "description": "Эта функция позволяет удалить клиента из базы данных по его уникальному идентификатору.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Clients"
                 ],
-                "summary": "Принять заказ",
+                "summary": "Удалить клиента",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID заказа",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Заказ успешно принят",
+                    "200": {
+                        "description": "Клиент успешно удалён",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ResponseSuccess"
+                            "$ref": "#/definitions/model.Client"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Некорректный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении клиента",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1050,57 +1079,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}/reject": {
-            "post": {
+        "/clients/{id}/payments": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Отклоняет заказ и изменяет его статус на \"rejected\"",
+                "description": "Возвращает оплаты клиента, новые сначала",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Clients"
                 ],
-                "summary": "Отклонить заказ",
+                "summary": "Оплаты клиента",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID заказа",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Заказ успешно отклонен",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.ResponseSuccess"
-                        }
                     },
-                    "400": {
-                        "description": "Неверный формат UUID",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    }
-                }
-            }
-        },
-        "/products": {
-            "get": {
-                "description": "Эта функция возвращает список всех продуктов с пагинацией и возможностью подгрузки категорий",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Products"
-                ],
-                "summary": "Получить список продуктов",
-                "parameters": [
                     {
                         "type": "integer",
                         "default": 1,
@@ -1111,23 +1112,35 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "default": 10,
-                        "description": "Количество элементов на странице",
-                        "name": "limit",
+                        "description": "Размер страницы",
+                        "name": "pageSize",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список продуктов с информацией о пагинации",
+                        "description": "Оплаты с информацией о пагинации",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Product"
+                                "$ref": "#/definitions/model.ClientPayment"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Некорректный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Клиент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
                     "500": {
-                        "description": "Ошибка сервера при получении списка продуктов",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1135,7 +1148,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Эта функция позволяет создать новый продукт",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Записывает полученную от клиента оплату. Оплаты уменьшают сальдо в выписке по клиенту. Продавцы записывают оплаты только своих клиентов.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1143,29 +1161,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Clients"
                 ],
-                "summary": "Создать продукт",
+                "summary": "Записать оплату клиента",
                 "parameters": [
                     {
-                        "description": "Данные нового продукта",
-                        "name": "product",
+                        "type": "string",
+                        "description": "UUID клиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Оплата",
+                        "name": "payment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateProductRequest"
+                            "$ref": "#/definitions/model.CreateClientPaymentRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Информация о созданном продукте",
+                        "description": "Оплата записана",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "$ref": "#/definitions/model.ClientPayment"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат запроса",
+                        "description": "Некорректный запрос или заказ другого клиента",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Клиент не найден",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1177,7 +1208,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при создании продукта",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1185,131 +1216,175 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/search": {
-            "get": {
-                "description": "Эта функция позволяет искать продукты на основе текстового запроса",
+        "/clients/{id}/payments/{paymentId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет ошибочно записанную оплату",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Clients"
                 ],
-                "summary": "Поиск продуктов",
+                "summary": "Удалить оплату клиента",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Текстовый запрос для поиска",
-                        "name": "q",
-                        "in": "query",
+                        "description": "UUID клиента",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID оплаты",
+                        "name": "paymentId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список найденных продуктов",
+                        "description": "Оплата удалена",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.ClientPayment"
                         }
                     },
                     "400": {
-                        "description": "Параметр запроса 'q' отсутствует",
+                        "description": "Некорректный формат UUID",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Оплата не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при поиске продуктов",
+                        "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/products/{id}": {
+        "/clients/{id}/statement.pdf": {
             "get": {
-                "description": "Эта функция возвращает информацию о продукте по его уникальному идентификатору",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Печатает выписку по расчётам с клиентом за период: сальдо на начало, заказы и оплаты по датам с текущим сальдо, обороты и сальдо на конец.\nСальдо — начальный баланс клиента (balance) плюс сумма заказов, входящих в выручку (как в статистике, по REVENUE_RECOGNITION), минус оплаты из /clients/{id}/payments. Продавцы получают выписки только своих клиентов.",
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "Products"
+                    "Clients"
                 ],
-                "summary": "Получить продукт",
+                "summary": "Выписка по клиенту",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID продукта",
+                        "description": "UUID клиента",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD), по умолчанию начало месяца",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD), по умолчанию сегодня",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "ru",
+                        "description": "Язык документа (ru, uz)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Информация о продукте",
+                        "description": "PDF-документ",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос или отсутствующий ID",
+                        "description": "Неверные параметры запроса",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "404": {
-                        "description": "Продукт не найден",
+                        "description": "Клиент не найден",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при получении продукта",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Эта функция удаляет продукт по его уникальному идентификатору",
+            }
+        },
+        "/commissions/rules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Удалить продукт",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID продукта",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Commissions"
                 ],
+                "summary": "Получить правила комиссии",
                 "responses": {
                     "200": {
-                        "description": "Сообщение об успешном удалении",
+                        "description": "Правила комиссии",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CommissionRule"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат запроса или отсутствующий ID",
+                    "500": {
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
             },
-            "patch": {
-                "description": "Эта функция обновляет информацию о продукте по его уникальному идентификатору",
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт правило комиссии для продавца или, без sellerId, правило по умолчанию. У продавца может быть только одно правило.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1317,48 +1392,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Commissions"
                 ],
-                "summary": "Обновить продукт",
+                "summary": "Создать правило комиссии",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID продукта",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для обновления продукта",
-                        "name": "product",
+                        "description": "Ставка и уровни",
+                        "name": "rule",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.UpdateProductRequest"
+                            "$ref": "#/definitions/model.CommissionRuleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Информация об обновлённом продукте",
+                    "201": {
+                        "description": "Правило создано",
                         "schema": {
-                            "$ref": "#/definitions/model.Product"
+                            "$ref": "#/definitions/model.CommissionRule"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат запроса или отсутствующий ID",
+                        "description": "Некорректный запрос",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "404": {
-                        "description": "Продукт не найден",
+                        "description": "Продавец не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Правило уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при обновлении продукта",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1366,40 +1446,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/statistics": {
-            "get": {
-                "description": "Возвращает информацию о том, сколько продукта было произведено и продано за текущий месяц",
+        "/commissions/rules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет ставку и уровни правила. Продавец правила не меняется. Утверждённые начисления не пересчитываются.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Commissions"
                 ],
-                "summary": "Получить статистику продукта",
+                "summary": "Изменить правило комиссии",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID продукта",
+                        "description": "ID правила",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Ставка и уровни",
+                        "name": "rule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CommissionRuleRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Статистика продукта",
+                        "description": "Правило обновлено",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ProductStatistics"
+                            "$ref": "#/definitions/model.CommissionRule"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат запроса или отсутствующий ID",
+                        "description": "Некорректный запрос",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "404": {
-                        "description": "Продукт не найден",
+                        "description": "Правило не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1411,37 +1514,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/statistics/chart": {
-            "get": {
-                "description": "Возвращает статистику продаж за последний месяц или год",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Statistics"
+                    "Commissions"
                 ],
-                "summary": "Получить статистику продаж",
+                "summary": "Удалить правило комиссии",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Период статистики (month, year)",
-                        "name": "period",
-                        "in": "query"
+                        "description": "ID правила",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список со статистикой всех продуктов",
+                        "description": "Правило удалено",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "array",
-                                "items": {
-                                    "$ref": "#/definitions/handlers.SalesData"
-                                }
-                            }
+                            "$ref": "#/definitions/model.CommissionRule"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Правило не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
@@ -1453,34 +1565,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/statistics/dashboard": {
+        "/commissions/statements": {
             "get": {
-                "description": "Возвращает топ 10 клиентов и продуктов по сумме потраченных денег и проданных единиц за текущий месяц",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Продавец видит только свои начисления",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Statistics"
+                    "Commissions"
                 ],
-                "summary": "Получить топ клиентов и продуктов",
+                "summary": "Получить начисления комиссий",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Период статистики (month, year)",
-                        "name": "period",
+                        "description": "Месяц (YYYY-MM)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID продавца",
+                        "name": "sellerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус (draft, approved)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список со статистикой всех продуктов",
+                        "description": "Начисления с информацией о пагинации",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.DashboardResponse"
+                                "$ref": "#/definitions/model.CommissionStatement"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
@@ -1488,28 +1637,57 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/statistics/products": {
-            "get": {
-                "description": "Возвращает статистику по всем продуктам, включая количество произведенного и проданного за текущий месяц",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Считает комиссию каждого продавца за месяц по выручке заказов, доставленных в этом месяце (по часовому поясу бизнеса). Черновики пересчитываются, утверждённые начисления не меняются.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Statistics"
+                    "Commissions"
+                ],
+                "summary": "Сформировать ведомость комиссий",
+                "parameters": [
+                    {
+                        "description": "Месяц (YYYY-MM)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.GenerateCommissionsRequest"
+                        }
+                    }
                 ],
-                "summary": "Получить статистику всех продуктов",
                 "responses": {
                     "200": {
-                        "description": "Список со статистикой всех продуктов",
+                        "description": "Начисления за месяц",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handlers.ProductStatistics"
+                                "$ref": "#/definitions/model.CommissionStatement"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
                     "500": {
                         "description": "Ошибка сервера",
                         "schema": {
@@ -1519,92 +1697,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/upload": {
+        "/commissions/statements/{id}/approve": {
             "post": {
-                "description": "Эта функция позволяет загружать одно изображение на сервер с проверкой размера и типа файла",
-                "consumes": [
-                    "multipart/form-data"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Утверждённое начисление не пересчитывается при повторном формировании ведомости",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Images"
+                    "Commissions"
                 ],
-                "summary": "Загрузка одной картинки",
+                "summary": "Утвердить начисление комиссии",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Файл изображения",
-                        "name": "image",
-                        "in": "formData",
+                        "type": "string",
+                        "description": "ID начисления",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Информация о загруженном файле: имя и URL",
+                    "200": {
+                        "description": "Начисление утверждено",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.CommissionStatement"
                         }
                     },
                     "400": {
-                        "description": "Неверный запрос или ошибка валидации",
+                        "description": "Начисление уже утверждено",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Начисление не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при сохранении файла",
+                        "description": "Ошибка сервера",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
             }
         },
-        "/uploadMany": {
-            "post": {
-                "description": "Эта функция позволяет загружать несколько изображений на сервер",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+        "/exports/clients": {
+            "get": {
+                "description": "Эта функция позволяет экспортировать список клиентов в формате Excel",
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Images"
+                    "Exports"
                 ],
-                "summary": "Загрузить несколько изображений",
+                "summary": "Экспорт клиентов в Excel",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Файлы изображений",
-                        "name": "images",
-                        "in": "formData",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Сформировать файл в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Список загруженных файлов с их URL",
+                    "200": {
+                        "description": "Excel-файл с клиентами",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "file"
                         }
                     },
-                    "400": {
-                        "description": "Неверный запрос или ошибка валидации",
+                    "202": {
+                        "description": "Задача поставлена в очередь",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/model.Job"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера при сохранении файла",
+                        "description": "Ошибка при поиске клиентов",
                         "schema": {
                             "type": "object",
                             "additionalProperties": true
@@ -1613,95 +1790,128 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/exports/commissions": {
             "get": {
-                "description": "Возвращает список всех пользователей с поддержкой пагинации",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Выгружает начисления комиссий за месяц в Excel",
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Users"
+                    "Export"
                 ],
-                "summary": "Получить список пользователей",
+                "summary": "Экспорт ведомости комиссий",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Номер страницы (по умолчанию 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Размер страницы (по умолчанию 10)",
-                        "name": "size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Месяц (YYYY-MM)",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список пользователей",
+                        "description": "Excel-файл",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
-                            }
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Создаёт нового пользователя в системе",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/exports/order-items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Выгружает позиции заказов с теми же фильтрами по заказу, что и список заказов. Файл формируется потоком, поэтому подходит для больших выгрузок; для более чем миллиона строк используйте CSV.",
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/csv"
                 ],
                 "tags": [
-                    "Users"
+                    "Exports"
                 ],
-                "summary": "Создать пользователя",
+                "summary": "Экспорт позиций заказов",
                 "parameters": [
                     {
-                        "description": "Данные пользователя",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.CreateUserRequest"
-                        }
+                        "type": "string",
+                        "default": "xlsx",
+                        "description": "Формат (xlsx, csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую: orderId, productId, productName, unit, categoryId, categoryName, quantity, totalPrice, unitCost, totalCost, margin, status, paymentMethod, createdAt, clientId, clientName, sellerId, sellerName, warehouseId",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате заказа (не раньше, чем) в формате YYYY-MM-DD",
+                        "name": "dateGte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате заказа (не позже, чем) в формате YYYY-MM-DD",
+                        "name": "dateLte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по складу",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сформировать файл в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Пользователь успешно создан",
+                    "200": {
+                        "description": "Файл с позициями заказов",
                         "schema": {
-                            "$ref": "#/definitions/model.User"
+                            "type": "file"
                         }
                     },
-                    "400": {
-                        "description": "Некорректный JSON",
+                    "202": {
+                        "description": "Задача поставлена в очередь",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "$ref": "#/definitions/model.Job"
                         }
                     },
-                    "422": {
-                        "description": "Ошибка валидации данных",
+                    "400": {
+                        "description": "Неверные параметры запроса",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1709,46 +1919,82 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/search": {
+        "/exports/orders": {
             "get": {
-                "description": "Ищет пользователей по заданному запросу",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Выгружает заказы с теми же фильтрами, что и список заказов. Файл формируется потоком, поэтому подходит для больших выгрузок; для более чем миллиона строк используйте CSV.",
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/csv"
                 ],
                 "tags": [
-                    "Users"
+                    "Exports"
                 ],
-                "summary": "Поиск пользователей",
+                "summary": "Экспорт заказов",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Поисковый запрос",
-                        "name": "q",
-                        "in": "query",
-                        "required": true
+                        "default": "xlsx",
+                        "description": "Формат (xlsx, csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Колонки через запятую: id, createdAt, status, paymentMethod, totalPrice, acceptedAt, deliveredAt, clientId, clientName, sellerId, sellerName, warehouseId, warehouseName",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате (не раньше, чем) в формате YYYY-MM-DD",
+                        "name": "dateGte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате (не позже, чем) в формате YYYY-MM-DD",
+                        "name": "dateLte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по складу",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сформировать файл в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Результаты поиска пользователей",
+                        "description": "Файл с заказами",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.User"
-                            }
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Задача поставлена в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/model.Job"
                         }
                     },
                     "400": {
-                        "description": "Отсутствует параметр запроса 'q'",
+                        "description": "Неверные параметры запроса",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка при поиске пользователей",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1756,160 +2002,226 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/exports/products": {
             "get": {
-                "description": "Возвращает информацию о пользователе по его ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Эта функция позволяет экспортировать список продуктов в формате Excel",
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Users"
+                    "Exports"
                 ],
-                "summary": "Получить пользователя",
+                "summary": "Экспорт продуктов в Excel",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Сформировать файл в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Данные пользователя",
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный формат ID",
+                        "description": "Excel-файл с продуктами",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "file"
                         }
                     },
-                    "404": {
-                        "description": "Пользователь не найден",
+                    "202": {
+                        "description": "Задача поставлена в очередь",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "$ref": "#/definitions/model.Job"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Ошибка при поиске продуктов",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Обновляет информацию о пользователе по его ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/exports/stocktakes/{id}": {
+            "get": {
+                "description": "Выгружает строки инвентаризации в Excel: ожидаемый и фактический остаток, расхождение в количестве и в деньгах",
                 "produces": [
-                    "application/json"
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Users"
+                    "Exports"
                 ],
-                "summary": "Обновить данные пользователя",
+                "summary": "Отчёт о расхождениях инвентаризации",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID пользователя",
+                        "description": "ID инвентаризации",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Обновляемые данные пользователя",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handlers.UpdateUserRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Пользователь успешно обновлён",
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
-                        }
-                    },
-                    "400": {
-                        "description": "Неверный формат ID или JSON",
+                        "description": "Excel-файл с расхождениями",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "file"
                         }
                     },
                     "404": {
-                        "description": "Пользователь не найден",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    },
-                    "422": {
-                        "description": "Ошибка валидации данных",
-                        "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
-                        }
-                    },
-                    "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Инвентаризация не найдена",
                         "schema": {
-                            "$ref": "#/definitions/handlers.APIError"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Удаляет пользователя из системы по его ID",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/getstatsof/seller": {
+            "get": {
+                "description": "Возвращает статистику продаж текущего продавца. Администратор и менеджер могут указать sellerId, в том числе деактивированного продавца. Параметры периода те же, что у /statistics/chart.\nС withTarget=true ответ — объект SellerSalesChart со статистикой и выполнением плана продавца на месяц month (по умолчанию текущий).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Statistics"
                 ],
-                "summary": "Удалить пользователя",
+                "summary": "Получить статистику продаж продавца",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID пользователя",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Пользователь успешно удалён",
-                        "schema": {
-                            "$ref": "#/definitions/model.User"
+                        "description": "Скользящий период (week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Шаг (day, week, month, quarter)",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сравнить с предыдущим периодом",
+                        "name": "compare",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID продавца (только для admin и manager)",
+                        "name": "sellerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Добавить выполнение плана продаж",
+                        "name": "withTarget",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Месяц плана (YYYY-MM), по умолчанию текущий",
+                        "name": "month",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статистика продаж по датам (SellerSalesChart при withTarget=true)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/handlers.SalesData"
+                                }
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный формат ID",
+                        "description": "Неверные параметры запроса",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
-                    "404": {
-                        "description": "Пользователь не найден",
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/getstatsof/seller/at-risk": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Клиенты текущего продавца из сегмента at_risk, самые ценные первыми. Администратор и менеджер могут указать sellerId.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Клиенты продавца под риском оттока",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продавца (только для admin и manager)",
+                        "name": "sellerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "Порог риска оттока в днях",
+                        "name": "atRiskDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 180,
+                        "description": "Порог потерянного клиента в днях",
+                        "name": "lostDays",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Клиенты под риском оттока",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ClientRFM"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     },
                     "500": {
-                        "description": "Ошибка на сервере",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1917,39 +2229,197 @@ const docTemplate = `{
                 }
             }
         },
-        "/warehouse/{id}/delivered": {
+        "/imports/clients": {
+            "post": {
+                "description": "Загружает клиентов из XLSX или CSV с колонками выгрузки /exports/clients (Name, Surname, Contact-info, Address, Balance).\nКлиент ищется по Contact-info, а если она не заполнена — по имени и фамилии среди клиентов без контактов.\nBalance задаёт начальный баланс (долг клиента, входит в сальдо выписки) только новых клиентов; Registered игнорируется. Новые клиенты закрепляются за загрузившим пользователем.\nВсе строки проверяются и сохраняются в одной транзакции: при любой ошибке ничего не сохраняется.\nС async=true файл проверяется и ставится в очередь, результат доступен в /jobs/{id}.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Импорт клиентов",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл .xlsx или .csv",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить файл и показать изменения, не сохраняя их",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Выполнить в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат импорта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Задача поставлена в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/model.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Файл не передан или не читается",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибки в строках файла",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/imports/products": {
             "post": {
+                "description": "Загружает продукты из XLSX или CSV с колонками выгрузки /exports/products (Name, Price, Category, Width, Height, Unit, Amount).\nПродукт ищется по названию без учёта регистра: найденный обновляется, иначе создаётся. Категория указывается по названию и должна существовать.\nОтсутствующие в файле колонки не меняются. Количество приходуется на основной склад.\nВсе строки проверяются и сохраняются в одной транзакции: при любой ошибке ничего не сохраняется.\nС async=true файл проверяется и ставится в очередь, результат доступен в /jobs/{id}.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Imports"
+                ],
+                "summary": "Импорт продуктов",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл .xlsx или .csv",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только проверить файл и показать изменения, не сохраняя их",
+                        "name": "dryRun",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Выполнить в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат импорта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportResult"
+                        }
+                    },
+                    "202": {
+                        "description": "Задача поставлена в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/model.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Файл не передан или не читается",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибки в строках файла",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ImportResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "изменяет статус заказа на \"delivered\"",
+                "description": "Возвращает задачи текущего пользователя, новые сначала. Администратор видит задачи всех пользователей.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "warehouse"
+                    "Jobs"
                 ],
-                "summary": "Доставлен",
+                "summary": "Список фоновых задач",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID заказа",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Статус (queued, running, succeeded, failed)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Тип задачи (export.orders, export.products, export.clients, import.products, import.clients, pdf.order)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Заказ успешно изменен",
+                    "200": {
+                        "description": "Задачи с информацией о пагинации",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ResponseSuccess"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Job"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Неверный формат UUID",
+                    "500": {
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
@@ -1957,364 +2427,9118 @@ const docTemplate = `{
                 }
             }
         },
-        "/warehouse/{id}/in_production": {
-            "post": {
+        "/jobs/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "изменяет статус заказа на \"ready\"",
+                "description": "Возвращает статус, прогресс и результат задачи. Когда status = succeeded и задан resultFileName, файл доступен по /jobs/{id}/download.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "warehouse"
+                    "Jobs"
                 ],
-                "summary": "Готово",
+                "summary": "Статус фоновой задачи",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "ID заказа",
+                        "description": "ID задачи",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Заказ успешно изменен",
+                    "200": {
+                        "description": "Задача",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ResponseSuccess"
+                            "$ref": "#/definitions/model.Job"
                         }
                     },
                     "400": {
-                        "description": "Неверный формат UUID",
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Задача другого пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
                         "schema": {
                             "$ref": "#/definitions/handlers.APIError"
                         }
                     }
                 }
-            }
-        }
-    },
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отменяет задачу в очереди или удаляет завершённую вместе с файлами. Выполняющуюся задачу удалить нельзя.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Удалить фоновую задачу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Задача удалена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Задача другого пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Задача выполняется",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/jobs/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Jobs"
+                ],
+                "summary": "Скачать результат фоновой задачи",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID задачи",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл результата",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Задача другого пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Задача не найдена или файл ещё не готов",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Эта функция позволяет пользователю войти в систему с помощью имени пользователя и пароля, и получить JWT-токен для дальнейшей аутентификации.\nЕсли у пользователя включена 2FA (или она обязательна для его роли), вместо токена возвращается промежуточный preAuthToken, действующий 5 минут.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Авторизация пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные для авторизации",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Требуется второй шаг входа",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TwoFactorLoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный формат JSON",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный пароль",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь деактивирован",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Имя пользователя не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при генерации токена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/login/2fa": {
+            "post": {
+                "description": "Принимает промежуточный токен из /login и код из приложения-аутентификатора (или резервный код) и возвращает JWT-токен.\nПо одному промежуточному токену даётся 5 попыток; после них нужно снова войти с паролем через /login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Второй шаг авторизации",
+                "parameters": [
+                    {
+                        "description": "Промежуточный токен и код",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TwoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешная авторизация, возвращается JWT-токен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный формат JSON",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "401": {
+                        "description": "Неверный код или истёкший токен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "429": {
+                        "description": "Превышено число попыток, нужен новый вход с паролем",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при генерации токена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает уведомления текущего пользователя, новые сначала",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Мои уведомления",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Только непрочитанные",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Уведомления с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Notification"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/{id}/read": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Notifications"
+                ],
+                "summary": "Отметить уведомление прочитанным",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID уведомления",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Уведомление прочитано",
+                        "schema": {
+                            "$ref": "#/definitions/model.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Уведомление не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список заказов с возможностью фильтрации по дате и пагинации.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Получить список заказов с поддержкой фильтрации и пагинации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Количество элементов на странице",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате (не раньше, чем) в формате YYYY-MM-DD",
+                        "name": "dateGte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по дате (не позже, чем) в формате YYYY-MM-DD",
+                        "name": "dateLte",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Фильтр по складу",
+                        "name": "warehouseId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список заказов с информацией о пагинации",
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateOrderRequest"
+                        }
+                    },
+                    "400": {
+                        "description": "Ошибка валидации параметров запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении списка заказов",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Эта функция позволяет продавцам и администраторам создать новый заказ. Заказ должен содержать как минимум один продукт.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Создать заказ",
+                "parameters": [
+                    {
+                        "description": "Данные нового заказа",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Информация о созданном заказе",
+                        "schema": {
+                            "$ref": "#/definitions/model.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или некорректные данные заказа",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Недостаточно прав для создания заказа",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Один из указанных продуктов не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании заказа",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Изменить можно любой",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления заказа",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Измененный объект заказа",
+                        "schema": {
+                            "$ref": "#/definitions/model.Order"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при обновлении данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/pdf/{id}": {
+            "get": {
+                "description": "Печатает счёт-фактуру по заказу; то же, что /orders/{id}/documents/invoice. С async=true PDF формируется в фоновой задаче.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Экспорт заказа в PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "ru",
+                        "description": "Язык документа (ru, uz)",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сформировать PDF в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF-файл с информацией о заказе",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Задача поставлена в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/model.Job"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка генерации PDF",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Эта функция возвращает информацию о заказе по его уникальному идентификатору. Заказ включает все продукты в нём.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Получить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация о заказе",
+                        "schema": {
+                            "$ref": "#/definitions/model.Order"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении данных заказа",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Удаление заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "UUID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно",
+                        "schema": {
+                            "$ref": "#/definitions/model.Order"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при удалении",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает заказ и изменяет его статус на \"в производстве\"",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Принять заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ успешно принят",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/documents": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает выданные по заказу документы с номерами",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Документы заказа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Документы заказа",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.OrderDocument"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/documents/{type}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Печатает документ по заказу: счёт-фактуру (invoice), накладную без цен (delivery_note), лист комплектации (pick_list) или квитанцию об оплате (receipt).\nНомер присваивается при первой печати (например, INV-2026-000123) и не меняется при повторной. Реквизиты берутся из /settings/company.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Печать документа по заказу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Вид документа (invoice, delivery_note, pick_list, receipt)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "ru",
+                        "description": "Язык документа (ru, uz)",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сформировать PDF в фоновой задаче",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF-документ",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Задача поставлена в очередь",
+                        "schema": {
+                            "$ref": "#/definitions/model.Job"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный вид документа или язык",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Заказ отклонён",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отклоняет заказ и изменяет его статус на \"rejected\"",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Отклонить заказ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ успешно отклонен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Эта функция возвращает список всех продуктов с пагинацией и возможностью подгрузки категорий",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Получить список продуктов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Количество элементов на странице",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список продуктов с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Product"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении списка продуктов",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Эта функция позволяет создать новый продукт",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Создать продукт",
+                "parameters": [
+                    {
+                        "description": "Данные нового продукта",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Информация о созданном продукте",
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при создании продукта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/search": {
+            "get": {
+                "description": "Эта функция позволяет искать продукты на основе текстового запроса",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Поиск продуктов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Текстовый запрос для поиска",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список найденных продуктов",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Параметр запроса 'q' отсутствует",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при поиске продуктов",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/products/stat/{id}": {
+            "get": {
+                "description": "Возвращает информацию о том, сколько продукта было произведено и продано за текущий месяц",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Получить статистику продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статистика продукта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ProductStatistics"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса или отсутствующий ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Эта функция возвращает информацию о продукте по его уникальному идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Получить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация о продукте",
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или отсутствующий ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при получении продукта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Эта функция удаляет продукт по его уникальному идентификатору",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Удалить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сообщение об успешном удалении",
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса или отсутствующий ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Эта функция обновляет информацию о продукте по его уникальному идентификатору",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Обновить продукт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления продукта",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Информация об обновлённом продукте",
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса или отсутствующий ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при обновлении продукта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/bom": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает компоненты, из которых изготавливается продукт, и их расход на единицу",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Спецификация продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Строки спецификации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BOMLine"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Полностью заменяет список компонентов продукта. Пустой список удаляет спецификацию.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Задать спецификацию продукта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Компоненты и расход на единицу",
+                        "name": "bom",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SetBOMRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Новая спецификация",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BOMLine"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или компонент не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации или циклическая спецификация",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/cost/from-bom": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Устанавливает себестоимость продукта равной сумме себестоимостей компонентов с учётом расхода на единицу",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Рассчитать себестоимость по спецификации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Продукт с новой себестоимостью",
+                        "schema": {
+                            "$ref": "#/definitions/model.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "У продукта нет спецификации",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Список заказов поставщикам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Статус (ordered, partially_received, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID поставщика",
+                        "name": "supplierId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказы с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PurchaseOrder"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID поставщика",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт заказ поставщику на склад (по умолчанию — основной). Закупочные цены сохраняются в истории цен поставщика.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Создать заказ поставщику",
+                "parameters": [
+                    {
+                        "description": "Данные заказа поставщику",
+                        "name": "purchaseOrder",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreatePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик, склад или продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает заказ поставщику со строками и всеми приёмками",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Получить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа поставщику",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ поставщику",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Закрывает заказ поставщику. Уже принятый товар остаётся на складе.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Отменить заказ поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа поставщику",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Заказ отменён",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Заказ уже закрыт",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase-orders/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Приходует полученное количество на склад заказа и пересчитывает себестоимость продуктов по средневзвешенной цене. Допускается частичная приёмка.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Purchases"
+                ],
+                "summary": "Принять товар по заказу поставщику",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа поставщику",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Полученные продукты",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReceivePurchaseOrderRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Товар принят",
+                        "schema": {
+                            "$ref": "#/definitions/model.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Заказ закрыт или получено больше заказанного",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Склад недоступен менеджеру",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Заказ не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает отчёты текущего пользователя и общие отчёты других пользователей",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Получить сохранённые отчёты",
+                "responses": {
+                    "200": {
+                        "description": "Список отчётов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SavedReport"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет определение отчёта для текущего пользователя. Определение проверяется так же, как при выполнении.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Сохранить отчёт",
+                "parameters": [
+                    {
+                        "description": "Отчёт",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SaveReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Отчёт сохранён",
+                        "schema": {
+                            "$ref": "#/definitions/model.SavedReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверное определение отчёта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/files": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Список файлов, сформированных по расписаниям, новые сначала",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Сформированные отчёты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID расписания",
+                        "name": "scheduleId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файлы с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.GeneratedReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/files/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет запись и файл отчёта",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Удалить сформированный отчёт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл удалён",
+                        "schema": {
+                            "$ref": "#/definitions/model.GeneratedReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Файл не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/files/{id}/download": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Скачать сформированный отчёт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID файла",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Файл отчёта",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Файл не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выполняет определение отчёта без сохранения. Наборы данных: orders, order_items, clients, products. Группировки — поля набора (для дат — с шагом, например createdAt:month), показатели — count, count_distinct, sum, avg, min, max. Период применяется к дате создания записи.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Выполнить отчёт",
+                "parameters": [
+                    {
+                        "description": "Определение отчёта",
+                        "name": "definition",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportDefinition"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Формат (json, csv, xlsx)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат отчёта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверное определение отчёта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Получить расписания отчётов",
+                "responses": {
+                    "200": {
+                        "description": "Список расписаний",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ReportSchedule"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ставит сохранённый отчёт (reportId) или встроенную выгрузку (export: products, clients) в расписание. cron — пять полей в часовом поясе бизнеса, например \"0 8 1 * *\" — первого числа в 8:00. period — строить отчёт за последний завершённый день, неделю, месяц, квартал или год. Файл отправляется получателям, если настроен SMTP_HOST.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Создать расписание отчёта",
+                "parameters": [
+                    {
+                        "description": "Расписание",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Расписание создано",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Отчёт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/schedules/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет параметры расписания и пересчитывает время следующего запуска",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Обновить расписание отчёта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID расписания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Расписание",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportScheduleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Расписание обновлено",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Расписание или отчёт не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет расписание; сформированные по нему файлы остаются",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Удалить расписание отчёта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID расписания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Расписание удалено",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReportSchedule"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Расписание не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/schedules/{id}/run": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Формирует и отправляет отчёт немедленно, не сдвигая плановый запуск",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Запустить расписание сейчас",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID расписания",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Отчёт сформирован; ошибки формирования и доставки — в полях error и deliveryError",
+                        "schema": {
+                            "$ref": "#/definitions/model.GeneratedReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Расписание не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает собственный или общий отчёт по ID; администратору доступны все отчёты",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Получить сохранённый отчёт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID отчёта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт",
+                        "schema": {
+                            "$ref": "#/definitions/model.SavedReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Отчёт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Заменяет название, описание, доступ и определение отчёта. Изменять отчёт может только его владелец.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Обновить сохранённый отчёт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID отчёта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Отчёт",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SaveReportRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/model.SavedReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверное определение отчёта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Отчёт принадлежит другому пользователю",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Отчёт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет отчёт. Удалить отчёт может его владелец или администратор.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Удалить сохранённый отчёт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID отчёта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт удалён",
+                        "schema": {
+                            "$ref": "#/definitions/model.SavedReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Отчёт принадлежит другому пользователю",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Отчёт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/{id}/run": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выполняет собственный или общий отчёт. Параметры period, from и to заменяют период из определения.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Выполнить сохранённый отчёт",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID отчёта",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "json",
+                        "description": "Формат (json, csv, xlsx)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Календарный период (day, week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результат отчёта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ReportResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Отчёт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/company": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает реквизиты, которые печатаются в документах по заказам",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Реквизиты компании",
+                "responses": {
+                    "200": {
+                        "description": "Реквизиты компании",
+                        "schema": {
+                            "$ref": "#/definitions/model.CompanySettings"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет реквизиты компании. Новые реквизиты применяются ко всем документам, напечатанным после изменения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Изменить реквизиты компании",
+                "parameters": [
+                    {
+                        "description": "Реквизиты компании",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateCompanySettingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сохранённые реквизиты",
+                        "schema": {
+                            "$ref": "#/definitions/model.CompanySettings"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает шаблоны документов по заказам. Макеты хранятся в версиях: /settings/templates/{id}/versions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Шаблоны документов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Вид документа (invoice, delivery_note, pick_list, receipt)",
+                        "name": "documentType",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Шаблоны",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DocumentTemplate"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт шаблон с первой версией макета. Шаблон печатается только после публикации.\nПоля для подстановки: document.*, order.*, client.*, salesperson.name, warehouse.name, company.*, label.*; в колонках таблицы — item.no, item.name, item.category, item.unit, item.quantity, item.price, item.amount, item.blank.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Создать шаблон документа",
+                "parameters": [
+                    {
+                        "description": "Шаблон",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateDocumentTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Шаблон создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.DocumentTemplateVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный макет",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Шаблон документа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Шаблон",
+                        "schema": {
+                            "$ref": "#/definitions/model.DocumentTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет макет новой версией шаблона; прежние версии не меняются. Опубликованная версия остаётся прежней до публикации новой.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Новая версия шаблона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Макет",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateDocumentTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версия сохранена",
+                        "schema": {
+                            "$ref": "#/definitions/model.DocumentTemplateVersion"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный макет",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет шаблон со всеми версиями. Если он был опубликован, документы печатаются встроенным макетом.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Удалить шаблон документа",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Шаблон удалён",
+                        "schema": {
+                            "$ref": "#/definitions/model.DocumentTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/templates/{id}/preview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Печатает версию шаблона по указанному заказу или по образцу заказа. Номер документа не присваивается, реквизиты берутся из /settings/company.",
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Предпросмотр шаблона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия (по умолчанию последняя)",
+                        "name": "version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID заказа (по умолчанию образец)",
+                        "name": "orderId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "ru",
+                        "description": "Язык документа (ru, uz)",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PDF-документ",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон, версия или заказ не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/templates/{id}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Делает версию шаблона печатаемой для его вида документа. Другие шаблоны того же вида снимаются с публикации. Публикация прежней версии — откат макета.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Опубликовать версию шаблона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Версия",
+                        "name": "version",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.PublishDocumentTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версия опубликована",
+                        "schema": {
+                            "$ref": "#/definitions/model.DocumentTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон или версия не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/templates/{id}/unpublish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "После снятия документы этого вида печатаются встроенным макетом, пока не опубликован другой шаблон",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Снять шаблон с публикации",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Шаблон снят с публикации",
+                        "schema": {
+                            "$ref": "#/definitions/model.DocumentTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/settings/templates/{id}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает все версии шаблона с макетами, новые сначала",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Settings"
+                ],
+                "summary": "Версии шаблона",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID шаблона",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версии",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.DocumentTemplateVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/chart": {
+            "get": {
+                "description": "Возвращает выручку (см. REVENUE_RECOGNITION) по дням, неделям, месяцам или кварталам в часовом поясе бизнеса. По умолчанию — последние 30 дней. С compare=true к каждой точке добавляется значение за соответствующий интервал предыдущего периода.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Получить статистику продаж",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Скользящий период (week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Шаг (day, week, month, quarter)",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сравнить с предыдущим периодом",
+                        "name": "compare",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список со статистикой всех продуктов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "array",
+                                "items": {
+                                    "$ref": "#/definitions/handlers.SalesData"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/clients": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Для каждого клиента с покупками считает давность, частоту и сумму покупок (см. REVENUE_RECOGNITION), баллы 1–5 и сегмент: new — первая покупка не раньше newDays дней назад, at_risk — нет покупок дольше atRiskDays, lost — дольше lostDays, loyal — высокие баллы давности и частоты, остальные — regular.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "RFM-сегментация клиентов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Сегмент (new, loyal, regular, at_risk, lost)",
+                        "name": "segment",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID продавца",
+                        "name": "sellerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Порог нового клиента в днях",
+                        "name": "newDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 60,
+                        "description": "Порог риска оттока в днях",
+                        "name": "atRiskDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 180,
+                        "description": "Порог потерянного клиента в днях",
+                        "name": "lostDays",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Клиенты с баллами и сегментами",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ClientRFM"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/dashboard": {
+            "get": {
+                "description": "Возвращает топ 10 клиентов и продуктов по сумме потраченных денег и проданных единиц за период (по умолчанию — текущий месяц). Учитываются только заказы, входящие в выручку: по умолчанию все принятые, при REVENUE_RECOGNITION=delivered — только доставленные. Для продуктов также считаются себестоимость проданного и маржа по себестоимости, зафиксированной в заказах. С compare=true добавляется сравнение выручки с предыдущим периодом. Также возвращаются планы продаж на месяцы периода и их выполнение.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Получить топ клиентов и продуктов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Календарный период (day, week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сравнить с предыдущим периодом",
+                        "name": "compare",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список со статистикой всех продуктов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.DashboardResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/funnel": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Для заказов, созданных за период, возвращает разбивку по текущим статусам и воронку создан → принят → в производстве → готов → доставлен с конверсией и долей отклонённых.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Воронка статусов заказов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "month",
+                        "description": "Календарный период (day, week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Воронка статусов",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StatusFunnel"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/lead-times": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Среднее и медианное время в часах между статусами для заказов, созданных за период. Учитываются только переходы, время которых записано.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Сроки между статусами заказов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "month",
+                        "description": "Календарный период (day, week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сроки по переходам",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.LeadTime"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/margins": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выручка, себестоимость (зафиксированная в позициях заказов) и валовая маржа по продуктам, категориям, клиентам или продавцам. Учитываются только заказы, входящие в выручку (см. REVENUE_RECOGNITION).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Отчёт о валовой марже",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "product",
+                        "description": "Группировка (product, category, client, seller)",
+                        "name": "groupBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "month",
+                        "description": "Календарный период (day, week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчёт о марже",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.MarginReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/products": {
+            "get": {
+                "description": "Возвращает статистику по всем продуктам, включая количество произведенного и проданного за период (по умолчанию — текущий месяц)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Получить статистику всех продуктов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Календарный период (day, week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список со статистикой всех продуктов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ProductStatistics"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/statistics/sellers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Выручка (см. REVENUE_RECOGNITION), число заказов, новые клиенты, средний чек и доля отклонённых заказов по каждому продавцу за период. Деактивированные продавцы с продажами за период тоже попадают в рейтинг.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Statistics"
+                ],
+                "summary": "Рейтинг продавцов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "month",
+                        "description": "Календарный период (day, week, month, quarter, year)",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Начало периода (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Конец периода включительно (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "revenue",
+                        "description": "Сортировка (revenue, orders, newClients, averageOrderValue, rejectionRate)",
+                        "name": "sortBy",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рейтинг продавцов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.SellerPerformance"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Получить список поставщиков",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поставщики с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Supplier"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Создать поставщика",
+                "parameters": [
+                    {
+                        "description": "Данные поставщика",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Поставщик создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Получить поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поставщик",
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет поставщика. Заказы поставщику и история цен сохраняются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Удалить поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поставщик удалён",
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Обновить поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные поставщика",
+                        "name": "supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateSupplierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Поставщик обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/model.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/suppliers/{id}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Без productId возвращает текущую закупочную цену каждого продукта поставщика, с productId — историю цен на продукт",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Suppliers"
+                ],
+                "summary": "Цены поставщика",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID поставщика",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID продукта для истории цен",
+                        "name": "productId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Цены поставщика",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SupplierPrice"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Поставщик не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/targets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает планы за месяц (по умолчанию текущий) вместе с фактической выручкой (см. REVENUE_RECOGNITION)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Targets"
+                ],
+                "summary": "Получить планы продаж с выполнением",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Месяц (YYYY-MM)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID продавца",
+                        "name": "sellerId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID категории",
+                        "name": "categoryId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Планы и их выполнение",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TargetProgress"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт план выручки на месяц для продавца или для категории (указывается что-то одно)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Targets"
+                ],
+                "summary": "Создать план продаж",
+                "parameters": [
+                    {
+                        "description": "План",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateSalesTargetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "План создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.SalesTarget"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Продавец или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "План уже существует",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/targets/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Targets"
+                ],
+                "summary": "Удалить план продаж",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID плана",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "План удалён",
+                        "schema": {
+                            "$ref": "#/definitions/model.SalesTarget"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "План не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Targets"
+                ],
+                "summary": "Изменить план продаж",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID плана",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая сумма плана",
+                        "name": "target",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateSalesTargetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "План обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/model.SalesTarget"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "План не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/upload": {
+            "post": {
+                "description": "Эта функция позволяет загружать одно изображение на сервер с проверкой размера и типа файла",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Загрузка одной картинки",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файл изображения",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Информация о загруженном файле: имя и URL",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или ошибка валидации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при сохранении файла",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/uploadMany": {
+            "post": {
+                "description": "Эта функция позволяет загружать несколько изображений на сервер",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images"
+                ],
+                "summary": "Загрузить несколько изображений",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Файлы изображений",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Список загруженных файлов с их URL",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный запрос или ошибка валидации",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера при сохранении файла",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Возвращает список всех пользователей с поддержкой пагинации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получить список пользователей",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Номер страницы (по умолчанию 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 10)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Фильтр по активности (true, false)",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список пользователей",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Создаёт нового пользователя в системе",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Создать пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Пользователь успешно создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный JSON",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/search": {
+            "get": {
+                "description": "Ищет пользователей по заданному запросу",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Поиск пользователей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результаты поиска пользователей",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Отсутствует параметр запроса 'q'",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка при поиске пользователей",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Возвращает информацию о пользователе по его ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получить пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Данные пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Мягко удаляет пользователя: он деактивируется и скрывается из списков, но его заказы и клиенты сохраняют ссылку на него.\nКлиентов удаляемого пользователя можно передать другому продавцу параметром reassignTo.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Удалить пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID продавца, которому передаются клиенты",
+                        "name": "reassignTo",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь успешно удалён",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Недопустимый получатель клиентов",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет информацию о пользователе по его ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Обновить данные пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Обновляемые данные пользователя",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь успешно обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или JSON",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снова разрешает деактивированному пользователю вход в систему",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Активировать пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь активирован",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Запрещает пользователю вход в систему. История заказов и статистика продаж продолжают ссылаться на него.\nЕсли указан reassignTo, все клиенты пользователя передаются этому продавцу.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Деактивировать пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID пользователя",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Получатель клиентов",
+                        "name": "data",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.DeactivateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Пользователь деактивирован",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID или JSON",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Недопустимый получатель клиентов",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка на сервере",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/alerts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает продукты, доступный остаток которых (с учётом резерва под непринятые заказы) ниже минимального",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Сигналы о низком остатке",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "open",
+                        "description": "Статус (open, resolved)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сигналы с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockAlert"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "По недельным продажам (см. REVENUE_RECOGNITION) за последние history полных недель прогнозирует спрос на weeks недель вперёд: скользящее среднее за window недель плюс линейный тренд, скорректированные на сезонные индексы с периодом season недель (0 — без сезонности; индексы считаются, если истории хватает хотя бы на два периода). Рекомендуемый объём производства покрывает прогноз, ожидающие подтверждения заказы и минимальный остаток за вычетом текущего остатка и округляется вверх до кратного объёма пополнения.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Прогноз спроса и план производства",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 4,
+                        "description": "Горизонт прогноза в неделях",
+                        "name": "weeks",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 26,
+                        "description": "Глубина истории в неделях",
+                        "name": "history",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 4,
+                        "description": "Окно скользящего среднего в неделях",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 4,
+                        "description": "Период сезонности в неделях",
+                        "name": "season",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID продукта",
+                        "name": "productId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Сравнивать с остатком этого склада вместо общего",
+                        "name": "warehouseId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Прогноз по продуктам",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ProductForecast"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные параметры запроса",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/material-requirements": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Суммирует расход компонентов по спецификациям для всех принятых заказов и сравнивает его с остатками",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Потребность в материалах",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Учитывать только заказы и остатки этого склада",
+                        "name": "warehouseId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Потребность по компонентам",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.MaterialRequirement"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID склада",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/production": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Приходует готовый продукт на склад и списывает с того же склада компоненты по спецификации",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Зарегистрировать выпуск продукции",
+                "parameters": [
+                    {
+                        "description": "Продукт и количество",
+                        "name": "production",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LogProductionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Выпуск зарегистрирован",
+                        "schema": {
+                            "$ref": "#/definitions/model.ProductionLog"
+                        }
+                    },
+                    "400": {
+                        "description": "Недостаточно компонентов на складе",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Склад недоступен менеджеру",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Продукт или склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/stocktakes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Список инвентаризаций",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Статус (open, approved, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID склада",
+                        "name": "warehouseId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Инвентаризации с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockTake"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID склада",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Открывает инвентаризацию склада (по умолчанию — основного) целиком или по одной категории и фиксирует ожидаемые остатки",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Открыть инвентаризацию",
+                "parameters": [
+                    {
+                        "description": "Склад и категория",
+                        "name": "stockTake",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStockTakeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Инвентаризация открыта",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Склад недоступен менеджеру",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Склад или категория не найдены",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "По складу уже идёт инвентаризация",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/stocktakes/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает инвентаризацию со строками: ожидаемый и фактический остаток, расхождение",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Получить инвентаризацию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Инвентаризация",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Инвентаризация не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/stocktakes/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Проводит расхождения по пересчитанным продуктам как корректировки остатков склада. Непересчитанные продукты не изменяются. Если остаток продукта изменился после пересчёта, утверждение отклоняется и продукт нужно пересчитать.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Утвердить инвентаризацию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Инвентаризация утверждена",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Инвентаризация уже закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Инвентаризация не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Остаток изменился после пересчёта, нужен пересчёт",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Остаток ушёл бы в минус",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/stocktakes/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Закрывает инвентаризацию без изменения остатков",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Отменить инвентаризацию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Инвентаризация отменена",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Инвентаризация уже закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Инвентаризация не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/stocktakes/{id}/counts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет фактическое количество по продуктам и рассчитывает расхождение с учётным остатком на момент пересчёта. Повторная отправка (пересчёт) перезаписывает значение и обновляет ожидаемый остаток.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Внести результаты пересчёта",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID инвентаризации",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Фактические остатки",
+                        "name": "counts",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SubmitStockTakeCountsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Результаты сохранены",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTake"
+                        }
+                    },
+                    "400": {
+                        "description": "Инвентаризация уже закрыта",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Склад недоступен менеджеру",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Инвентаризация не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Продукт не входит в инвентаризацию",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/transfers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает перемещения между складами. Менеджер, привязанный к складу, видит только перемещения своего склада.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Список перемещений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Статус (in_transit, received, cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Перемещения с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.StockTransfer"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Списывает товар с исходного склада и переводит документ в статус \"in_transit\". Товар поступает на склад назначения после подтверждения получения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Создать перемещение между складами",
+                "parameters": [
+                    {
+                        "description": "Данные перемещения",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateStockTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Перемещение создано",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Недостаточно товара на складе",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Склад недоступен менеджеру",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Склад или продукт не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/transfers/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает товар на исходный склад и переводит документ в статус \"cancelled\"",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Отменить перемещение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID перемещения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Перемещение отменено",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID или статус",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Склад недоступен менеджеру",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Перемещение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/transfers/{id}/receive": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Оприходует товар на складе назначения и переводит документ в статус \"received\"",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Принять перемещение",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID перемещения",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Перемещение принято",
+                        "schema": {
+                            "$ref": "#/definitions/model.StockTransfer"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID или статус",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "403": {
+                        "description": "Склад недоступен менеджеру",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Перемещение не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/{id}/delivered": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "изменяет статус заказа на \"delivered\"",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Доставлен",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ успешно изменен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouse/{id}/in_production": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "изменяет статус заказа на \"ready\"",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouse"
+                ],
+                "summary": "Готово",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID заказа",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Заказ успешно изменен",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponseSuccess"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат UUID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Получить список складов",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список складов с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Warehouse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создаёт новый склад или магазин",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Создать склад",
+                "parameters": [
+                    {
+                        "description": "Данные склада",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Склад создан",
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет пустой склад. Нельзя удалить основной склад, склад с остатками, перемещениями в пути, открытыми заказами поставщикам или инвентаризацией и склад, к которому привязаны менеджеры.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Удалить склад",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID склада",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Склад удалён",
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "409": {
+                        "description": "Склад основной, на нём есть остатки или незакрытые документы",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет название, адрес или делает склад основным",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Обновить склад",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID склада",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новые данные склада",
+                        "name": "warehouse",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.UpdateWarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Склад обновлён",
+                        "schema": {
+                            "$ref": "#/definitions/model.Warehouse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "404": {
+                        "description": "Склад не найден",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "422": {
+                        "description": "Ошибка валидации данных",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses/{id}/stock": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает остатки всех продуктов на складе",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Warehouses"
+                ],
+                "summary": "Остатки на складе",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID склада",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Номер страницы",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Размер страницы",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Остатки с информацией о пагинации",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WarehouseStock"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный формат ID",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.APIError"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
         "handlers.APIError": {
             "type": "object",
             "properties": {
-                "message": {
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.ClientRFM": {
+            "type": "object",
+            "properties": {
+                "clientId": {
+                    "type": "string"
+                },
+                "clientName": {
+                    "type": "string"
+                },
+                "daysSinceLastOrder": {
+                    "type": "integer"
+                },
+                "firstOrderDay": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "integer"
+                },
+                "frequencyScore": {
+                    "type": "integer"
+                },
+                "lastOrderDay": {
+                    "type": "string"
+                },
+                "monetary": {
+                    "type": "number"
+                },
+                "monetaryScore": {
+                    "type": "integer"
+                },
+                "recencyScore": {
+                    "type": "integer"
+                },
+                "salespersonId": {
+                    "type": "string"
+                },
+                "segment": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ClientSummary": {
+            "type": "object",
+            "properties": {
+                "client_id": {
+                    "type": "string"
+                },
+                "client_name": {
+                    "type": "string"
+                },
+                "order_count": {
+                    "type": "integer"
+                },
+                "total_spent": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "permissions",
+                "userId"
+            ],
+            "properties": {
+                "allowedIps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "10.0.0.0/24"
+                    ]
+                },
+                "expiresAt": {
+                    "type": "string",
+                    "example": "2026-12-31T23:59:59Z"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Accounting export"
+                },
+                "permissions": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "orders:read",
+                        "clients:read"
+                    ]
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "handlers.CreateAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "apiKey": {
+                    "$ref": "#/definitions/model.APIKey"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "crm_1a2b3c4d_..."
+                }
+            }
+        },
+        "handlers.CreateClientRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "surname"
+            ],
+            "properties": {
+                "Note": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "contactInfo": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "name": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CreateUserRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "image": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 4
+                },
+                "role": {
+                    "enum": [
+                        "admin",
+                        "manager",
+                        "seller"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    ]
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "warehouseId": {
+                    "description": "Склад, к которому привязан менеджер",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.DashboardComparison": {
+            "type": "object",
+            "properties": {
+                "order_count": {
+                    "type": "integer"
+                },
+                "order_count_change": {
+                    "type": "number"
+                },
+                "previous_from": {
+                    "type": "string"
+                },
+                "previous_order_count": {
+                    "type": "integer"
+                },
+                "previous_revenue": {
+                    "type": "number"
+                },
+                "previous_to": {
+                    "type": "string"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "revenue_change": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.DashboardResponse": {
+            "type": "object",
+            "properties": {
+                "comparison": {
+                    "$ref": "#/definitions/handlers.DashboardComparison"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "targets": {
+                    "description": "Планы продаж на месяцы, попадающие в период, с выполнением",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.TargetProgress"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "top_clients": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ClientSummary"
+                    }
+                },
+                "top_products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ProductSummary"
+                    }
+                }
+            }
+        },
+        "handlers.DeactivateUserRequest": {
+            "type": "object",
+            "properties": {
+                "reassignTo": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "handlers.FunnelStage": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Заказы, дошедшие до этапа",
+                    "type": "integer"
+                },
+                "overallConversion": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stepConversion": {
+                    "description": "Доля от предыдущего этапа и от всех созданных заказов, %",
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.ImportResult": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ImportRowError"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.ImportRowResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "column": {
+                    "type": "string",
+                    "example": "Price"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "must be greater than or equal to 0"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "handlers.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "create"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "Стол дубовый"
+                },
+                "row": {
+                    "type": "integer",
+                    "example": 2
+                }
+            }
+        },
+        "handlers.LeadTime": {
+            "type": "object",
+            "properties": {
+                "avgHours": {
+                    "type": "number"
+                },
+                "fromStatus": {
+                    "type": "string"
+                },
+                "medianHours": {
+                    "type": "number"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "toStatus": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "token": {
+                    "type": "string",
+                    "example": "JWTOKEN"
+                }
+            }
+        },
+        "handlers.MarginReport": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "groupBy": {
+                    "type": "string"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MarginRow"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/handlers.MarginRow"
+                }
+            }
+        },
+        "handlers.MarginRow": {
+            "type": "object",
+            "properties": {
+                "cost": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "margin": {
+                    "type": "number"
+                },
+                "marginPercent": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "orderCount": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.MaterialRequirement": {
+            "type": "object",
+            "properties": {
+                "available": {
+                    "type": "number"
+                },
+                "componentId": {
+                    "type": "string"
+                },
+                "componentName": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "number"
+                },
+                "shortage": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ProductForecast": {
+            "type": "object",
+            "properties": {
+                "forecast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.WeeklyForecast"
+                    }
+                },
+                "forecastTotal": {
+                    "type": "number"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "minStock": {
+                    "type": "number"
+                },
+                "pending": {
+                    "type": "number"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "productName": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "number"
+                },
+                "suggestedProduction": {
+                    "type": "number"
+                },
+                "trend": {
+                    "type": "number"
+                },
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ProductStatistics": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Wooden Table"
+                },
+                "producedQuantity": {
+                    "type": "number",
+                    "example": 120.5
+                },
+                "productId": {
+                    "type": "string",
+                    "example": "d6c9b3be-652f-45d4-8384-a5eab99f03a6"
+                },
+                "soldQuantity": {
+                    "type": "number",
+                    "example": 95.3
+                }
+            }
+        },
+        "handlers.ProductSummary": {
+            "type": "object",
+            "properties": {
+                "margin": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "total_cost": {
+                    "type": "number"
+                },
+                "total_sold": {
+                    "type": "number"
+                },
+                "units_sold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.ReportResult": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "object",
+                        "additionalProperties": true
+                    }
+                }
+            }
+        },
+        "handlers.ResponseSuccess": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.SalesData": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "previous_amount": {
+                    "type": "number"
+                },
+                "previous_date": {
+                    "type": "string"
+                },
+                "total_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "handlers.SellerPerformance": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "averageOrderValue": {
+                    "type": "number"
+                },
+                "newClients": {
+                    "type": "integer"
+                },
+                "orders": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "rejectionRate": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "revenueOrders": {
+                    "type": "integer"
+                },
+                "sellerId": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.StatusCount": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.StatusFunnel": {
+            "type": "object",
+            "properties": {
+                "breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.StatusCount"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "rejectionRate": {
+                    "type": "number"
+                },
+                "stages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.FunnelStage"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.TargetProgress": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "number"
+                },
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/model.Category"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "expected": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "progress": {
+                    "type": "number"
+                },
+                "remaining": {
+                    "type": "number"
+                },
+                "seller": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "sellerId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.TwoFactorCodeRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "handlers.TwoFactorEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "provisioningUri": {
+                    "type": "string",
+                    "example": "otpauth://totp/CRM:admin?secret=JBSWY3DPEHPK3PXP\u0026issuer=CRM"
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "handlers.TwoFactorLoginRequest": {
+            "type": "object",
+            "required": [
+                "preAuthToken"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "preAuthToken": {
+                    "type": "string"
+                },
+                "recoveryCode": {
+                    "type": "string",
+                    "example": "a1b2c-3d4e5"
+                }
+            }
+        },
+        "handlers.TwoFactorLoginResponse": {
+            "type": "object",
+            "properties": {
+                "enrollmentRequired": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Two-factor authentication required"
+                },
+                "preAuthToken": {
+                    "type": "string",
+                    "example": "JWTOKEN"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "twoFactorRequired": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "handlers.TwoFactorVerifyResponse": {
+            "type": "object",
+            "properties": {
+                "recoveryCodes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "type": "string",
+                    "example": "JWTOKEN"
+                }
+            }
+        },
+        "handlers.UpdateClientRequest": {
+            "type": "object",
+            "required": [
+                "surname"
+            ],
+            "properties": {
+                "Note": {
+                    "type": "string"
+                },
+                "address": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "contactInfo": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 2
+                },
+                "surname": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateProductRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "costPrice": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "height": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "minStock": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "reorderQuantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "piece",
+                        "meter"
+                    ]
+                },
+                "width": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateSupplierRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "contactInfo": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateUserRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "role",
+                "username"
+            ],
+            "properties": {
+                "image": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 4
+                },
+                "role": {
+                    "enum": [
+                        "admin",
+                        "manager",
+                        "seller"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.Role"
+                        }
+                    ]
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3
+                },
+                "warehouseId": {
+                    "description": "Склад, к которому привязан менеджер",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.UpdateWarehouseRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "handlers.WeeklyForecast": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "number"
+                },
+                "weekStart": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.APIKey": {
+            "type": "object",
+            "properties": {
+                "allowedIps": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastUsedAt": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actorId": {
+                    "type": "string"
+                },
+                "actorRole": {
+                    "$ref": "#/definitions/model.Role"
+                },
+                "actorUsername": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "apiKeyId": {
+                    "type": "string"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object"
+                },
+                "entityId": {
+                    "type": "string"
+                },
+                "entityType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BOMLine": {
+            "type": "object",
+            "properties": {
+                "component": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "componentId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BOMLineRequest": {
+            "type": "object",
+            "required": [
+                "componentId",
+                "quantity"
+            ],
+            "properties": {
+                "componentId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 2.5
+                }
+            }
+        },
+        "model.Category": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Client": {
+            "type": "object",
+            "required": [
+                "name",
+                "surname"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "Начальное сальдо: долг клиента до начала учёта, входит в выписку",
+                    "type": "integer"
+                },
+                "contactInfo": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "purchaseHistory": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Order"
+                    }
+                },
+                "salespersonId": {
+                    "type": "string"
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ClientPayment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "clientId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "cash"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Предоплата"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "paidAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommissionRule": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "seller": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "sellerId": {
+                    "type": "string"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommissionTier"
+                    }
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommissionRuleRequest": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 3
+                },
+                "sellerId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommissionTierRequest"
+                    }
+                }
+            }
+        },
+        "model.CommissionStatement": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "approvedAt": {
+                    "type": "string"
+                },
+                "approvedById": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "orderCount": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "ruleId": {
+                    "type": "string"
+                },
+                "seller": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "sellerId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommissionTier": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "number"
+                },
+                "ruleId": {
+                    "type": "string"
+                },
+                "threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.CommissionTierRequest": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 5
+                },
+                "threshold": {
+                    "type": "number",
+                    "example": 50000000
+                }
+            }
+        },
+        "model.CompanySettings": {
+            "type": "object",
+            "properties": {
+                "accountantName": {
+                    "type": "string",
+                    "example": "Петрова А. А."
+                },
+                "address": {
+                    "type": "string",
+                    "example": "г. Ташкент, ул. Навои, 1"
+                },
+                "bankAccount": {
+                    "type": "string",
+                    "example": "20208000900123456001"
+                },
+                "bankCode": {
+                    "type": "string",
+                    "example": "00974"
+                },
+                "bankName": {
+                    "type": "string",
+                    "example": "АКБ «Капиталбанк»"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "UZS"
+                },
+                "directorName": {
+                    "type": "string",
+                    "example": "Иванов И. И."
+                },
+                "email": {
+                    "type": "string",
+                    "example": "info@example.uz"
+                },
+                "logoPath": {
+                    "description": "Путь к загруженному логотипу (uploads/...); если не задан, документы без логотипа",
+                    "type": "string",
+                    "example": "uploads/logo.png"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "ООО «Sharif Mebel»"
+                },
+                "phone": {
+                    "type": "string",
+                    "example": "+998 90 123 45 67"
+                },
+                "taxId": {
+                    "type": "string",
+                    "example": "123456789"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CreateClientPaymentRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "method"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1500000
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "transfer",
+                        "card"
+                    ],
+                    "example": "cash"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Предоплата"
+                },
+                "orderId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "paidAt": {
+                    "description": "Дата оплаты; по умолчанию — текущий момент",
+                    "type": "string",
+                    "example": "2026-03-15T10:00:00+05:00"
+                }
+            }
+        },
+        "model.CreateDocumentTemplateRequest": {
+            "type": "object",
+            "required": [
+                "documentType",
+                "layout",
+                "name"
+            ],
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Первая версия"
+                },
+                "documentType": {
+                    "type": "string",
+                    "enum": [
+                        "invoice",
+                        "delivery_note",
+                        "pick_list",
+                        "receipt"
+                    ],
+                    "example": "invoice"
+                },
+                "layout": {
+                    "$ref": "#/definitions/model.DocumentLayout"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Счёт с логотипом"
+                }
+            }
+        },
+        "model.CreateOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "model.CreateOrderRequest": {
+            "type": "object",
+            "required": [
+                "clientId",
+                "paymentMethod",
+                "products",
+                "salespersonId"
+            ],
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "clientId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                },
+                "paymentMethod": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "transfer",
+                        "credit"
+                    ],
+                    "example": "cash"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CreateOrderItemRequest"
+                    }
+                },
+                "salespersonId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "warehouseId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174002"
+                }
+            }
+        },
+        "model.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "categoryId",
+                "name",
+                "unit"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "categoryId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "height": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "image": {
+                    "type": "string",
+                    "minLength": 5,
+                    "example": "uploads/image.jpg"
+                },
+                "minStock": {
+                    "description": "Минимальный доступный остаток, ниже которого создаётся сигнал, и рекомендуемый объём дозаказа",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Example Product"
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 19.99
+                },
+                "reorderQuantity": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 20
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "piece",
+                        "meter"
+                    ],
+                    "example": "piece"
+                },
+                "warehouseId": {
+                    "description": "Склад для начального остатка; по умолчанию — основной склад",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174002"
+                },
+                "width": {
+                    "type": "string",
+                    "example": "50"
+                }
+            }
+        },
+        "model.CreatePurchaseOrderItemRequest": {
+            "type": "object",
+            "required": [
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 100
+                },
+                "unitCost": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2.5
+                }
+            }
+        },
+        "model.CreatePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items",
+                "supplierId"
+            ],
+            "properties": {
+                "expectedAt": {
+                    "type": "string",
+                    "example": "2025-01-31T00:00:00Z"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.CreatePurchaseOrderItemRequest"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "supplierId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "warehouseId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                }
+            }
+        },
+        "model.CreateSalesTargetRequest": {
+            "type": "object",
+            "required": [
+                "month"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 150000000
+                },
+                "categoryId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2025-01"
+                },
+                "sellerId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "model.CreateStockTakeRequest": {
+            "type": "object",
+            "properties": {
+                "categoryId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "warehouseId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                }
+            }
+        },
+        "model.CreateStockTransferItemRequest": {
+            "type": "object",
+            "required": [
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 10
+                }
+            }
+        },
+        "model.CreateStockTransferRequest": {
+            "type": "object",
+            "required": [
+                "fromWarehouseId",
+                "items",
+                "toWarehouseId"
+            ],
+            "properties": {
+                "fromWarehouseId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.CreateStockTransferItemRequest"
+                    }
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "toWarehouseId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                }
+            }
+        },
+        "model.DocumentBlock": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "align": {
+                    "type": "string",
+                    "enum": [
+                        "left",
+                        "center",
+                        "right"
+                    ],
+                    "example": "center"
+                },
+                "bold": {
+                    "type": "boolean"
+                },
+                "color": {
+                    "type": "string",
+                    "example": "#0984e3"
+                },
+                "columns": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "$ref": "#/definitions/model.DocumentColumn"
+                    }
+                },
+                "field": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "warehouse.name"
+                },
+                "height": {
+                    "type": "number",
+                    "maximum": 400,
+                    "minimum": 0
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "{{label.warehouse}}"
+                },
+                "signatures": {
+                    "type": "array",
+                    "maxItems": 6,
+                    "items": {
+                        "$ref": "#/definitions/model.DocumentSignature"
+                    }
+                },
+                "size": {
+                    "type": "number",
+                    "maximum": 40,
+                    "minimum": 6
+                },
+                "sortBy": {
+                    "type": "string",
+                    "enum": [
+                        "name",
+                        "category"
+                    ],
+                    "example": "category"
+                },
+                "text": {
+                    "type": "string",
+                    "maxLength": 2000,
+                    "example": "{{label.buyer}}: {{client.name}}"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "text",
+                        "field",
+                        "logo",
+                        "items",
+                        "totals",
+                        "signatures",
+                        "line",
+                        "spacer"
+                    ],
+                    "example": "text"
+                },
+                "width": {
+                    "type": "number",
+                    "maximum": 520,
+                    "minimum": 0
+                },
+                "x": {
+                    "type": "number",
+                    "maximum": 500,
+                    "minimum": 0
+                },
+                "y": {
+                    "type": "number",
+                    "maximum": 790,
+                    "minimum": 0
+                }
+            }
+        },
+        "model.DocumentColumn": {
+            "type": "object",
+            "required": [
+                "field"
+            ],
+            "properties": {
+                "align": {
+                    "type": "string",
+                    "enum": [
+                        "left",
+                        "center",
+                        "right"
+                    ],
+                    "example": "left"
+                },
+                "field": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "item.name"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "{{label.col.name}}"
+                },
+                "width": {
+                    "type": "number",
+                    "maximum": 520,
+                    "minimum": 0,
+                    "example": 200
+                }
+            }
+        },
+        "model.DocumentLayout": {
+            "type": "object",
+            "required": [
+                "blocks"
+            ],
+            "properties": {
+                "blocks": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.DocumentBlock"
+                    }
+                },
+                "boldFont": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Roboto-Bold.ttf"
+                },
+                "color": {
+                    "type": "string",
+                    "example": "#2d3436"
+                },
+                "font": {
+                    "description": "Файлы шрифтов из DOCUMENT_FONTS_DIR; по умолчанию Roboto-Regular.ttf и Roboto-Bold.ttf",
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Roboto-Regular.ttf"
+                },
+                "fontSize": {
+                    "description": "Размер и цвет текста по умолчанию",
+                    "type": "number",
+                    "maximum": 24,
+                    "minimum": 6,
+                    "example": 10
+                },
+                "pageNumbers": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "model.DocumentSignature": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "{{company.director}}"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "{{label.sign.director}}"
+                }
+            }
+        },
+        "model.DocumentTemplate": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "documentType": {
+                    "type": "string",
+                    "example": "invoice"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "latestVersion": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Счёт с логотипом"
+                },
+                "publishedVersion": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DocumentTemplateVersion": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Добавлен логотип"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "layout": {
+                    "$ref": "#/definitions/model.DocumentLayout"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "model.GenerateCommissionsRequest": {
+            "type": "object",
+            "required": [
+                "month"
+            ],
+            "properties": {
+                "month": {
+                    "type": "string",
+                    "example": "2025-01"
+                }
+            }
+        },
+        "model.GeneratedReport": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "deliveryError": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "periodFrom": {
+                    "type": "string"
+                },
+                "periodTo": {
+                    "type": "string"
+                },
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows": {
+                    "type": "integer"
+                },
+                "scheduleId": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Job": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "maxAttempts": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "progressMessage": {
+                    "type": "string",
+                    "example": "Обработано 2000 строк из 5000"
+                },
+                "result": {
+                    "description": "Данные результата (например, итог импорта) и файл результата, если задача его формирует",
+                    "type": "object"
+                },
+                "resultContentType": {
+                    "type": "string"
+                },
+                "resultFileName": {
+                    "type": "string"
+                },
+                "resultSize": {
+                    "type": "integer"
+                },
+                "runAt": {
+                    "type": "string"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "queued"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "export.orders"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.LogProductionRequest": {
+            "type": "object",
+            "required": [
+                "productId",
+                "quantity"
+            ],
+            "properties": {
+                "productId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "quantity": {
+                    "type": "number",
+                    "example": 10
+                },
+                "warehouseId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                }
+            }
+        },
+        "model.Notification": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "readAt": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Order": {
+            "type": "object",
+            "required": [
+                "clientId",
+                "paymentMethod",
+                "status"
+            ],
+            "properties": {
+                "acceptedAt": {
+                    "description": "Время переходов между статусами, по ним считаются сроки исполнения заказов",
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "client": {
+                    "$ref": "#/definitions/model.Client"
+                },
+                "clientId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "deliveredAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inProductionAt": {
+                    "type": "string"
+                },
+                "paymentMethod": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "transfer",
+                        "credit"
+                    ]
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrderItem"
+                    }
+                },
+                "readyAt": {
+                    "type": "string"
+                },
+                "rejectedAt": {
+                    "type": "string"
+                },
+                "salesperson": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "salespersonId": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "accepted",
+                        "rejected",
+                        "in_production",
+                        "ready",
+                        "delivered"
+                    ]
+                },
+                "totalPrice": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "warehouse": {
+                    "$ref": "#/definitions/model.Warehouse"
+                },
+                "warehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.OrderDocument": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issuedAt": {
+                    "type": "string"
+                },
+                "issuedById": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string",
+                    "example": "INV-2026-000123"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "invoice"
+                }
+            }
+        },
+        "model.OrderItem": {
+            "type": "object",
+            "required": [
+                "productId"
+            ],
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "orderId": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "totalCost": {
+                    "type": "number"
+                },
+                "totalPrice": {
+                    "description": "Unit      string     ` + "`" + `json:\"unit\" validate:\"required, oneof=piece meter\"` + "`" + `\nPricePerUnit float64    ` + "`" + `json:\"pricePerUnit\"` + "`" + `",
+                    "type": "number"
+                },
+                "unitCost": {
+                    "description": "Себестоимость на момент оформления заказа",
+                    "type": "number"
+                }
+            }
+        },
+        "model.Product": {
+            "type": "object",
+            "required": [
+                "amount",
+                "categoryId",
+                "name",
+                "unit"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "category": {
+                    "$ref": "#/definitions/model.Category"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "costPrice": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "height": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string",
+                    "minLength": 5
+                },
+                "minStock": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "price": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "reorderQuantity": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "stocks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.WarehouseStock"
+                    }
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "piece",
+                        "meter"
+                    ]
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProductionLog": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "warehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PublishDocumentTemplateRequest": {
+            "type": "object",
+            "required": [
+                "version"
+            ],
+            "properties": {
+                "version": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 2
+                }
+            }
+        },
+        "model.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "expectedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseOrderItem"
+                    }
+                },
+                "note": {
+                    "type": "string"
+                },
+                "receipts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseReceipt"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier": {
+                    "$ref": "#/definitions/model.Supplier"
+                },
+                "supplierId": {
+                    "type": "string"
+                },
+                "totalCost": {
+                    "type": "number"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "warehouse": {
+                    "$ref": "#/definitions/model.Warehouse"
+                },
+                "warehouseId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.PurchaseOrderItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "purchaseOrderId": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "number"
+                },
+                "receivedQuantity": {
+                    "type": "number"
+                },
+                "totalCost": {
+                    "type": "number"
                 },
-                "success": {
-                    "type": "boolean"
+                "unitCost": {
+                    "type": "number"
                 }
             }
         },
-        "handlers.ClientSummary": {
+        "model.PurchaseReceipt": {
             "type": "object",
             "properties": {
-                "client_id": {
+                "createdAt": {
                     "type": "string"
                 },
-                "client_name": {
+                "id": {
                     "type": "string"
                 },
-                "order_count": {
-                    "type": "integer"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PurchaseReceiptItem"
+                    }
                 },
-                "total_spent": {
-                    "type": "number"
+                "note": {
+                    "type": "string"
+                },
+                "purchaseOrderId": {
+                    "type": "string"
+                },
+                "receivedById": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.CreateClientRequest": {
+        "model.PurchaseReceiptItem": {
             "type": "object",
-            "required": [
-                "name",
-                "surname"
-            ],
             "properties": {
-                "Note": {
+                "id": {
                     "type": "string"
                 },
-                "address": {
+                "productId": {
                     "type": "string"
                 },
-                "contactInfo": {
+                "purchaseOrderItemId": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string",
-                    "minLength": 5
+                "quantity": {
+                    "type": "number"
                 },
-                "name": {
+                "receiptId": {
                     "type": "string"
                 },
-                "surname": {
-                    "type": "string"
+                "unitCost": {
+                    "type": "number"
                 }
             }
         },
-        "handlers.CreateUserRequest": {
+        "model.ReceivePurchaseOrderItemRequest": {
             "type": "object",
             "required": [
-                "password",
-                "role",
-                "username"
+                "productId",
+                "quantity"
             ],
             "properties": {
-                "image": {
-                    "type": "string",
-                    "minLength": 5
-                },
-                "password": {
+                "productId": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 4
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
                 },
-                "role": {
-                    "enum": [
-                        "admin",
-                        "manager",
-                        "seller"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.Role"
-                        }
-                    ]
+                "quantity": {
+                    "type": "number",
+                    "example": 40
+                }
+            }
+        },
+        "model.ReceivePurchaseOrderRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.ReceivePurchaseOrderItemRequest"
+                    }
                 },
-                "username": {
+                "note": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
+                    "maxLength": 500
                 }
             }
         },
-        "handlers.DashboardResponse": {
+        "model.ReportDefinition": {
             "type": "object",
+            "required": [
+                "dataset",
+                "measures"
+            ],
             "properties": {
-                "top_clients": {
+                "dataset": {
+                    "type": "string",
+                    "enum": [
+                        "orders",
+                        "order_items",
+                        "clients",
+                        "products"
+                    ],
+                    "example": "orders"
+                },
+                "dimensions": {
+                    "description": "Группировки: поле или поле с датой и шагом, например \"createdAt:month\"",
                     "type": "array",
+                    "maxItems": 5,
                     "items": {
-                        "$ref": "#/definitions/handlers.ClientSummary"
+                        "type": "string"
+                    },
+                    "example": [
+                        "sellerName",
+                        "createdAt:month"
+                    ]
+                },
+                "filters": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/model.ReportFilter"
                     }
                 },
-                "top_products": {
+                "from": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "limit": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 0,
+                    "example": 100
+                },
+                "measures": {
                     "type": "array",
+                    "maxItems": 10,
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/handlers.ProductSummary"
+                        "$ref": "#/definitions/model.ReportMeasure"
                     }
+                },
+                "period": {
+                    "description": "Календарный период (day, week, month, quarter, year) или from/to (YYYY-MM-DD, включительно).\nЕсли Rolling, period означает последние 7, 30 или 90 дней либо 12 месяцев.",
+                    "type": "string",
+                    "enum": [
+                        "day",
+                        "week",
+                        "month",
+                        "quarter",
+                        "year"
+                    ],
+                    "example": "month"
+                },
+                "rolling": {
+                    "type": "boolean"
+                },
+                "sortBy": {
+                    "description": "Ключ группировки или показателя для сортировки",
+                    "type": "string",
+                    "example": "sum:totalPrice"
+                },
+                "sortDesc": {
+                    "type": "boolean"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-01-31"
                 }
             }
         },
-        "handlers.LoginRequest": {
+        "model.ReportFilter": {
             "type": "object",
+            "required": [
+                "field",
+                "operator"
+            ],
             "properties": {
-                "password": {
-                    "type": "string"
+                "field": {
+                    "type": "string",
+                    "example": "status"
                 },
-                "username": {
-                    "type": "string"
+                "operator": {
+                    "type": "string",
+                    "enum": [
+                        "eq",
+                        "ne",
+                        "gt",
+                        "gte",
+                        "lt",
+                        "lte",
+                        "in",
+                        "contains"
+                    ],
+                    "example": "in"
+                },
+                "value": {
+                    "type": "object"
                 }
             }
         },
-        "handlers.LoginResponse": {
+        "model.ReportMeasure": {
             "type": "object",
+            "required": [
+                "aggregate"
+            ],
             "properties": {
-                "message": {
+                "aggregate": {
                     "type": "string",
-                    "example": "success"
-                },
-                "status": {
-                    "type": "integer",
-                    "example": 200
+                    "enum": [
+                        "count",
+                        "count_distinct",
+                        "sum",
+                        "avg",
+                        "min",
+                        "max"
+                    ],
+                    "example": "sum"
                 },
-                "token": {
+                "field": {
                     "type": "string",
-                    "example": "JWTOKEN"
+                    "example": "totalPrice"
                 }
             }
         },
-        "handlers.ProductStatistics": {
+        "model.ReportSchedule": {
             "type": "object",
             "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "cron": {
+                    "description": "Cron-выражение в часовом поясе бизнеса",
+                    "type": "string"
+                },
+                "export": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lastRunAt": {
+                    "type": "string"
+                },
                 "name": {
-                    "type": "string",
-                    "example": "Wooden Table"
+                    "type": "string"
                 },
-                "producedQuantity": {
-                    "type": "number",
-                    "example": 120.5
+                "nextRunAt": {
+                    "type": "string"
                 },
-                "productId": {
-                    "type": "string",
-                    "example": "d6c9b3be-652f-45d4-8384-a5eab99f03a6"
+                "period": {
+                    "description": "Если задан, отчёт строится за последний завершённый период (day, week, month, quarter, year)\nвместо периода из определения отчёта",
+                    "type": "string"
                 },
-                "soldQuantity": {
-                    "type": "number",
-                    "example": 95.3
+                "recipients": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "report": {
+                    "$ref": "#/definitions/model.SavedReport"
+                },
+                "reportId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.ProductSummary": {
+        "model.ReportScheduleRequest": {
             "type": "object",
+            "required": [
+                "cron",
+                "format",
+                "name"
+            ],
             "properties": {
-                "product_id": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "product_name": {
-                    "type": "string"
+                "cron": {
+                    "type": "string",
+                    "example": "0 8 1 * *"
                 },
-                "total_sold": {
-                    "type": "number"
+                "export": {
+                    "type": "string",
+                    "enum": [
+                        "products",
+                        "clients"
+                    ],
+                    "example": ""
                 },
-                "units_sold": {
-                    "type": "integer"
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "xlsx"
+                    ],
+                    "example": "xlsx"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Продажи за месяц"
+                },
+                "period": {
+                    "type": "string",
+                    "enum": [
+                        "day",
+                        "week",
+                        "month",
+                        "quarter",
+                        "year"
+                    ],
+                    "example": "month"
+                },
+                "recipients": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "director@example.com"
+                    ]
+                },
+                "reportId": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 }
             }
         },
-        "handlers.ResponseSuccess": {
+        "model.Role": {
+            "type": "string",
+            "enum": [
+                "admin",
+                "manager",
+                "seller"
+            ],
+            "x-enum-varnames": [
+                "AdminRole",
+                "Manager",
+                "Seller"
+            ]
+        },
+        "model.SalesTarget": {
             "type": "object",
             "properties": {
-                "message": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "$ref": "#/definitions/model.Category"
+                },
+                "categoryId": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdById": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "month": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "handlers.SalesData": {
-            "type": "object",
-            "properties": {
-                "date": {
+                "seller": {
+                    "$ref": "#/definitions/model.User"
+                },
+                "sellerId": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "number"
+                "updatedAt": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.UpdateClientRequest": {
+        "model.SaveReportRequest": {
             "type": "object",
             "required": [
-                "surname"
+                "definition",
+                "name"
             ],
             "properties": {
-                "Note": {
-                    "type": "string"
-                },
-                "address": {
-                    "type": "string",
-                    "minLength": 5
+                "definition": {
+                    "$ref": "#/definitions/model.ReportDefinition"
                 },
-                "contactInfo": {
-                    "type": "string"
-                },
-                "image": {
+                "description": {
                     "type": "string",
-                    "minLength": 5
+                    "maxLength": 500,
+                    "example": "Помесячная выручка каждого продавца"
                 },
                 "name": {
                     "type": "string",
-                    "minLength": 2
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Выручка по продавцам"
                 },
-                "surname": {
-                    "type": "string"
+                "shared": {
+                    "type": "boolean",
+                    "example": false
                 }
             }
         },
-        "handlers.UpdateProductRequest": {
+        "model.SavedReport": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number",
-                    "minimum": 0
-                },
-                "categoryId": {
+                "createdAt": {
                     "type": "string"
                 },
-                "height": {
+                "definition": {
+                    "$ref": "#/definitions/model.ReportDefinition"
+                },
+                "description": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string",
-                    "minLength": 5
+                "id": {
+                    "type": "string"
                 },
                 "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3
+                    "type": "string"
                 },
-                "price": {
-                    "type": "number",
-                    "minimum": 0
+                "owner": {
+                    "$ref": "#/definitions/model.User"
                 },
-                "unit": {
-                    "type": "string",
-                    "enum": [
-                        "piece",
-                        "meter"
-                    ]
+                "ownerId": {
+                    "type": "string"
                 },
-                "width": {
+                "shared": {
+                    "type": "boolean"
+                },
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "handlers.UpdateUserRequest": {
+        "model.SetBOMRequest": {
             "type": "object",
-            "required": [
-                "password",
-                "role",
-                "username"
-            ],
             "properties": {
-                "image": {
-                    "type": "string",
-                    "minLength": 5
-                },
-                "password": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 4
-                },
-                "role": {
-                    "enum": [
-                        "admin",
-                        "manager",
-                        "seller"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/model.Role"
-                        }
-                    ]
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3
+                "components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BOMLineRequest"
+                    }
                 }
             }
         },
-        "model.Category": {
+        "model.StockAlert": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "available": {
+                    "type": "number"
+                },
                 "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "minStock": {
+                    "type": "number"
+                },
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "reorderQuantity": {
+                    "type": "number"
+                },
+                "reserved": {
+                    "type": "number"
+                },
+                "resolvedAt": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 },
                 "updatedAt": {
@@ -2322,241 +11546,155 @@ const docTemplate = `{
                 }
             }
         },
-        "model.Client": {
+        "model.StockTake": {
             "type": "object",
-            "required": [
-                "name",
-                "surname"
-            ],
             "properties": {
-                "address": {
+                "approvedAt": {
                     "type": "string"
                 },
-                "balance": {
-                    "type": "integer"
-                },
-                "contactInfo": {
+                "approvedById": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
+                "category": {
+                    "$ref": "#/definitions/model.Category"
                 },
-                "id": {
+                "categoryId": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string",
-                    "minLength": 5
+                "createdAt": {
+                    "type": "string"
                 },
-                "name": {
+                "createdById": {
                     "type": "string"
                 },
-                "note": {
+                "id": {
                     "type": "string"
                 },
-                "purchaseHistory": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Order"
+                        "$ref": "#/definitions/model.StockTakeItem"
                     }
                 },
-                "salespersonId": {
+                "note": {
                     "type": "string"
                 },
-                "surname": {
+                "status": {
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
-                }
-            }
-        },
-        "model.CreateOrderItemRequest": {
-            "type": "object",
-            "required": [
-                "productId",
-                "quantity"
-            ],
-            "properties": {
-                "productId": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174003"
                 },
-                "quantity": {
-                    "type": "number",
-                    "example": 10
+                "warehouse": {
+                    "$ref": "#/definitions/model.Warehouse"
+                },
+                "warehouseId": {
+                    "type": "string"
                 }
             }
         },
-        "model.CreateOrderRequest": {
+        "model.StockTakeCountRequest": {
             "type": "object",
             "required": [
-                "clientId",
-                "paymentMethod",
-                "products",
-                "salespersonId"
+                "productId"
             ],
             "properties": {
-                "attachments": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "clientId": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174001"
-                },
-                "paymentMethod": {
-                    "type": "string",
-                    "enum": [
-                        "cash",
-                        "transfer",
-                        "credit"
-                    ],
-                    "example": "cash"
-                },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.CreateOrderItemRequest"
-                    }
+                "counted": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 8
                 },
-                "salespersonId": {
+                "productId": {
                     "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
                 }
             }
         },
-        "model.CreateProductRequest": {
+        "model.StockTakeItem": {
             "type": "object",
-            "required": [
-                "amount",
-                "categoryId",
-                "name",
-                "unit"
-            ],
             "properties": {
-                "amount": {
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 10
+                "counted": {
+                    "type": "number"
                 },
-                "categoryId": {
-                    "type": "string",
-                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                "countedAt": {
+                    "type": "string"
                 },
-                "height": {
-                    "type": "string",
-                    "example": "100"
+                "countedById": {
+                    "type": "string"
                 },
-                "image": {
-                    "type": "string",
-                    "minLength": 5,
-                    "example": "uploads/image.jpg"
+                "expected": {
+                    "type": "number"
                 },
-                "name": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 3,
-                    "example": "Example Product"
+                "id": {
+                    "type": "string"
                 },
-                "price": {
-                    "type": "number",
-                    "minimum": 0,
-                    "example": 19.99
+                "product": {
+                    "$ref": "#/definitions/model.Product"
                 },
-                "unit": {
-                    "type": "string",
-                    "enum": [
-                        "piece",
-                        "meter"
-                    ],
-                    "example": "piece"
+                "productId": {
+                    "type": "string"
                 },
-                "width": {
-                    "type": "string",
-                    "example": "50"
+                "stockTakeId": {
+                    "type": "string"
+                },
+                "variance": {
+                    "type": "number"
                 }
             }
         },
-        "model.Order": {
+        "model.StockTransfer": {
             "type": "object",
-            "required": [
-                "clientId",
-                "paymentMethod",
-                "status"
-            ],
             "properties": {
-                "attachments": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "client": {
-                    "$ref": "#/definitions/model.Client"
+                "createdAt": {
+                    "type": "string"
                 },
-                "clientId": {
+                "createdById": {
                     "type": "string"
                 },
-                "createdAt": {
+                "fromWarehouse": {
+                    "$ref": "#/definitions/model.Warehouse"
+                },
+                "fromWarehouseId": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "paymentMethod": {
-                    "type": "string",
-                    "enum": [
-                        "cash",
-                        "transfer",
-                        "credit"
-                    ]
-                },
-                "products": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.OrderItem"
+                        "$ref": "#/definitions/model.StockTransferItem"
                     }
                 },
-                "salesperson": {
-                    "$ref": "#/definitions/model.User"
+                "note": {
+                    "type": "string"
                 },
-                "salespersonId": {
+                "receivedAt": {
+                    "type": "string"
+                },
+                "receivedById": {
                     "type": "string"
                 },
                 "status": {
-                    "type": "string",
-                    "enum": [
-                        "pending",
-                        "accepted",
-                        "rejected",
-                        "in_production",
-                        "ready",
-                        "delivered"
-                    ]
+                    "type": "string"
                 },
-                "totalPrice": {
-                    "type": "number"
+                "toWarehouse": {
+                    "$ref": "#/definitions/model.Warehouse"
+                },
+                "toWarehouseId": {
+                    "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "model.OrderItem": {
+        "model.StockTransferItem": {
             "type": "object",
-            "required": [
-                "productId"
-            ],
             "properties": {
                 "id": {
                     "type": "string"
                 },
-                "orderId": {
-                    "type": "string"
-                },
                 "product": {
                     "$ref": "#/definitions/model.Product"
                 },
@@ -2566,84 +11704,193 @@ const docTemplate = `{
                 "quantity": {
                     "type": "number"
                 },
-                "totalPrice": {
-                    "description": "Unit      string     ` + "`" + `json:\"unit\" validate:\"required, oneof=piece meter\"` + "`" + `\nPricePerUnit float64    ` + "`" + `json:\"pricePerUnit\"` + "`" + `",
-                    "type": "number"
+                "transferId": {
+                    "type": "string"
                 }
             }
         },
-        "model.Product": {
+        "model.SubmitStockTakeCountsRequest": {
             "type": "object",
             "required": [
-                "amount",
-                "categoryId",
-                "name",
-                "unit"
+                "items"
             ],
             "properties": {
-                "amount": {
-                    "type": "number",
-                    "minimum": 0
-                },
-                "category": {
-                    "$ref": "#/definitions/model.Category"
+                "items": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/model.StockTakeCountRequest"
+                    }
+                }
+            }
+        },
+        "model.Supplier": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
                 },
-                "categoryId": {
+                "contactInfo": {
                     "type": "string"
                 },
                 "createdAt": {
                     "type": "string"
                 },
-                "height": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "note": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SupplierPrice": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "string",
-                    "minLength": 5
+                "price": {
+                    "type": "number"
                 },
-                "name": {
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "purchaseOrderId": {
+                    "type": "string"
+                },
+                "supplierId": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UpdateCompanySettingsRequest": {
+            "type": "object",
+            "required": [
+                "currency",
+                "name"
+            ],
+            "properties": {
+                "accountantName": {
                     "type": "string",
                     "maxLength": 100,
-                    "minLength": 3
+                    "example": "Петрова А. А."
                 },
-                "price": {
-                    "type": "number",
-                    "minimum": 0
+                "address": {
+                    "type": "string",
+                    "maxLength": 300,
+                    "example": "г. Ташкент, ул. Навои, 1"
                 },
-                "unit": {
+                "bankAccount": {
+                    "type": "string",
+                    "maxLength": 34,
+                    "example": "20208000900123456001"
+                },
+                "bankCode": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "00974"
+                },
+                "bankName": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "АКБ «Капиталбанк»"
+                },
+                "currency": {
                     "type": "string",
                     "enum": [
-                        "piece",
-                        "meter"
-                    ]
+                        "UZS",
+                        "USD",
+                        "RUB"
+                    ],
+                    "example": "UZS"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "directorName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Иванов И. И."
                 },
-                "width": {
-                    "type": "string"
+                "email": {
+                    "type": "string",
+                    "example": "info@example.uz"
+                },
+                "logoPath": {
+                    "type": "string",
+                    "example": "uploads/logo.png"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 200,
+                    "example": "ООО «Sharif Mebel»"
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "+998 90 123 45 67"
+                },
+                "taxId": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "123456789"
                 }
             }
         },
-        "model.Role": {
-            "type": "string",
-            "enum": [
-                "admin",
-                "manager",
-                "seller"
+        "model.UpdateDocumentTemplateRequest": {
+            "type": "object",
+            "required": [
+                "layout",
+                "name"
             ],
-            "x-enum-varnames": [
-                "AdminRole",
-                "Manager",
-                "Seller"
-            ]
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Добавлен логотип"
+                },
+                "layout": {
+                    "$ref": "#/definitions/model.DocumentLayout"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Счёт с логотипом"
+                }
+            }
+        },
+        "model.UpdateSalesTargetRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 180000000
+                }
+            }
         },
         "model.User": {
             "type": "object",
             "properties": {
+                "active": {
+                    "description": "Деактивированный пользователь не может войти, но остаётся привязанным к своим заказам и клиентам",
+                    "type": "boolean"
+                },
                 "clients": {
                     "type": "array",
                     "items": {
@@ -2653,6 +11900,9 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "deactivatedAt": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -2669,11 +11919,72 @@ const docTemplate = `{
                 "role": {
                     "$ref": "#/definitions/model.Role"
                 },
+                "totpEnabled": {
+                    "type": "boolean"
+                },
                 "updatedAt": {
                     "type": "string"
                 },
                 "username": {
                     "type": "string"
+                },
+                "warehouseId": {
+                    "description": "Склад менеджера: маршруты /warehouse работают только с его заказами и перемещениями",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Warehouse": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isDefault": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.WarehouseStock": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "product": {
+                    "$ref": "#/definitions/model.Product"
+                },
+                "productId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "warehouse": {
+                    "$ref": "#/definitions/model.Warehouse"
+                },
+                "warehouseId": {
+                    "type": "string"
                 }
             }
         }
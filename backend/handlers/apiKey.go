@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CreateAPIKeyRequest struct {
+	Name        string     `json:"name" validate:"required,min=3,max=100" example:"Accounting export"`
+	UserID      guuid.UUID `json:"userId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Permissions []string   `json:"permissions" validate:"required,min=1" example:"orders:read,clients:read"`
+	AllowedIPs  []string   `json:"allowedIps" validate:"omitempty,dive,cidr|ip" example:"10.0.0.0/24"`
+	ExpiresAt   *time.Time `json:"expiresAt" example:"2026-12-31T23:59:59Z"`
+}
+
+type CreateAPIKeyResponse struct {
+	Key    string       `json:"key" example:"crm_1a2b3c4d_..."`
+	APIKey model.APIKey `json:"apiKey"`
+}
+
+// CreateAPIKey Создать API-ключ
+//
+//	@Summary		Создать API-ключ
+//	@Description	Создаёт ключ для интеграций. Ключ действует от имени пользователя userId и только в пределах прав вида "<ресурс>:read" / "<ресурс>:write".
+//	@Description	Ключ в открытом виде возвращается только в этом ответе, в БД хранится его хэш.
+//	@Tags			API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			data	body		CreateAPIKeyRequest		true	"Параметры ключа"
+//	@Success		201		{object}	CreateAPIKeyResponse	"Ключ создан"
+//	@Failure		400		{object}	APIError				"Некорректный JSON"
+//	@Failure		404		{object}	APIError				"Пользователь не найден"
+//	@Failure		422		{object}	APIError				"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError				"Ошибка на сервере"
+//	@Router			/api-keys [post]
+func CreateAPIKey(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*auth.Claims)
+	db := database.DB
+
+	json := new(CreateAPIKeyRequest)
+	if err := c.BodyParser(json); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid JSON",
+			"success": false,
+		})
+	}
+
+	if err := validator.New().Struct(json); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"message": err.Error(),
+			"success": false,
+		})
+	}
+
+	for _, permission := range json.Permissions {
+		if !validAPIKeyPermission(permission) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"status":  422,
+				"message": fmt.Sprintf("Unknown permission '%s'", permission),
+				"success": false,
+			})
+		}
+	}
+
+	if json.ExpiresAt != nil && json.ExpiresAt.Before(time.Now()) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"message": "expiresAt must be in the future",
+			"success": false,
+		})
+	}
+
+	var owner model.User
+	if err := db.First(&owner, "id = ?", json.UserID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"message": "User not found",
+			"success": false,
+		})
+	}
+
+	rawKey, prefix, hash, err := utils.GenerateAPIKey()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to generate API key",
+			"success": false,
+		})
+	}
+
+	key := model.APIKey{
+		ID:          guuid.New(),
+		Name:        json.Name,
+		Prefix:      prefix,
+		KeyHash:     hash,
+		UserID:      owner.ID,
+		Permissions: json.Permissions,
+		AllowedIPs:  json.AllowedIPs,
+		ExpiresAt:   json.ExpiresAt,
+		CreatedByID: claims.ID,
+	}
+
+	if err := db.Create(&key).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to create API key",
+			"success": false,
+		})
+	}
+
+	recordAudit(c, db, model.AuditCreate, "api_key", key.ID, nil, key)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"message": "API key created successfully",
+		"success": true,
+		"data": CreateAPIKeyResponse{
+			Key:    rawKey,
+			APIKey: key,
+		},
+	})
+}
+
+// GetAPIKeys Список API-ключей
+//
+//	@Summary		Список API-ключей
+//	@Description	Возвращает выданные API-ключи (без секретной части) с датой последнего использования
+//	@Tags			API Keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int				false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int				false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.APIKey	"Список ключей с информацией о пагинации"
+//	@Failure		500			{object}	APIError		"Ошибка на сервере"
+//	@Router			/api-keys [get]
+func GetAPIKeys(c *fiber.Ctx) error {
+	keys := []model.APIKey{}
+
+	response, err := utils.Paginate(database.DB.Preload("User"), c, nil, &keys)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to retrieve API keys",
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// RevokeAPIKey Отозвать API-ключ
+//
+//	@Summary		Отозвать API-ключ
+//	@Description	Отзывает ключ, после чего запросы с ним отклоняются
+//	@Tags			API Keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID ключа"
+//	@Success		200	{object}	model.APIKey	"Ключ отозван"
+//	@Failure		400	{object}	APIError		"Неверный формат ID"
+//	@Failure		404	{object}	APIError		"Ключ не найден"
+//	@Failure		500	{object}	APIError		"Ошибка на сервере"
+//	@Router			/api-keys/{id} [delete]
+func RevokeAPIKey(c *fiber.Ctx) error {
+	db := database.DB
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid ID format",
+			"success": false,
+		})
+	}
+
+	var key model.APIKey
+	if err := db.First(&key, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"message": "API key not found",
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Database error",
+			"success": false,
+		})
+	}
+
+	if err := db.Delete(&key).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to revoke API key",
+			"success": false,
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "api_key", key.ID, key, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"message": "API key revoked",
+		"success": true,
+		"data":    key,
+	})
+}
+
+func validAPIKeyPermission(permission string) bool {
+	resource, action, found := strings.Cut(permission, ":")
+	if !found || (action != "read" && action != "write") {
+		return false
+	}
+	return slices.Contains(model.APIKeyResources, resource)
+}
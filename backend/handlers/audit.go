@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// auditActor — кто и откуда выполнил действие. Фоновые задачи получают его при постановке
+// в очередь, чтобы записи журнала указывали на пользователя, а не на воркер.
+type auditActor struct {
+	ID        *guuid.UUID `json:"id"`
+	Username  string      `json:"username"`
+	Role      model.Role  `json:"role"`
+	APIKeyID  *guuid.UUID `json:"apiKeyId"`
+	IP        string      `json:"ip"`
+	UserAgent string      `json:"userAgent"`
+}
+
+func auditActorFrom(c *fiber.Ctx) auditActor {
+	actor := auditActor{
+		IP:        c.IP(),
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+	}
+	if claims, ok := c.Locals("user").(*auth.Claims); ok && claims != nil {
+		actorID := claims.ID
+		actor.ID = &actorID
+		actor.Username = claims.Username
+		actor.Role = claims.Role
+		actor.APIKeyID = claims.APIKeyID
+	}
+	return actor
+}
+
+// recordAudit записывает в журнал изменение сущности. before и after — состояние до и после
+// операции (nil для создания и удаления соответственно). Если передана транзакция, запись
+// попадает в неё и откатывается вместе с изменением; сбой самой записи откатывается до точки
+// сохранения и не прерывает транзакцию.
+func recordAudit(c *fiber.Ctx, db *gorm.DB, action, entityType string, entityID interface{}, before, after interface{}) {
+	recordAuditAs(auditActorFrom(c), db, action, entityType, entityID, before, after)
+}
+
+// recordAuditAs — recordAudit для кода, который выполняется вне запроса.
+func recordAuditAs(actor auditActor, db *gorm.DB, action, entityType string, entityID interface{}, before, after interface{}) {
+	entry := model.AuditEntry{
+		ID:            guuid.New(),
+		Action:        action,
+		EntityType:    entityType,
+		EntityID:      fmt.Sprint(entityID),
+		ActorID:       actor.ID,
+		ActorUsername: actor.Username,
+		ActorRole:     actor.Role,
+		APIKeyID:      actor.APIKeyID,
+		IP:            actor.IP,
+		UserAgent:     actor.UserAgent,
+	}
+
+	beforeMap := toAuditMap(before)
+	afterMap := toAuditMap(after)
+	entry.Before = marshalAudit(beforeMap)
+	entry.After = marshalAudit(afterMap)
+	entry.Diff = marshalAudit(auditDiff(beforeMap, afterMap))
+
+	db = db.Session(&gorm.Session{NewDB: true})
+	if _, inTx := db.Statement.ConnPool.(gorm.TxCommitter); !inTx {
+		if err := db.Create(&entry).Error; err != nil {
+			log.Printf("Failed to write audit entry: %v | %s %s %s", err, action, entityType, entry.EntityID)
+		}
+		return
+	}
+
+	// Ошибка запроса в Postgres прерывает всю транзакцию, поэтому запись делается в точке сохранения
+	const savepoint = "audit_entry"
+	if err := db.SavePoint(savepoint).Error; err != nil {
+		log.Printf("Failed to write audit entry: %v | %s %s %s", err, action, entityType, entry.EntityID)
+		return
+	}
+	if err := db.Create(&entry).Error; err != nil {
+		log.Printf("Failed to write audit entry: %v | %s %s %s", err, action, entityType, entry.EntityID)
+		if err := db.RollbackTo(savepoint).Error; err != nil {
+			log.Printf("Failed to roll back audit savepoint: %v", err)
+		}
+	}
+}
+
+func toAuditMap(v interface{}) map[string]interface{} {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Ptr && reflect.ValueOf(v).IsNil()) {
+		return nil
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+func marshalAudit(v map[string]interface{}) model.JSON {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return model.JSON(raw)
+}
+
+// auditDiff возвращает только изменившиеся поля в виде {"поле": {"from": ..., "to": ...}}.
+func auditDiff(before, after map[string]interface{}) map[string]interface{} {
+	diff := map[string]interface{}{}
+
+	for key, newValue := range after {
+		if key == "updatedAt" {
+			continue
+		}
+		oldValue, exists := before[key]
+		if !exists || !reflect.DeepEqual(oldValue, newValue) {
+			diff[key] = fiber.Map{"from": oldValue, "to": newValue}
+		}
+	}
+	for key, oldValue := range before {
+		if _, exists := after[key]; !exists {
+			diff[key] = fiber.Map{"from": oldValue, "to": nil}
+		}
+	}
+
+	if len(diff) == 0 {
+		return nil
+	}
+	return diff
+}
+
+// GetAuditLog Журнал изменений
+//
+//	@Summary		Журнал изменений
+//	@Description	Возвращает записи журнала изменений с фильтрами по автору, сущности, действию и дате
+//	@Tags			Audit
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			actorId		query		string				false	"ID пользователя, выполнившего действие"
+//	@Param			entityType	query		string				false	"Тип сущности (user, client, category, product, order)"
+//	@Param			entityId	query		string				false	"ID сущности"
+//	@Param			action		query		string				false	"Действие (create, update, delete)"
+//	@Param			dateGte		query		string				false	"Не раньше даты (YYYY-MM-DD)"
+//	@Param			dateLte		query		string				false	"Не позже даты (YYYY-MM-DD)"
+//	@Param			page		query		int					false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int					false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.AuditEntry	"Записи журнала с информацией о пагинации"
+//	@Failure		400			{object}	APIError			"Ошибка валидации параметров запроса"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/audit [get]
+func GetAuditLog(c *fiber.Ctx) error {
+	entries := []model.AuditEntry{}
+	db := database.DB.Order("created_at desc")
+
+	if actorID := c.Query("actorId"); actorID != "" {
+		id, err := guuid.Parse(actorID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid actorId format",
+			})
+		}
+		db = db.Where("actor_id = ?", id)
+	}
+	if entityType := c.Query("entityType"); entityType != "" {
+		db = db.Where("entity_type = ?", entityType)
+	}
+	if entityID := c.Query("entityId"); entityID != "" {
+		db = db.Where("entity_id = ?", entityID)
+	}
+	if action := c.Query("action"); action != "" {
+		db = db.Where("action = ?", action)
+	}
+
+	if dateGte := c.Query("dateGte"); dateGte != "" {
+		parsedDate, err := time.Parse("2006-01-02", dateGte)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid dateGte format, expected YYYY-MM-DD",
+			})
+		}
+		db = db.Where("created_at >= ?", parsedDate)
+	}
+	if dateLte := c.Query("dateLte"); dateLte != "" {
+		parsedDate, err := time.Parse("2006-01-02", dateLte)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid dateLte format, expected YYYY-MM-DD",
+			})
+		}
+		// Включаем весь указанный день
+		db = db.Where("created_at < ?", parsedDate.AddDate(0, 0, 1))
+	}
+
+	response, err := utils.Paginate(db, c, nil, &entries)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve audit log",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"backend/auth"
 	"backend/database"
 	"backend/model"
 	"backend/utils"
 
 	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -18,18 +20,28 @@ type LoginResponse struct {
 	Message string `json:"message" example:"success"`
 	Token   string `json:"token" example:"JWTOKEN"`
 }
+type TwoFactorLoginResponse struct {
+	Code               int    `json:"status" example:"200"`
+	Message            string `json:"message" example:"Two-factor authentication required"`
+	TwoFactorRequired  bool   `json:"twoFactorRequired" example:"true"`
+	EnrollmentRequired bool   `json:"enrollmentRequired" example:"false"`
+	PreAuthToken       string `json:"preAuthToken" example:"JWTOKEN"`
+}
 
 // Login Авторизация пользователя
 //
 //	@Summary		Авторизация пользователя
 //	@Description	Эта функция позволяет пользователю войти в систему с помощью имени пользователя и пароля, и получить JWT-токен для дальнейшей аутентификации.
+//	@Description	Если у пользователя включена 2FA (или она обязательна для его роли), вместо токена возвращается промежуточный preAuthToken, действующий 5 минут.
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		LoginRequest	true	"Данные для авторизации"
 //	@Success		200		{object}	LoginResponse	"Успешная авторизация, возвращается JWT-токен"
+//	@Success		200		{object}	TwoFactorLoginResponse	"Требуется второй шаг входа"
 //	@Failure		400		{object}	APIError		"Некорректный формат JSON"
 //	@Failure		401		{object}	APIError		"Неверный пароль"
+//	@Failure		403		{object}	APIError		"Пользователь деактивирован"
 //	@Failure		404		{object}	APIError		"Имя пользователя не найдено"
 //	@Failure		500		{object}	APIError		"Ошибка при генерации токена"
 //	@Router			/login [post]
@@ -65,6 +77,52 @@ func Login(c *fiber.Ctx) error {
 		})
 	}
 
+	if !found.Active {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "User is deactivated",
+		})
+	}
+
+	// Второй шаг входа: вместо токена доступа выдаём промежуточный токен
+	if found.TOTPEnabled || utils.TwoFactorRequired(found.Role) {
+		scope := auth.ScopeTwoFactorLogin
+		if !found.TOTPEnabled {
+			scope = auth.ScopeTwoFactorEnroll
+		}
+
+		// Новый промежуточный токен заменяет прежний и заново открывает попытки ввода кода
+		tokenID := guuid.NewString()
+		err := db.Model(&found).Select("two_factor_token_id", "two_factor_attempts").
+			Updates(model.User{TwoFactorTokenID: tokenID, TwoFactorAttempts: 0}).Error
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Could not generate token",
+			})
+		}
+
+		preAuthToken, err := utils.GeneratePreAuthJWT(found, scope, tokenID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Could not generate token",
+			})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":             200,
+			"message":            "Two-factor authentication required",
+			"success":            true,
+			"twoFactorRequired":  found.TOTPEnabled,
+			"enrollmentRequired": !found.TOTPEnabled,
+			"preAuthToken":       preAuthToken,
+		})
+	}
+
 	tokenString, err := utils.GenerateJWT(found)
 
 	if err != nil {
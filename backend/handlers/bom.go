@@ -0,0 +1,572 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"fmt"
+	"sort"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaterialRequirement — потребность в компоненте и её покрытие остатком.
+type MaterialRequirement struct {
+	ComponentID   guuid.UUID `json:"componentId"`
+	ComponentName string     `json:"componentName"`
+	Unit          string     `json:"unit"`
+	Required      float64    `json:"required"`
+	Available     float64    `json:"available"`
+	Shortage      float64    `json:"shortage"`
+}
+
+// GetProductBOM Спецификация продукта
+//
+//	@Summary		Спецификация продукта
+//	@Description	Возвращает компоненты, из которых изготавливается продукт, и их расход на единицу
+//	@Tags			Products
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID продукта"
+//	@Success		200	{array}		model.BOMLine	"Строки спецификации"
+//	@Failure		400	{object}	APIError		"Неверный формат ID"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/products/{id}/bom [get]
+func GetProductBOM(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Product ID",
+		})
+	}
+
+	lines := []model.BOMLine{}
+	if err := database.DB.Preload("Component", unscoped).Where("product_id = ?", id).Find(&lines).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve bill of materials",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    lines,
+	})
+}
+
+// SetProductBOM Задать спецификацию продукта
+//
+//	@Summary		Задать спецификацию продукта
+//	@Description	Полностью заменяет список компонентов продукта. Пустой список удаляет спецификацию.
+//	@Tags			Products
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string				true	"ID продукта"
+//	@Param			bom		body		model.SetBOMRequest	true	"Компоненты и расход на единицу"
+//	@Success		200		{array}		model.BOMLine		"Новая спецификация"
+//	@Failure		400		{object}	APIError			"Некорректный запрос"
+//	@Failure		404		{object}	APIError			"Продукт или компонент не найден"
+//	@Failure		422		{object}	APIError			"Ошибка валидации или циклическая спецификация"
+//	@Failure		500		{object}	APIError			"Ошибка сервера"
+//	@Router			/products/{id}/bom [put]
+func SetProductBOM(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Product ID",
+		})
+	}
+
+	body := new(model.SetBOMRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var product model.Product
+	if err := tx.First(&product, "id = ?", id).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Product not found",
+		})
+	}
+
+	var before []model.BOMLine
+	if err := tx.Where("product_id = ?", id).Find(&before).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve bill of materials",
+		})
+	}
+
+	if err := tx.Where("product_id = ?", id).Delete(&model.BOMLine{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update bill of materials",
+		})
+	}
+
+	lines := []model.BOMLine{}
+	seen := make(map[guuid.UUID]bool, len(body.Components))
+	for _, component := range body.Components {
+		if component.ComponentID == id {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"status":  422,
+				"success": false,
+				"message": "Product cannot be a component of itself",
+			})
+		}
+		if seen[component.ComponentID] {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"status":  422,
+				"success": false,
+				"message": fmt.Sprintf("Component %s is listed more than once", component.ComponentID),
+			})
+		}
+		seen[component.ComponentID] = true
+
+		var componentProduct model.Product
+		if err := tx.First(&componentProduct, "id = ?", component.ComponentID).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Component product not found",
+			})
+		}
+
+		cyclic, err := bomContains(tx, component.ComponentID, id)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to check bill of materials",
+			})
+		}
+		if cyclic {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"status":  422,
+				"success": false,
+				"message": fmt.Sprintf("Component '%s' is itself made from this product", componentProduct.Name),
+			})
+		}
+
+		lines = append(lines, model.BOMLine{
+			ID:          guuid.New(),
+			ProductID:   id,
+			ComponentID: component.ComponentID,
+			Component:   &componentProduct,
+			Quantity:    component.Quantity,
+		})
+	}
+
+	if len(lines) > 0 {
+		if err := tx.Omit("Component").Create(&lines).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update bill of materials",
+			})
+		}
+	}
+
+	recordAudit(c, tx, model.AuditUpdate, "product_bom", id, fiber.Map{"components": before}, fiber.Map{"components": lines})
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Bill of materials updated successfully",
+		"data":    lines,
+	})
+}
+
+// LogProduction Зарегистрировать выпуск продукции
+//
+//	@Summary		Зарегистрировать выпуск продукции
+//	@Description	Приходует готовый продукт на склад и списывает с того же склада компоненты по спецификации
+//	@Tags			warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			production	body		model.LogProductionRequest	true	"Продукт и количество"
+//	@Success		201			{object}	model.ProductionLog			"Выпуск зарегистрирован"
+//	@Failure		400			{object}	APIError					"Недостаточно компонентов на складе"
+//	@Failure		403			{object}	APIError					"Склад недоступен менеджеру"
+//	@Failure		404			{object}	APIError					"Продукт или склад не найден"
+//	@Failure		422			{object}	APIError					"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError					"Ошибка сервера"
+//	@Router			/warehouse/production [post]
+func LogProduction(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.LogProductionRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var product model.Product
+	if err := tx.First(&product, "id = ?", body.ProductID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Product not found",
+		})
+	}
+
+	warehouseID, err := resolveWarehouseID(tx, body.WarehouseID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Warehouse not found",
+		})
+	}
+
+	allowed, err := warehouseAllowed(c, tx, &warehouseID)
+	if err != nil || !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "Production can only be logged for your own warehouse",
+		})
+	}
+
+	var lines []model.BOMLine
+	if err := tx.Preload("Component", unscoped).Where("product_id = ?", product.ID).Find(&lines).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve bill of materials",
+		})
+	}
+
+	// Выпущенная продукция поступает по себестоимости израсходованных компонентов
+	if len(lines) > 0 {
+		if err := applyIncomingCost(tx, product.ID, body.Quantity, bomUnitCost(lines)); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update product cost",
+			})
+		}
+	}
+
+	for _, line := range lines {
+		if err := adjustStock(tx, warehouseID, line.ComponentID, -line.Quantity*body.Quantity); err != nil {
+			return stockErrorResponse(c, err)
+		}
+	}
+
+	if err := adjustStock(tx, warehouseID, product.ID, body.Quantity); err != nil {
+		return stockErrorResponse(c, err)
+	}
+
+	productionLog := model.ProductionLog{
+		ID:          guuid.New(),
+		ProductID:   product.ID,
+		Quantity:    body.Quantity,
+		WarehouseID: &warehouseID,
+		CreatedByID: &user.ID,
+	}
+	if err := tx.Create(&productionLog).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to log production",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditCreate, "production_log", productionLog.ID, nil, productionLog)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Production logged successfully",
+		"data":    productionLog,
+	})
+}
+
+// RecalculateCostFromBOM Рассчитать себестоимость по спецификации
+//
+//	@Summary		Рассчитать себестоимость по спецификации
+//	@Description	Устанавливает себестоимость продукта равной сумме себестоимостей компонентов с учётом расхода на единицу
+//	@Tags			Products
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID продукта"
+//	@Success		200	{object}	model.Product	"Продукт с новой себестоимостью"
+//	@Failure		400	{object}	APIError		"Неверный формат ID"
+//	@Failure		404	{object}	APIError		"Продукт не найден"
+//	@Failure		422	{object}	APIError		"У продукта нет спецификации"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/products/{id}/cost/from-bom [post]
+func RecalculateCostFromBOM(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Product ID",
+		})
+	}
+
+	db := database.DB
+	var product model.Product
+	if err := db.First(&product, "id = ?", id).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Product not found",
+		})
+	}
+
+	var lines []model.BOMLine
+	if err := db.Preload("Component", unscoped).Where("product_id = ?", id).Find(&lines).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve bill of materials",
+		})
+	}
+	if len(lines) == 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": "Product has no bill of materials",
+		})
+	}
+
+	before := product
+	product.CostPrice = bomUnitCost(lines)
+	if err := db.Model(&product).UpdateColumn("cost_price", product.CostPrice).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update product cost",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "product", product.ID, before, product)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Product cost recalculated successfully",
+		"data":    product,
+	})
+}
+
+// GetMaterialRequirements Потребность в материалах
+//
+//	@Summary		Потребность в материалах
+//	@Description	Суммирует расход компонентов по спецификациям для всех принятых заказов и сравнивает его с остатками
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			warehouseId	query		string					false	"Учитывать только заказы и остатки этого склада"
+//	@Success		200			{array}		MaterialRequirement		"Потребность по компонентам"
+//	@Failure		400			{object}	APIError				"Неверный формат ID склада"
+//	@Failure		500			{object}	APIError				"Ошибка сервера"
+//	@Router			/warehouse/material-requirements [get]
+func GetMaterialRequirements(c *fiber.Ctx) error {
+	db := database.DB
+
+	var warehouseID *guuid.UUID
+	if raw := c.Query("warehouseId"); raw != "" {
+		id, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid warehouseId format",
+			})
+		}
+		warehouseID = &id
+	}
+
+	var items []model.OrderItem
+	query := db.Joins("JOIN orders ON orders.id = order_items.order_id").Where("orders.status = ?", "accepted")
+	if warehouseID != nil {
+		query = query.Where("orders.warehouse_id = ?", *warehouseID)
+	}
+	if err := query.Find(&items).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve accepted orders",
+		})
+	}
+
+	requirements, err := materialRequirements(db, items, warehouseID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to calculate material requirements",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    requirements,
+	})
+}
+
+// materialRequirements рассчитывает расход компонентов на позиции заказов. Если склад не указан,
+// потребность сравнивается с общим остатком по всем складам.
+func materialRequirements(db *gorm.DB, items []model.OrderItem, warehouseID *guuid.UUID) ([]MaterialRequirement, error) {
+	quantities := map[guuid.UUID]float64{}
+	for _, item := range items {
+		quantities[item.ProductID] += item.Quantity
+	}
+	if len(quantities) == 0 {
+		return []MaterialRequirement{}, nil
+	}
+
+	productIDs := make([]guuid.UUID, 0, len(quantities))
+	for id := range quantities {
+		productIDs = append(productIDs, id)
+	}
+
+	var lines []model.BOMLine
+	if err := db.Preload("Component", unscoped).Where("product_id IN ?", productIDs).Find(&lines).Error; err != nil {
+		return nil, err
+	}
+
+	byComponent := map[guuid.UUID]*MaterialRequirement{}
+	for _, line := range lines {
+		requirement, ok := byComponent[line.ComponentID]
+		if !ok {
+			requirement = &MaterialRequirement{ComponentID: line.ComponentID}
+			if line.Component != nil {
+				requirement.ComponentName = line.Component.Name
+				requirement.Unit = line.Component.Unit
+				requirement.Available = line.Component.Amount
+			}
+			byComponent[line.ComponentID] = requirement
+		}
+		requirement.Required += line.Quantity * quantities[line.ProductID]
+	}
+
+	result := make([]MaterialRequirement, 0, len(byComponent))
+	for _, requirement := range byComponent {
+		if warehouseID != nil {
+			available, err := availableStock(db, *warehouseID, requirement.ComponentID)
+			if err != nil {
+				return nil, err
+			}
+			requirement.Available = available
+		}
+		if requirement.Required > requirement.Available {
+			requirement.Shortage = requirement.Required - requirement.Available
+		}
+		result = append(result, *requirement)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Shortage != result[j].Shortage {
+			return result[i].Shortage > result[j].Shortage
+		}
+		return result[i].ComponentName < result[j].ComponentName
+	})
+	return result, nil
+}
+
+// bomUnitCost возвращает себестоимость единицы продукта по текущим себестоимостям компонентов.
+// Строки должны быть загружены вместе с Component.
+func bomUnitCost(lines []model.BOMLine) float64 {
+	var cost float64
+	for _, line := range lines {
+		if line.Component != nil {
+			cost += line.Component.CostPrice * line.Quantity
+		}
+	}
+	return cost
+}
+
+// bomContains проверяет, входит ли target в спецификацию product на любом уровне вложенности.
+func bomContains(db *gorm.DB, product, target guuid.UUID) (bool, error) {
+	visited := map[guuid.UUID]bool{}
+	queue := []guuid.UUID{product}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		var componentIDs []guuid.UUID
+		if err := db.Model(&model.BOMLine{}).Where("product_id = ?", current).Pluck("component_id", &componentIDs).Error; err != nil {
+			return false, err
+		}
+		for _, id := range componentIDs {
+			if id == target {
+				return true, nil
+			}
+			queue = append(queue, id)
+		}
+	}
+	return false, nil
+}
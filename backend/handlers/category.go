@@ -66,6 +66,8 @@ func CreateCategory(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, DB, model.AuditCreate, "category", category.ID, nil, category)
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"status":  201,
 		"success": true,
@@ -222,6 +224,7 @@ func UpdateCategory(c *fiber.Ctx) error {
 		})
 	}
 
+	before := category
 	category.Name = json.Name
 
 	if err := db.Save(&category).Error; err != nil {
@@ -232,6 +235,8 @@ func UpdateCategory(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, db, model.AuditUpdate, "category", category.ID, before, category)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  200,
 		"success": true,
@@ -264,6 +269,22 @@ func DeleteCategory(c *fiber.Ctx) error {
 	}
 
 	db := database.DB
+	var category model.Category
+	if err := db.First(&category, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Category not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
 	if err := db.Where("id = ?", id).Delete(&model.Category{}).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  500,
@@ -272,6 +293,8 @@ func DeleteCategory(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, db, model.AuditDelete, "category", category.ID, category, nil)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  200,
 		"success": false,
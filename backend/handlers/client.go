@@ -99,6 +99,8 @@ func CreateClient(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, database.DB, model.AuditCreate, "client", client.ID, nil, client)
+
 	// Возвращаем данные созданного клиента
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"status":  201,
@@ -272,6 +274,8 @@ func UpdateClient(c *fiber.Ctx) error {
 		})
 	}
 
+	before := client
+
 	if json.Name != nil {
 		client.Name = *json.Name
 	}
@@ -298,6 +302,8 @@ func UpdateClient(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, db, model.AuditUpdate, "client", client.ID, before, client)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  200,
 		"success": true,
@@ -359,6 +365,8 @@ func DeleteClient(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, db, model.AuditDelete, "client", client.ID, client, nil)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  fiber.StatusOK,
 		"data":    client,
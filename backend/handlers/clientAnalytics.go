@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	SegmentNew     = "new"
+	SegmentLoyal   = "loyal"
+	SegmentRegular = "regular"
+	SegmentAtRisk  = "at_risk"
+	SegmentLost    = "lost"
+)
+
+// ClientRFM — давность, частота и сумма покупок клиента. Баллы от 1 до 5 — квинтиль
+// среди всех клиентов с покупками, 5 — лучший.
+type ClientRFM struct {
+	ClientID           guuid.UUID `json:"clientId"`
+	ClientName         string     `json:"clientName"`
+	SalespersonID      guuid.UUID `json:"salespersonId"`
+	FirstOrderDay      time.Time  `json:"firstOrderDay"`
+	LastOrderDay       time.Time  `json:"lastOrderDay"`
+	DaysSinceLastOrder int        `json:"daysSinceLastOrder"`
+	Frequency          int        `json:"frequency"`
+	Monetary           float64    `json:"monetary"`
+	RecencyScore       int        `json:"recencyScore"`
+	FrequencyScore     int        `json:"frequencyScore"`
+	MonetaryScore      int        `json:"monetaryScore"`
+	Segment            string     `json:"segment"`
+}
+
+// rfmThresholds — границы сегментов в днях.
+type rfmThresholds struct {
+	NewDays    int
+	AtRiskDays int
+	LostDays   int
+}
+
+// GetClientRFM RFM-сегментация клиентов
+//
+//	@Summary		RFM-сегментация клиентов
+//	@Description	Для каждого клиента с покупками считает давность, частоту и сумму покупок (см. REVENUE_RECOGNITION), баллы 1–5 и сегмент: new — первая покупка не раньше newDays дней назад, at_risk — нет покупок дольше atRiskDays, lost — дольше lostDays, loyal — высокие баллы давности и частоты, остальные — regular.
+//	@Tags			Statistics
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			segment		query		string		false	"Сегмент (new, loyal, regular, at_risk, lost)"
+//	@Param			sellerId	query		string		false	"ID продавца"
+//	@Param			newDays		query		int			false	"Порог нового клиента в днях"	default(30)
+//	@Param			atRiskDays	query		int			false	"Порог риска оттока в днях"		default(60)
+//	@Param			lostDays	query		int			false	"Порог потерянного клиента в днях"	default(180)
+//	@Param			page		query		int			false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int			false	"Размер страницы"	default(10)
+//	@Success		200			{array}		ClientRFM	"Клиенты с баллами и сегментами"
+//	@Failure		400			{object}	APIError	"Неверные параметры запроса"
+//	@Failure		500			{object}	APIError	"Ошибка сервера"
+//	@Router			/statistics/clients [get]
+func GetClientRFM(c *fiber.Ctx) error {
+	thresholds, err := parseRFMThresholds(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	var sellerID *guuid.UUID
+	if raw := c.Query("sellerId"); raw != "" {
+		parsed, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid sellerId format",
+			})
+		}
+		sellerID = &parsed
+	}
+
+	clients, err := clientRFM(database.DB, thresholds)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to calculate client segments",
+		})
+	}
+
+	// Сводка по сегментам считается до фильтра по сегменту
+	segment := c.Query("segment")
+	summary := map[string]int{}
+	filtered := []ClientRFM{}
+	for _, client := range clients {
+		if sellerID != nil && client.SalespersonID != *sellerID {
+			continue
+		}
+		summary[client.Segment]++
+		if segment == "" || client.Segment == segment {
+			filtered = append(filtered, client)
+		}
+	}
+
+	page, err := strconv.Atoi(c.Query("page", "1"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(c.Query("pageSize", "10"))
+	if err != nil || pageSize < 1 {
+		pageSize = 10
+	}
+	total := len(filtered)
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    filtered[start:end],
+		"summary": summary,
+		"pagination": fiber.Map{
+			"page":       page,
+			"pageSize":   pageSize,
+			"total":      total,
+			"totalPages": (total + pageSize - 1) / pageSize,
+		},
+	})
+}
+
+// GetSellerAtRiskClients Клиенты продавца под риском оттока
+//
+//	@Summary		Клиенты продавца под риском оттока
+//	@Description	Клиенты текущего продавца из сегмента at_risk, самые ценные первыми. Администратор и менеджер могут указать sellerId.
+//	@Tags			Statistics
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			sellerId	query		string		false	"ID продавца (только для admin и manager)"
+//	@Param			atRiskDays	query		int			false	"Порог риска оттока в днях"		default(60)
+//	@Param			lostDays	query		int			false	"Порог потерянного клиента в днях"	default(180)
+//	@Success		200			{array}		ClientRFM	"Клиенты под риском оттока"
+//	@Failure		400			{object}	APIError	"Неверные параметры запроса"
+//	@Failure		500			{object}	APIError	"Ошибка сервера"
+//	@Router			/getstatsof/seller/at-risk [get]
+func GetSellerAtRiskClients(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+	sellerID := user.ID
+
+	if raw := c.Query("sellerId"); raw != "" && user.Role != model.Seller {
+		parsed, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid sellerId format",
+			})
+		}
+		sellerID = parsed
+	}
+
+	thresholds, err := parseRFMThresholds(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	clients, err := clientRFM(database.DB, thresholds)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to calculate client segments",
+		})
+	}
+
+	atRisk := []ClientRFM{}
+	for _, client := range clients {
+		if client.SalespersonID == sellerID && client.Segment == SegmentAtRisk {
+			atRisk = append(atRisk, client)
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    atRisk,
+	})
+}
+
+// clientRFM считает показатели по дневным итогам продаж; клиенты упорядочены по сумме покупок.
+func clientRFM(db *gorm.DB, thresholds rfmThresholds) ([]ClientRFM, error) {
+	// Даты из базы приходят без часового пояса, поэтому сегодняшнюю дату тоже берём в UTC
+	now := utils.BusinessNow()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var clients []ClientRFM
+	err := db.Raw(`
+		WITH stats AS (
+			SELECT client_id, MIN(day) AS first_order_day, MAX(day) AS last_order_day,
+				SUM(order_count) AS frequency, SUM(revenue) AS monetary
+			FROM daily_sales_rollups
+			GROUP BY client_id
+		)
+		SELECT clients.id AS client_id, CONCAT(clients.name, ' ', clients.surname) AS client_name,
+			clients.salesperson_id, stats.first_order_day, stats.last_order_day,
+			?::date - stats.last_order_day AS days_since_last_order,
+			stats.frequency, stats.monetary,
+			NTILE(5) OVER (ORDER BY stats.last_order_day) AS recency_score,
+			NTILE(5) OVER (ORDER BY stats.frequency) AS frequency_score,
+			NTILE(5) OVER (ORDER BY stats.monetary) AS monetary_score
+		FROM stats
+		JOIN clients ON clients.id = stats.client_id
+		ORDER BY stats.monetary DESC`, today.Format("2006-01-02")).
+		Scan(&clients).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range clients {
+		clients[i].Segment = rfmSegment(clients[i], today, thresholds)
+	}
+	return clients, nil
+}
+
+func rfmSegment(client ClientRFM, today time.Time, thresholds rfmThresholds) string {
+	daysSinceFirst := int(today.Sub(client.FirstOrderDay).Hours() / 24)
+
+	switch {
+	case client.DaysSinceLastOrder > thresholds.LostDays:
+		return SegmentLost
+	case client.DaysSinceLastOrder > thresholds.AtRiskDays:
+		return SegmentAtRisk
+	case daysSinceFirst <= thresholds.NewDays:
+		return SegmentNew
+	case client.RecencyScore >= 4 && client.FrequencyScore >= 4:
+		return SegmentLoyal
+	default:
+		return SegmentRegular
+	}
+}
+
+func parseRFMThresholds(c *fiber.Ctx) (rfmThresholds, error) {
+	thresholds := rfmThresholds{
+		NewDays:    c.QueryInt("newDays", 30),
+		AtRiskDays: c.QueryInt("atRiskDays", 60),
+		LostDays:   c.QueryInt("lostDays", 180),
+	}
+	if thresholds.NewDays < 0 || thresholds.AtRiskDays <= 0 || thresholds.LostDays <= thresholds.AtRiskDays {
+		return thresholds, errors.New("thresholds must satisfy newDays >= 0, atRiskDays > 0 and lostDays > atRiskDays")
+	}
+	return thresholds, nil
+}
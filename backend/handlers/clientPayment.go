@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var errInvalidClientID = errors.New("invalid client id")
+
+// findAccessibleClient загружает клиента из параметра id. Продавцы видят только своих клиентов.
+func findAccessibleClient(c *fiber.Ctx, db *gorm.DB) (model.Client, error) {
+	var client model.Client
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return client, errInvalidClientID
+	}
+
+	query := db.Where("id = ?", id)
+	if user := c.Locals("user").(*auth.Claims); user.Role == model.Seller {
+		query = query.Where("salesperson_id = ?", user.ID)
+	}
+	err = query.First(&client).Error
+	return client, err
+}
+
+func clientErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errInvalidClientID):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format",
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Client not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal server error",
+	})
+}
+
+// CreateClientPayment Записать оплату клиента
+//
+//	@Summary		Записать оплату клиента
+//	@Description	Записывает полученную от клиента оплату. Оплаты уменьшают сальдо в выписке по клиенту. Продавцы записывают оплаты только своих клиентов.
+//	@Tags			Clients
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"UUID клиента"
+//	@Param			payment	body		model.CreateClientPaymentRequest	true	"Оплата"
+//	@Success		201		{object}	model.ClientPayment				"Оплата записана"
+//	@Failure		400		{object}	APIError						"Некорректный запрос или заказ другого клиента"
+//	@Failure		404		{object}	APIError						"Клиент не найден"
+//	@Failure		422		{object}	APIError						"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError						"Ошибка сервера"
+//	@Router			/clients/{id}/payments [post]
+func CreateClientPayment(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+	db := database.DB
+
+	client, err := findAccessibleClient(c, db)
+	if err != nil {
+		return clientErrorResponse(c, err)
+	}
+
+	body := new(model.CreateClientPaymentRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	if body.OrderID != nil {
+		var count int64
+		if err := db.Model(&model.Order{}).Where("id = ? AND client_id = ?", *body.OrderID, client.ID).Count(&count).Error; err != nil {
+			return clientErrorResponse(c, err)
+		}
+		if count == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Order does not belong to this client",
+			})
+		}
+	}
+
+	payment := model.ClientPayment{
+		ID:          guuid.New(),
+		ClientID:    client.ID,
+		OrderID:     body.OrderID,
+		Amount:      body.Amount,
+		Method:      body.Method,
+		PaidAt:      utils.BusinessNow(),
+		Note:        body.Note,
+		CreatedByID: user.ID,
+	}
+	if body.PaidAt != nil {
+		payment.PaidAt = *body.PaidAt
+	}
+
+	if err := db.Create(&payment).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not record payment",
+		})
+	}
+
+	recordAudit(c, db, model.AuditCreate, "client_payment", payment.ID, nil, payment)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Payment recorded successfully",
+		"data":    payment,
+	})
+}
+
+// GetClientPayments Оплаты клиента
+//
+//	@Summary		Оплаты клиента
+//	@Description	Возвращает оплаты клиента, новые сначала
+//	@Tags			Clients
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string				true	"UUID клиента"
+//	@Param			page		query		int					false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int					false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.ClientPayment	"Оплаты с информацией о пагинации"
+//	@Failure		400			{object}	APIError			"Некорректный формат UUID"
+//	@Failure		404			{object}	APIError			"Клиент не найден"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/clients/{id}/payments [get]
+func GetClientPayments(c *fiber.Ctx) error {
+	db := database.DB
+	client, err := findAccessibleClient(c, db)
+	if err != nil {
+		return clientErrorResponse(c, err)
+	}
+
+	payments := []model.ClientPayment{}
+	response, err := utils.Paginate(db.Where("client_id = ?", client.ID).Order("paid_at desc"), c, nil, &payments)
+	if err != nil {
+		return clientErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// DeleteClientPayment Удалить оплату клиента
+//
+//	@Summary		Удалить оплату клиента
+//	@Description	Удаляет ошибочно записанную оплату
+//	@Tags			Clients
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string				true	"UUID клиента"
+//	@Param			paymentId	path		string				true	"ID оплаты"
+//	@Success		200			{object}	model.ClientPayment	"Оплата удалена"
+//	@Failure		400			{object}	APIError			"Некорректный формат UUID"
+//	@Failure		404			{object}	APIError			"Оплата не найдена"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/clients/{id}/payments/{paymentId} [delete]
+func DeleteClientPayment(c *fiber.Ctx) error {
+	db := database.DB
+	client, err := findAccessibleClient(c, db)
+	if err != nil {
+		return clientErrorResponse(c, err)
+	}
+	paymentID, err := guuid.Parse(c.Params("paymentId"))
+	if err != nil {
+		return clientErrorResponse(c, errInvalidClientID)
+	}
+
+	var payment model.ClientPayment
+	if err := db.Where("id = ? AND client_id = ?", paymentID, client.ID).First(&payment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Payment not found",
+			})
+		}
+		return clientErrorResponse(c, err)
+	}
+
+	if err := db.Delete(&payment).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not delete payment",
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "client_payment", payment.ID, payment, nil)
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Payment deleted successfully",
+		"data":    payment,
+	})
+}
@@ -0,0 +1,290 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"github.com/signintech/gopdf"
+	"gorm.io/gorm"
+)
+
+// statementEntry — строка выписки: заказ увеличивает сальдо, оплата уменьшает.
+// У заказа задан Number (номер счёта-фактуры или ID заказа), у оплаты — Method.
+type statementEntry struct {
+	Date    time.Time
+	Number  string
+	Method  string
+	Note    string
+	Charged float64
+	Paid    float64
+}
+
+// clientStatement — расчёты с клиентом за полуинтервал [From, To) в часовом поясе бизнеса.
+// Сальдо — задолженность клиента: начальный баланс клиента плюс сумма заказов, входящих в выручку (utils.RevenueStatuses), минус оплаты.
+type clientStatement struct {
+	Client  model.Client
+	Company model.CompanySettings
+	From    time.Time
+	To      time.Time
+	Opening float64
+	Entries []statementEntry
+}
+
+// GetClientStatement Выписка по клиенту
+//
+//	@Summary		Выписка по клиенту
+//	@Description	Печатает выписку по расчётам с клиентом за период: сальдо на начало, заказы и оплаты по датам с текущим сальдо, обороты и сальдо на конец.
+//	@Description	Сальдо — начальный баланс клиента (balance) плюс сумма заказов, входящих в выручку (как в статистике, по REVENUE_RECOGNITION), минус оплаты из /clients/{id}/payments. Продавцы получают выписки только своих клиентов.
+//	@Tags			Clients
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			id		path		string		true	"UUID клиента"
+//	@Param			from	query		string		false	"Начало периода (YYYY-MM-DD), по умолчанию начало месяца"
+//	@Param			to		query		string		false	"Конец периода включительно (YYYY-MM-DD), по умолчанию сегодня"
+//	@Param			lang	query		string		false	"Язык документа (ru, uz)"	default(ru)
+//	@Success		200		{file}		file		"PDF-документ"
+//	@Failure		400		{object}	APIError	"Неверные параметры запроса"
+//	@Failure		404		{object}	APIError	"Клиент не найден"
+//	@Failure		500		{object}	APIError	"Ошибка сервера"
+//	@Router			/clients/{id}/statement.pdf [get]
+func GetClientStatement(c *fiber.Ctx) error {
+	db := database.DB
+	client, err := findAccessibleClient(c, db)
+	if err != nil {
+		return clientErrorResponse(c, err)
+	}
+
+	language := c.Query("lang", "ru")
+	if _, ok := documentLabels[language]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid lang. Use ru or uz",
+		})
+	}
+	from, to, err := statementPeriod(c.Query("from"), c.Query("to"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	statement, err := loadClientStatement(db, client, from, to)
+	if err != nil {
+		return clientErrorResponse(c, err)
+	}
+	statement.Company, err = loadCompanySettings(db)
+	if err != nil {
+		return clientErrorResponse(c, err)
+	}
+
+	content, err := renderClientStatement(statement, language)
+	if err != nil {
+		log.Printf("PDF generation failed: %v | ClientID: %s", err, client.ID)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to generate statement",
+		})
+	}
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=statement-%s-%s.pdf",
+		from.Format("20060102"), to.AddDate(0, 0, -1).Format("20060102")))
+	return c.Send(content)
+}
+
+// statementPeriod разбирает границы периода (YYYY-MM-DD, включительно) и возвращает полуинтервал.
+func statementPeriod(rawFrom, rawTo string) (time.Time, time.Time, error) {
+	location := utils.BusinessLocation()
+	now := utils.BusinessNow()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location).AddDate(0, 0, 1)
+
+	if rawFrom != "" {
+		day, err := time.ParseInLocation("2006-01-02", rawFrom, location)
+		if err != nil {
+			return from, to, errors.New("invalid from format, expected YYYY-MM-DD")
+		}
+		from = day
+	}
+	if rawTo != "" {
+		day, err := time.ParseInLocation("2006-01-02", rawTo, location)
+		if err != nil {
+			return from, to, errors.New("invalid to format, expected YYYY-MM-DD")
+		}
+		// Включаем весь указанный день
+		to = day.AddDate(0, 0, 1)
+	}
+	if !from.Before(to) {
+		return from, to, errors.New("from must not be after to")
+	}
+	return from, to, nil
+}
+
+// loadClientStatement считает сальдо на начало периода и собирает заказы и оплаты за период.
+func loadClientStatement(db *gorm.DB, client model.Client, from, to time.Time) (clientStatement, error) {
+	statement := clientStatement{Client: client, From: from, To: to}
+
+	var charged, paid float64
+	err := db.Model(&model.Order{}).
+		Where("client_id = ? AND status IN ? AND created_at < ?", client.ID, utils.RevenueStatuses(), from).
+		Select("COALESCE(SUM(total_price), 0)").Scan(&charged).Error
+	if err != nil {
+		return statement, err
+	}
+	err = db.Model(&model.ClientPayment{}).
+		Where("client_id = ? AND paid_at < ?", client.ID, from).
+		Select("COALESCE(SUM(amount), 0)").Scan(&paid).Error
+	if err != nil {
+		return statement, err
+	}
+	// Balance — долг клиента до начала учёта в системе (задаётся, например, при импорте клиентов)
+	statement.Opening = float64(client.Balance) + charged - paid
+
+	var orders []model.Order
+	err = db.Select("id", "total_price", "created_at").
+		Where("client_id = ? AND status IN ? AND created_at >= ? AND created_at < ?", client.ID, utils.RevenueStatuses(), from, to).
+		Order("created_at").Find(&orders).Error
+	if err != nil {
+		return statement, err
+	}
+
+	// В выписке заказ называется номером счёта-фактуры, если счёт уже выдан
+	invoices := map[guuid.UUID]string{}
+	if len(orders) > 0 {
+		ids := make([]guuid.UUID, len(orders))
+		for i, order := range orders {
+			ids[i] = order.ID
+		}
+		var documents []model.OrderDocument
+		err := db.Select("order_id", "number").
+			Where("order_id IN ? AND type = ?", ids, model.DocumentInvoice).
+			Find(&documents).Error
+		if err != nil {
+			return statement, err
+		}
+		for _, document := range documents {
+			invoices[document.OrderID] = document.Number
+		}
+	}
+
+	var payments []model.ClientPayment
+	err = db.Where("client_id = ? AND paid_at >= ? AND paid_at < ?", client.ID, from, to).
+		Order("paid_at").Find(&payments).Error
+	if err != nil {
+		return statement, err
+	}
+
+	for _, order := range orders {
+		number, ok := invoices[order.ID]
+		if !ok {
+			number = order.ID.String()
+		}
+		statement.Entries = append(statement.Entries, statementEntry{Date: order.CreatedAt, Number: number, Charged: order.TotalPrice})
+	}
+	for _, payment := range payments {
+		statement.Entries = append(statement.Entries, statementEntry{Date: payment.PaidAt, Method: payment.Method, Note: payment.Note, Paid: payment.Amount})
+	}
+	sort.SliceStable(statement.Entries, func(i, j int) bool {
+		return statement.Entries[i].Date.Before(statement.Entries[j].Date)
+	})
+
+	return statement, nil
+}
+
+// statementDocument описывает строку выписки: «Заказ INV-2026-000123» или «Оплата (наличные), комментарий».
+func statementDocument(labels map[string]string, entry statementEntry) string {
+	if entry.Method == "" {
+		return fmt.Sprintf(labels["entry.order"], entry.Number)
+	}
+	return joinNonEmpty(", ", fmt.Sprintf(labels["entry.payment"], documentLabel(labels, "pay.", entry.Method)), entry.Note)
+}
+
+// renderClientStatement печатает выписку: шапку компании, клиента и период, таблицу заказов
+// и оплат с текущим сальдо, обороты и сальдо на конец периода.
+func renderClientStatement(statement clientStatement, language string) ([]byte, error) {
+	labels, ok := documentLabels[language]
+	if !ok {
+		labels = documentLabels["ru"]
+	}
+	d, err := newDocumentPDF("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	location := utils.BusinessLocation()
+	client := statement.Client
+	d.companyHeader(statement.Company, labels)
+
+	d.cell(docMargin, d.y, docContentWidth, labels["statement"], true, docTitleSize, gopdf.Center, accentColor)
+	d.y += 20
+	d.cell(docMargin, d.y, docContentWidth, fmt.Sprintf(labels["statementPeriod"],
+		statement.From.In(location).Format("02.01.2006"), statement.To.AddDate(0, 0, -1).In(location).Format("02.01.2006")),
+		false, docFontSize+1, gopdf.Center, primaryColor)
+	d.y += 25
+
+	d.paragraph(docMargin, docContentWidth, fmt.Sprintf("%s: %s", labels["client"], strings.TrimSpace(client.Name+" "+client.Surname)), true, docFontSize, primaryColor)
+	for _, line := range []string{prefixed(labels["address"], client.Address), prefixed(labels["contact"], client.ContactInfo)} {
+		if line != "" {
+			d.paragraph(docMargin, docContentWidth, line, false, docFontSize, secondaryColor)
+		}
+	}
+	d.y += 10
+
+	balance := statement.Opening
+	var charged, paid float64
+	rows := [][]string{{"", labels["openingBalance"], "", "", formatDocumentAmount(balance)}}
+	for _, entry := range statement.Entries {
+		balance += entry.Charged - entry.Paid
+		charged += entry.Charged
+		paid += entry.Paid
+		rows = append(rows, []string{
+			entry.Date.In(location).Format("02.01.2006"),
+			statementDocument(labels, entry),
+			statementAmount(entry.Charged),
+			statementAmount(entry.Paid),
+			formatDocumentAmount(balance),
+		})
+	}
+	rows = append(rows,
+		[]string{"", labels["turnover"], formatDocumentAmount(charged), formatDocumentAmount(paid), ""},
+		[]string{"", labels["closingBalance"], "", "", formatDocumentAmount(balance)},
+	)
+	d.table([]documentColumn{
+		{labels["col.date"], 65, gopdf.Center},
+		{labels["col.document"], 185, gopdf.Left},
+		{labels["col.charged"], 85, gopdf.Right},
+		{labels["col.paid"], 85, gopdf.Right},
+		{labels["col.balance"], docContentWidth - 420, gopdf.Right},
+	}, rows)
+
+	d.y += 8
+	d.paragraph(docMargin, docContentWidth, fmt.Sprintf("%s: %s %s", labels["closingBalance"], formatDocumentAmount(balance), statement.Company.Currency), true, docFontSize, primaryColor)
+	d.paragraph(docMargin, docContentWidth, labels["balanceNote"], false, docSmallSize, secondaryColor)
+	d.signatures([][2]string{
+		{labels["sign.accountant"], statement.Company.AccountantName},
+		{labels["client"], ""},
+	})
+
+	return d.bytes(labels["page"])
+}
+
+// statementAmount выводит сумму строки выписки; нулевая колонка остаётся пустой.
+func statementAmount(value float64) string {
+	if value == 0 {
+		return ""
+	}
+	return formatDocumentAmount(value)
+}
@@ -0,0 +1,700 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateCommissionRule Создать правило комиссии
+//
+//	@Summary		Создать правило комиссии
+//	@Description	Создаёт правило комиссии для продавца или, без sellerId, правило по умолчанию. У продавца может быть только одно правило.
+//	@Tags			Commissions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			rule	body		model.CommissionRuleRequest	true	"Ставка и уровни"
+//	@Success		201		{object}	model.CommissionRule		"Правило создано"
+//	@Failure		400		{object}	APIError					"Некорректный запрос"
+//	@Failure		404		{object}	APIError					"Продавец не найден"
+//	@Failure		409		{object}	APIError					"Правило уже существует"
+//	@Failure		422		{object}	APIError					"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError					"Ошибка сервера"
+//	@Router			/commissions/rules [post]
+func CreateCommissionRule(c *fiber.Ctx) error {
+	body := new(model.CommissionRuleRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	if body.SellerID != nil {
+		var seller model.User
+		if err := tx.First(&seller, "id = ? AND role = ?", body.SellerID, model.Seller).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Seller not found",
+			})
+		}
+	}
+
+	existing := tx.Model(&model.CommissionRule{})
+	if body.SellerID != nil {
+		existing = existing.Where("seller_id = ?", body.SellerID)
+	} else {
+		existing = existing.Where("seller_id IS NULL")
+	}
+	var count int64
+	if err := existing.Count(&count).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create commission rule",
+		})
+	}
+	if count > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status":  409,
+			"success": false,
+			"message": "Commission rule for this seller already exists",
+		})
+	}
+
+	rule := model.CommissionRule{
+		ID:       guuid.New(),
+		SellerID: body.SellerID,
+		Rate:     body.Rate,
+	}
+	rule.Tiers = commissionTiers(rule.ID, body.Tiers)
+
+	if err := tx.Create(&rule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create commission rule",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditCreate, "commission_rule", rule.ID, nil, rule)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to finalize transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Commission rule created successfully",
+		"data":    rule,
+	})
+}
+
+// GetCommissionRules Получить правила комиссии
+//
+//	@Summary		Получить правила комиссии
+//	@Tags			Commissions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		model.CommissionRule	"Правила комиссии"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/commissions/rules [get]
+func GetCommissionRules(c *fiber.Ctx) error {
+	rules := []model.CommissionRule{}
+	err := database.DB.Preload("Seller", unscoped).Preload("Tiers", func(db *gorm.DB) *gorm.DB {
+		return db.Order("threshold asc")
+	}).Order("created_at asc").Find(&rules).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve commission rules",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    rules,
+	})
+}
+
+// UpdateCommissionRule Изменить правило комиссии
+//
+//	@Summary		Изменить правило комиссии
+//	@Description	Заменяет ставку и уровни правила. Продавец правила не меняется. Утверждённые начисления не пересчитываются.
+//	@Tags			Commissions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"ID правила"
+//	@Param			rule	body		model.CommissionRuleRequest	true	"Ставка и уровни"
+//	@Success		200		{object}	model.CommissionRule		"Правило обновлено"
+//	@Failure		400		{object}	APIError					"Некорректный запрос"
+//	@Failure		404		{object}	APIError					"Правило не найдено"
+//	@Failure		422		{object}	APIError					"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError					"Ошибка сервера"
+//	@Router			/commissions/rules/{id} [put]
+func UpdateCommissionRule(c *fiber.Ctx) error {
+	body := new(model.CommissionRuleRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	rule, err := findCommissionRule(tx, c.Params("id"))
+	if err != nil {
+		return commissionRuleErrorResponse(c, err)
+	}
+
+	before := rule
+
+	if err := tx.Where("rule_id = ?", rule.ID).Delete(&model.CommissionTier{}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update commission rule",
+		})
+	}
+
+	rule.Rate = body.Rate
+	rule.Tiers = commissionTiers(rule.ID, body.Tiers)
+
+	if err := tx.Save(&rule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update commission rule",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditUpdate, "commission_rule", rule.ID, before, rule)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to finalize transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Commission rule updated successfully",
+		"data":    rule,
+	})
+}
+
+// DeleteCommissionRule Удалить правило комиссии
+//
+//	@Summary		Удалить правило комиссии
+//	@Tags			Commissions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID правила"
+//	@Success		200	{object}	model.CommissionRule	"Правило удалено"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		404	{object}	APIError				"Правило не найдено"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/commissions/rules/{id} [delete]
+func DeleteCommissionRule(c *fiber.Ctx) error {
+	db := database.DB
+	rule, err := findCommissionRule(db, c.Params("id"))
+	if err != nil {
+		return commissionRuleErrorResponse(c, err)
+	}
+
+	if err := db.Select("Tiers").Delete(&rule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to delete commission rule",
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "commission_rule", rule.ID, rule, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Commission rule was removed",
+		"data":    rule,
+	})
+}
+
+// GenerateCommissionStatements Сформировать ведомость комиссий
+//
+//	@Summary		Сформировать ведомость комиссий
+//	@Description	Считает комиссию каждого продавца за месяц по выручке заказов, доставленных в этом месяце (по часовому поясу бизнеса). Черновики пересчитываются, утверждённые начисления не меняются.
+//	@Tags			Commissions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.GenerateCommissionsRequest	true	"Месяц (YYYY-MM)"
+//	@Success		200		{array}		model.CommissionStatement			"Начисления за месяц"
+//	@Failure		400		{object}	APIError							"Некорректный запрос"
+//	@Failure		422		{object}	APIError							"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError							"Ошибка сервера"
+//	@Router			/commissions/statements [post]
+func GenerateCommissionStatements(c *fiber.Ctx) error {
+	body := new(model.GenerateCommissionsRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	from, to, err := monthRange(body.Month)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var revenues []struct {
+		SalespersonID guuid.UUID
+		Revenue       float64
+		OrderCount    int
+	}
+	// Для заказов, доставленных до появления delivered_at, берём время последнего изменения
+	err = tx.Model(&model.Order{}).
+		Select("salesperson_id, COALESCE(SUM(total_price), 0) AS revenue, COUNT(id) AS order_count").
+		Where("status = ?", "delivered").
+		Where("COALESCE(delivered_at, updated_at) >= ? AND COALESCE(delivered_at, updated_at) < ?", from, to).
+		Group("salesperson_id").
+		Scan(&revenues).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to calculate seller revenue",
+		})
+	}
+
+	var rules []model.CommissionRule
+	if err := tx.Preload("Tiers").Find(&rules).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to load commission rules",
+		})
+	}
+	var defaultRule *model.CommissionRule
+	sellerRules := map[guuid.UUID]*model.CommissionRule{}
+	for i := range rules {
+		if rules[i].SellerID == nil {
+			defaultRule = &rules[i]
+		} else {
+			sellerRules[*rules[i].SellerID] = &rules[i]
+		}
+	}
+
+	var existing []model.CommissionStatement
+	if err := tx.Where("month = ?", body.Month).Find(&existing).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to load commission statements",
+		})
+	}
+	statements := map[guuid.UUID]model.CommissionStatement{}
+	for _, statement := range existing {
+		statements[statement.SellerID] = statement
+	}
+
+	sellers := map[guuid.UUID]bool{}
+	for _, revenue := range revenues {
+		sellers[revenue.SalespersonID] = true
+
+		statement, ok := statements[revenue.SalespersonID]
+		if ok && statement.Status == model.CommissionApproved {
+			continue
+		}
+		if !ok {
+			statement = model.CommissionStatement{
+				ID:       guuid.New(),
+				SellerID: revenue.SalespersonID,
+				Month:    body.Month,
+				Status:   model.CommissionDraft,
+			}
+		}
+
+		rule := sellerRules[revenue.SalespersonID]
+		if rule == nil {
+			rule = defaultRule
+		}
+
+		statement.Revenue = revenue.Revenue
+		statement.OrderCount = revenue.OrderCount
+		statement.Rate = 0
+		statement.RuleID = nil
+		if rule != nil {
+			statement.Rate = commissionRate(*rule, revenue.Revenue)
+			statement.RuleID = &rule.ID
+		}
+		statement.Amount = statement.Revenue * statement.Rate / 100
+
+		if err := tx.Save(&statement).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to save commission statement",
+			})
+		}
+	}
+
+	// Черновики продавцов, у которых больше нет доставленной выручки за месяц, удаляем
+	for _, statement := range existing {
+		if !sellers[statement.SellerID] && statement.Status == model.CommissionDraft {
+			if err := tx.Delete(&statement).Error; err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"status":  500,
+					"success": false,
+					"message": "Failed to save commission statement",
+				})
+			}
+		}
+	}
+
+	result := []model.CommissionStatement{}
+	err = tx.Preload("Seller", unscoped).
+		Where("month = ?", body.Month).
+		Order("amount desc").
+		Find(&result).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to load commission statements",
+		})
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to finalize transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Commission statements generated",
+		"data":    result,
+	})
+}
+
+// GetCommissionStatements Получить начисления комиссий
+//
+//	@Summary		Получить начисления комиссий
+//	@Description	Продавец видит только свои начисления
+//	@Tags			Commissions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			month		query		string						false	"Месяц (YYYY-MM)"
+//	@Param			sellerId	query		string						false	"ID продавца"
+//	@Param			status		query		string						false	"Статус (draft, approved)"
+//	@Param			page		query		int							false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int							false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.CommissionStatement	"Начисления с информацией о пагинации"
+//	@Failure		400			{object}	APIError					"Неверные параметры запроса"
+//	@Failure		500			{object}	APIError					"Ошибка сервера"
+//	@Router			/commissions/statements [get]
+func GetCommissionStatements(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+	db := database.DB.Preload("Seller", unscoped)
+
+	if user.Role == model.Seller {
+		db = db.Where("seller_id = ?", user.ID)
+	} else if raw := c.Query("sellerId"); raw != "" {
+		sellerID, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid sellerId format",
+			})
+		}
+		db = db.Where("seller_id = ?", sellerID)
+	}
+	if month := c.Query("month"); month != "" {
+		db = db.Where("month = ?", month)
+	}
+	if status := c.Query("status"); status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	statements := []model.CommissionStatement{}
+	response, err := utils.Paginate(db, c, nil, &statements)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve commission statements",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// ApproveCommissionStatement Утвердить начисление комиссии
+//
+//	@Summary		Утвердить начисление комиссии
+//	@Description	Утверждённое начисление не пересчитывается при повторном формировании ведомости
+//	@Tags			Commissions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string						true	"ID начисления"
+//	@Success		200	{object}	model.CommissionStatement	"Начисление утверждено"
+//	@Failure		400	{object}	APIError					"Начисление уже утверждено"
+//	@Failure		404	{object}	APIError					"Начисление не найдено"
+//	@Failure		500	{object}	APIError					"Ошибка сервера"
+//	@Router			/commissions/statements/{id}/approve [post]
+func ApproveCommissionStatement(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Commission statement ID",
+		})
+	}
+
+	db := database.DB
+	var statement model.CommissionStatement
+	if err := db.First(&statement, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Commission statement not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
+	if statement.Status != model.CommissionDraft {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Commission statement is already approved",
+		})
+	}
+
+	before := statement
+	now := time.Now()
+	statement.Status = model.CommissionApproved
+	statement.ApprovedByID = &user.ID
+	statement.ApprovedAt = &now
+
+	if err := db.Save(&statement).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to approve commission statement",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "commission_statement", statement.ID, before, statement)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Commission statement approved",
+		"data":    statement,
+	})
+}
+
+type commissionExportRow struct {
+	Seller     string
+	Revenue    float64
+	OrderCount int
+	Rate       float64
+	Amount     float64
+	Status     string
+}
+
+// ExportCommissionStatements Экспорт ведомости комиссий
+//
+//	@Summary		Экспорт ведомости комиссий
+//	@Description	Выгружает начисления комиссий за месяц в Excel
+//	@Tags			Export
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		BearerAuth
+//	@Param			month	query		string		true	"Месяц (YYYY-MM)"
+//	@Success		200		{file}		file		"Excel-файл"
+//	@Failure		400		{object}	APIError	"Неверные параметры запроса"
+//	@Failure		500		{object}	APIError	"Ошибка сервера"
+//	@Router			/exports/commissions [get]
+func ExportCommissionStatements(ctx *fiber.Ctx) error {
+	month := ctx.Query("month")
+	if _, err := time.Parse("2006-01", month); err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid month format, expected YYYY-MM",
+		})
+	}
+
+	var statements []model.CommissionStatement
+	err := database.DB.Preload("Seller", unscoped).
+		Where("month = ?", month).
+		Order("amount desc").
+		Find(&statements).Error
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve commission statements",
+		})
+	}
+
+	rows := make([]commissionExportRow, 0, len(statements))
+	for _, statement := range statements {
+		row := commissionExportRow{
+			Revenue:    statement.Revenue,
+			OrderCount: statement.OrderCount,
+			Rate:       statement.Rate,
+			Amount:     statement.Amount,
+			Status:     statement.Status,
+		}
+		if statement.Seller != nil {
+			row.Seller = statement.Seller.Username
+		}
+		rows = append(rows, row)
+	}
+
+	headers := []string{"Seller", "Delivered revenue", "Orders", "Rate, %", "Commission", "Status"}
+	fields := []string{"Seller", "Revenue", "OrderCount", "Rate", "Amount", "Status"}
+	return utils.ExportDataToExcel(ctx, rows, headers, fields, fmt.Sprintf("commissions-%s.xlsx", month))
+}
+
+// commissionRate возвращает ставку самого высокого достигнутого уровня или базовую ставку правила.
+func commissionRate(rule model.CommissionRule, revenue float64) float64 {
+	tiers := append([]model.CommissionTier(nil), rule.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].Threshold < tiers[j].Threshold })
+
+	rate := rule.Rate
+	for _, tier := range tiers {
+		if revenue >= tier.Threshold {
+			rate = tier.Rate
+		}
+	}
+	return rate
+}
+
+func commissionTiers(ruleID guuid.UUID, requests []model.CommissionTierRequest) []model.CommissionTier {
+	tiers := make([]model.CommissionTier, 0, len(requests))
+	for _, request := range requests {
+		tiers = append(tiers, model.CommissionTier{
+			ID:        guuid.New(),
+			RuleID:    ruleID,
+			Threshold: request.Threshold,
+			Rate:      request.Rate,
+		})
+	}
+	return tiers
+}
+
+var errInvalidCommissionRuleID = errors.New("invalid commission rule id")
+
+func findCommissionRule(db *gorm.DB, rawID string) (model.CommissionRule, error) {
+	var rule model.CommissionRule
+
+	id, err := guuid.Parse(rawID)
+	if err != nil {
+		return rule, errInvalidCommissionRuleID
+	}
+
+	err = db.Preload("Tiers").First(&rule, "id = ?", id).Error
+	return rule, err
+}
+
+func commissionRuleErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidCommissionRuleID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Commission rule ID",
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Commission rule not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/model"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// companySettingsID — реквизиты компании хранятся в единственной записи.
+const companySettingsID = 1
+
+// loadCompanySettings возвращает реквизиты компании; пока они не заполнены — пустые с валютой по умолчанию.
+func loadCompanySettings(db *gorm.DB) (model.CompanySettings, error) {
+	settings := model.CompanySettings{ID: companySettingsID, Currency: "UZS"}
+	err := db.First(&settings, companySettingsID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return settings, nil
+	}
+	return settings, err
+}
+
+// GetCompanySettings Реквизиты компании
+//
+//	@Summary		Реквизиты компании
+//	@Description	Возвращает реквизиты, которые печатаются в документах по заказам
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	model.CompanySettings	"Реквизиты компании"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/settings/company [get]
+func GetCompanySettings(c *fiber.Ctx) error {
+	settings, err := loadCompanySettings(database.DB)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve company settings",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Company settings found",
+		"data":    settings,
+	})
+}
+
+// UpdateCompanySettings Изменить реквизиты компании
+//
+//	@Summary		Изменить реквизиты компании
+//	@Description	Сохраняет реквизиты компании. Новые реквизиты применяются ко всем документам, напечатанным после изменения.
+//	@Tags			Settings
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			settings	body		model.UpdateCompanySettingsRequest	true	"Реквизиты компании"
+//	@Success		200			{object}	model.CompanySettings				"Сохранённые реквизиты"
+//	@Failure		400			{object}	APIError							"Неверный формат запроса"
+//	@Failure		422			{object}	APIError							"Ошибка валидации"
+//	@Failure		500			{object}	APIError							"Ошибка сервера"
+//	@Router			/settings/company [put]
+func UpdateCompanySettings(c *fiber.Ctx) error {
+	var body model.UpdateCompanySettingsRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request body",
+		})
+	}
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	db := database.DB
+	settings, err := loadCompanySettings(db)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve company settings",
+		})
+	}
+	before := settings
+
+	settings.Name = body.Name
+	settings.Address = body.Address
+	settings.Phone = body.Phone
+	settings.Email = body.Email
+	settings.TaxID = body.TaxID
+	settings.BankName = body.BankName
+	settings.BankAccount = body.BankAccount
+	settings.BankCode = body.BankCode
+	settings.DirectorName = body.DirectorName
+	settings.AccountantName = body.AccountantName
+	settings.LogoPath = body.LogoPath
+	settings.Currency = body.Currency
+
+	if err := db.Save(&settings).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update company settings",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "company_settings", settings.ID, before, settings)
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Company settings updated",
+		"data":    settings,
+	})
+}
@@ -0,0 +1,361 @@
+package handlers
+
+import (
+	"backend/model"
+	"backend/utils"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/signintech/gopdf"
+)
+
+// documentPlaceholder — подстановка поля в тексте макета: {{client.name}}.
+var documentPlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.]+)\s*\}\}`)
+
+// documentFields возвращает значения полей документа для подстановки в макет.
+// Подписи на языке документа доступны как label.<ключ>, например label.total.
+func documentFields(data orderDocumentData, labels map[string]string) map[string]string {
+	order, company, document := data.Order, data.Company, data.Document
+	location := utils.BusinessLocation()
+
+	fields := map[string]string{
+		"document.title":      labels[document.Type],
+		"document.number":     document.Number,
+		"document.date":       document.IssuedAt.In(location).Format("02.01.2006"),
+		"document.numberDate": fmt.Sprintf(labels["numberDate"], document.Number, document.IssuedAt.In(location).Format("02.01.2006")),
+		"order.id":            order.ID.String(),
+		"order.date":          order.CreatedAt.In(location).Format("02.01.2006"),
+		"order.paymentMethod": documentLabel(labels, "pay.", order.PaymentMethod),
+		"order.total":         formatDocumentAmount(order.TotalPrice),
+		"order.totalInWords":  utils.AmountInWords(order.TotalPrice, company.Currency, data.Language),
+		"order.itemCount":     strconv.Itoa(len(order.Products)),
+		"order.basis":         fmt.Sprintf(labels["basisText"], order.ID),
+		"client.name":         "",
+		"client.address":      "",
+		"client.contact":      "",
+		"salesperson.name":    "",
+		"warehouse.name":      "",
+		"company.name":        company.Name,
+		"company.address":     company.Address,
+		"company.phone":       company.Phone,
+		"company.email":       company.Email,
+		"company.taxId":       company.TaxID,
+		"company.bankName":    company.BankName,
+		"company.bankAccount": company.BankAccount,
+		"company.bankCode":    company.BankCode,
+		"company.director":    company.DirectorName,
+		"company.accountant":  company.AccountantName,
+		"company.currency":    company.Currency,
+	}
+	if order.Client != nil {
+		fields["client.name"] = strings.TrimSpace(order.Client.Name + " " + order.Client.Surname)
+		fields["client.address"] = order.Client.Address
+		fields["client.contact"] = order.Client.ContactInfo
+	}
+	if order.Salesperson != nil {
+		fields["salesperson.name"] = order.Salesperson.Username
+	}
+	if order.Warehouse != nil {
+		fields["warehouse.name"] = order.Warehouse.Name
+	}
+	// Подписи с форматом (numberDate, itemCount, ...) подставляются только готовыми полями
+	for key, label := range labels {
+		if !strings.Contains(label, "%") {
+			fields["label."+key] = label
+		}
+	}
+	return fields
+}
+
+// documentItemFields возвращает значения полей позиции заказа для колонок таблицы.
+func documentItemFields(index int, item model.OrderItem, labels map[string]string) map[string]string {
+	return map[string]string{
+		"item.no":       strconv.Itoa(index + 1),
+		"item.name":     documentProductName(item),
+		"item.category": documentProductCategory(item),
+		"item.unit":     documentLabel(labels, "unit.", documentProductUnit(item)),
+		"item.quantity": formatDocumentQuantity(item.Quantity),
+		"item.price":    formatDocumentAmount(documentUnitPrice(item)),
+		"item.amount":   formatDocumentAmount(item.TotalPrice),
+		"item.blank":    "",
+	}
+}
+
+func fillDocumentText(text string, fields map[string]string) string {
+	return documentPlaceholder.ReplaceAllStringFunc(text, func(match string) string {
+		return fields[documentPlaceholder.FindStringSubmatch(match)[1]]
+	})
+}
+
+func documentAlign(align string) int {
+	switch align {
+	case "center":
+		return gopdf.Center
+	case "right":
+		return gopdf.Right
+	}
+	return gopdf.Left
+}
+
+// documentColumnWidths раздаёт колонкам без ширины оставшееся место поровну.
+func documentColumnWidths(columns []model.DocumentColumn) []float64 {
+	widths := make([]float64, len(columns))
+	fixed, auto := 0.0, 0
+	for i, column := range columns {
+		widths[i] = column.Width
+		fixed += column.Width
+		if column.Width == 0 {
+			auto++
+		}
+	}
+	for i := range widths {
+		if widths[i] == 0 {
+			widths[i] = math.Max(docContentWidth-fixed, 0) / float64(auto)
+		}
+	}
+	return widths
+}
+
+// validateDocumentLayout проверяет то, что не выразить тегами: имена полей в подстановках,
+// ширину таблицы и файлы шрифтов. Ошибка пригодна для ответа клиенту.
+func validateDocumentLayout(layout model.DocumentLayout) error {
+	labels := documentLabels["ru"]
+	known := documentFields(sampleOrderDocumentData(model.DocumentInvoice, "ru", model.CompanySettings{}), labels)
+	knownItem := documentItemFields(0, model.OrderItem{}, labels)
+
+	checkText := func(block int, text string) error {
+		for _, match := range documentPlaceholder.FindAllStringSubmatch(text, -1) {
+			if _, ok := known[match[1]]; !ok {
+				return fmt.Errorf("block %d: unknown field %q", block+1, match[1])
+			}
+		}
+		return nil
+	}
+
+	for _, font := range []string{layout.Font, layout.BoldFont} {
+		if font == "" {
+			continue
+		}
+		if filepath.Base(font) != font || !strings.EqualFold(filepath.Ext(font), ".ttf") {
+			return fmt.Errorf("font %q must be a .ttf file name", font)
+		}
+		if _, err := os.Stat(documentFontPath(font)); err != nil {
+			return fmt.Errorf("font %q not found", font)
+		}
+	}
+
+	for i, block := range layout.Blocks {
+		texts := []string{block.Text, block.Label}
+		if block.Type == "field" {
+			if _, ok := known[block.Field]; !ok {
+				return fmt.Errorf("block %d: unknown field %q", i+1, block.Field)
+			}
+		}
+		if block.X+block.Width > docContentWidth {
+			return fmt.Errorf("block %d: x + width exceeds page width %.0f", i+1, docContentWidth)
+		}
+
+		total := 0.0
+		for _, column := range block.Columns {
+			if _, ok := knownItem[column.Field]; !ok {
+				return fmt.Errorf("block %d: unknown item field %q", i+1, column.Field)
+			}
+			total += column.Width
+			texts = append(texts, column.Title)
+		}
+		if total > docContentWidth {
+			return fmt.Errorf("block %d: columns exceed page width %.0f", i+1, docContentWidth)
+		}
+
+		for _, signature := range block.Signatures {
+			texts = append(texts, signature.Title, signature.Name)
+		}
+		for _, text := range texts {
+			if err := checkText(i, text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderDocumentLayout печатает документ по макету шаблона.
+func renderDocumentLayout(layout model.DocumentLayout, data orderDocumentData, labels map[string]string) ([]byte, error) {
+	d, err := newDocumentPDF(layout.Font, layout.BoldFont)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := documentFields(data, labels)
+	baseSize, baseColor := layout.FontSize, layout.Color
+	if baseSize == 0 {
+		baseSize = docFontSize
+	}
+	if baseColor == "" {
+		baseColor = primaryColor
+	}
+
+	for _, block := range layout.Blocks {
+		x := docMargin + block.X
+		width := block.Width
+		if width == 0 {
+			width = docContentWidth - block.X
+		}
+		size, color := block.Size, block.Color
+		if size == 0 {
+			size = baseSize
+		}
+		if color == "" {
+			color = baseColor
+		}
+		if block.Y > 0 {
+			d.y = docMargin + block.Y
+		}
+
+		switch block.Type {
+		case "text", "field":
+			text := fillDocumentText(block.Text, fields)
+			if block.Type == "field" {
+				value := fields[block.Field]
+				if value == "" {
+					continue
+				}
+				text = value
+				if label := fillDocumentText(block.Label, fields); label != "" {
+					text = label + ": " + value
+				}
+			}
+			for _, paragraph := range strings.Split(text, "\n") {
+				for _, line := range d.lines(paragraph, width, block.Bold, size) {
+					d.ensureSpace(size + 3)
+					d.cell(x, d.y, width, line, block.Bold, size, documentAlign(block.Align), color)
+					d.y += size + 3
+				}
+			}
+
+		case "logo":
+			if data.Company.LogoPath == "" {
+				continue
+			}
+			logoWidth, logoHeight := block.Width, block.Height
+			if logoWidth == 0 {
+				logoWidth = 55
+			}
+			if logoHeight == 0 {
+				logoHeight = 55
+			}
+			d.ensureSpace(logoHeight)
+			if err := d.pdf.Image(data.Company.LogoPath, x, d.y, &gopdf.Rect{W: logoWidth, H: logoHeight}); err != nil {
+				log.Printf("Document logo skipped: %v | %s", err, data.Company.LogoPath)
+				continue
+			}
+			d.y += logoHeight + 5
+
+		case "items":
+			items := append([]model.OrderItem(nil), data.Order.Products...)
+			switch block.SortBy {
+			case "name":
+				sort.SliceStable(items, func(i, j int) bool {
+					return documentProductName(items[i]) < documentProductName(items[j])
+				})
+			case "category":
+				sort.SliceStable(items, func(i, j int) bool {
+					ci, cj := documentProductCategory(items[i]), documentProductCategory(items[j])
+					if ci != cj {
+						return ci < cj
+					}
+					return documentProductName(items[i]) < documentProductName(items[j])
+				})
+			}
+
+			widths := documentColumnWidths(block.Columns)
+			columns := make([]documentColumn, len(block.Columns))
+			for i, column := range block.Columns {
+				columns[i] = documentColumn{fillDocumentText(column.Title, fields), widths[i], documentAlign(column.Align)}
+			}
+			rows := make([][]string, len(items))
+			for i, item := range items {
+				values := documentItemFields(i, item, labels)
+				rows[i] = make([]string, len(block.Columns))
+				for j, column := range block.Columns {
+					rows[i][j] = values[column.Field]
+				}
+			}
+			d.table(columns, rows)
+
+		case "totals":
+			d.totals(labels, data.Order.TotalPrice, data.Company.Currency, data.Language)
+
+		case "signatures":
+			pairs := make([][2]string, len(block.Signatures))
+			for i, signature := range block.Signatures {
+				pairs[i] = [2]string{fillDocumentText(signature.Title, fields), fillDocumentText(signature.Name, fields)}
+			}
+			d.signatures(pairs)
+
+		case "line":
+			d.ensureSpace(6)
+			c := parseHexColor(color)
+			d.pdf.SetLineWidth(0.5)
+			d.pdf.SetStrokeColor(c.R, c.G, c.B)
+			d.pdf.Line(x, d.y+2, x+width, d.y+2)
+			d.y += 6
+
+		case "spacer":
+			if d.ensureSpace(block.Height) {
+				continue
+			}
+			d.y += block.Height
+		}
+	}
+
+	pageLabel := ""
+	if layout.PageNumbers {
+		pageLabel = labels["page"]
+	}
+	return d.bytes(pageLabel)
+}
+
+// sampleOrderDocumentData — образец заказа для предпросмотра шаблона, когда заказ не указан.
+func sampleOrderDocumentData(docType, language string, company model.CompanySettings) orderDocumentData {
+	now := utils.BusinessNow()
+	category := &model.Category{Name: "Образец"}
+	items := []model.OrderItem{
+		{Quantity: 2, TotalPrice: 1500000, Product: &model.Product{Name: "Дверь межкомнатная", Unit: "piece", Category: category}},
+		{Quantity: 12.5, TotalPrice: 625000, Product: &model.Product{Name: "Наличник", Unit: "meter", Category: category}},
+		{Quantity: 4, TotalPrice: 180000, Product: &model.Product{Name: "Ручка дверная", Unit: "piece", Category: category}},
+	}
+	total := 0.0
+	for _, item := range items {
+		total += item.TotalPrice
+	}
+
+	return orderDocumentData{
+		Document: model.OrderDocument{
+			Type:     docType,
+			Number:   fmt.Sprintf("%s-%d-000000", model.DocumentPrefixes[docType], now.Year()),
+			IssuedAt: now,
+		},
+		Order: model.Order{
+			ID:            guuid.Nil,
+			Client:        &model.Client{Name: "Иван", Surname: "Иванов", Address: "г. Ташкент", ContactInfo: "+998 90 000 00 00"},
+			Salesperson:   &model.User{Username: "seller"},
+			Warehouse:     &model.Warehouse{Name: "Основной склад"},
+			Products:      items,
+			PaymentMethod: "cash",
+			TotalPrice:    total,
+			CreatedAt:     now.Add(-24 * time.Hour),
+		},
+		Company:  company,
+		Language: language,
+	}
+}
@@ -0,0 +1,651 @@
+package handlers
+
+import (
+	"backend/model"
+	"backend/utils"
+	"bytes"
+	"fmt"
+	"image/color"
+	"log"
+	"math"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/signintech/gopdf"
+)
+
+// Оформление документов
+const (
+	docMargin       = 40.0
+	docPageWidth    = 595.28
+	docContentWidth = docPageWidth - 2*docMargin
+	docBottom       = 790.0
+	docFontSize     = 10.0
+	docSmallSize    = 8.0
+	docTitleSize    = 15.0
+	docLineHeight   = 13.0
+	primaryColor    = "#2d3436"
+	secondaryColor  = "#636e72"
+	accentColor     = "#0984e3"
+)
+
+// documentLabels — подписи документов на русском и узбекском (латиница).
+var documentLabels = map[string]map[string]string{
+	"ru": {
+		model.DocumentInvoice:      "СЧЁТ-ФАКТУРА",
+		model.DocumentDeliveryNote: "ТОВАРНАЯ НАКЛАДНАЯ",
+		model.DocumentPickList:     "ЛИСТ КОМПЛЕКТАЦИИ",
+		model.DocumentReceipt:      "КВИТАНЦИЯ ОБ ОПЛАТЕ",
+		"numberDate":               "№ %s от %s",
+		"seller":                   "Поставщик",
+		"buyer":                    "Покупатель",
+		"order":                    "Заказ",
+		"orderDate":                "Дата заказа",
+		"warehouse":                "Склад",
+		"salesperson":              "Продавец",
+		"payment":                  "Способ оплаты",
+		"taxId":                    "ИНН",
+		"bank":                     "Банк",
+		"account":                  "Р/с",
+		"bankCode":                 "МФО",
+		"phone":                    "Тел.",
+		"contact":                  "Контакты",
+		"address":                  "Адрес",
+		"col.no":                   "№",
+		"col.name":                 "Наименование",
+		"col.category":             "Категория",
+		"col.unit":                 "Ед.",
+		"col.quantity":             "Кол-во",
+		"col.price":                "Цена",
+		"col.amount":               "Сумма",
+		"col.picked":               "Отобрано",
+		"total":                    "Итого",
+		"inWords":                  "Сумма прописью",
+		"itemCount":                "Всего наименований: %d",
+		"receivedFrom":             "Принято от",
+		"basis":                    "Основание",
+		"basisText":                "оплата по заказу № %s",
+		"amount":                   "Сумма",
+		"sign.director":            "Руководитель",
+		"sign.accountant":          "Главный бухгалтер",
+		"sign.released":            "Отпустил",
+		"sign.received":            "Получил",
+		"sign.picked":              "Отобрал",
+		"sign.checked":             "Проверил",
+		"sign.cashier":             "Кассир",
+		"sign.payer":               "Плательщик",
+		"unit.piece":               "шт",
+		"unit.meter":               "м",
+		"pay.cash":                 "наличные",
+		"pay.transfer":             "перечисление",
+		"pay.credit":               "в кредит",
+		"pay.card":                 "карта",
+		"page":                     "Стр. %d из %d",
+		"statement":                "ВЫПИСКА ПО РАСЧЁТАМ С КЛИЕНТОМ",
+		"statementPeriod":          "за период с %s по %s",
+		"client":                   "Клиент",
+		"col.date":                 "Дата",
+		"col.document":             "Документ",
+		"col.charged":              "Начислено",
+		"col.paid":                 "Оплачено",
+		"col.balance":              "Сальдо",
+		"openingBalance":           "Сальдо на начало периода",
+		"closingBalance":           "Сальдо на конец периода",
+		"turnover":                 "Обороты за период",
+		"entry.order":              "Заказ %s",
+		"entry.payment":            "Оплата (%s)",
+		"balanceNote":              "Положительное сальдо — задолженность клиента, отрицательное — переплата.",
+	},
+	"uz": {
+		model.DocumentInvoice:      "HISOB-FAKTURA",
+		model.DocumentDeliveryNote: "YUK XATI",
+		model.DocumentPickList:     "TERISH VARAQASI",
+		model.DocumentReceipt:      "TO'LOV KVITANSIYASI",
+		"numberDate":               "№ %s, %s",
+		"seller":                   "Yetkazib beruvchi",
+		"buyer":                    "Xaridor",
+		"order":                    "Buyurtma",
+		"orderDate":                "Buyurtma sanasi",
+		"warehouse":                "Ombor",
+		"salesperson":              "Sotuvchi",
+		"payment":                  "To'lov usuli",
+		"taxId":                    "STIR",
+		"bank":                     "Bank",
+		"account":                  "H/r",
+		"bankCode":                 "MFO",
+		"phone":                    "Tel.",
+		"contact":                  "Aloqa",
+		"address":                  "Manzil",
+		"col.no":                   "№",
+		"col.name":                 "Nomi",
+		"col.category":             "Kategoriya",
+		"col.unit":                 "O'lch.",
+		"col.quantity":             "Miqdori",
+		"col.price":                "Narxi",
+		"col.amount":               "Summa",
+		"col.picked":               "Terildi",
+		"total":                    "Jami",
+		"inWords":                  "Summa so'z bilan",
+		"itemCount":                "Jami nomlar: %d",
+		"receivedFrom":             "Qabul qilindi",
+		"basis":                    "Asos",
+		"basisText":                "%s-sonli buyurtma bo'yicha to'lov",
+		"amount":                   "Summa",
+		"sign.director":            "Rahbar",
+		"sign.accountant":          "Bosh hisobchi",
+		"sign.released":            "Topshirdi",
+		"sign.received":            "Qabul qildi",
+		"sign.picked":              "Terdi",
+		"sign.checked":             "Tekshirdi",
+		"sign.cashier":             "Kassir",
+		"sign.payer":               "To'lovchi",
+		"unit.piece":               "dona",
+		"unit.meter":               "m",
+		"pay.cash":                 "naqd",
+		"pay.transfer":             "pul o'tkazish",
+		"pay.credit":               "nasiya",
+		"pay.card":                 "karta",
+		"page":                     "%d/%d-bet",
+		"statement":                "MIJOZ BILAN HISOB-KITOBLAR KO'CHIRMASI",
+		"statementPeriod":          "%s — %s davr uchun",
+		"client":                   "Mijoz",
+		"col.date":                 "Sana",
+		"col.document":             "Hujjat",
+		"col.charged":              "Hisoblandi",
+		"col.paid":                 "To'landi",
+		"col.balance":              "Qoldiq",
+		"openingBalance":           "Davr boshidagi qoldiq",
+		"closingBalance":           "Davr oxiridagi qoldiq",
+		"turnover":                 "Davr aylanmasi",
+		"entry.order":              "Buyurtma %s",
+		"entry.payment":            "To'lov (%s)",
+		"balanceNote":              "Musbat qoldiq — mijozning qarzi, manfiy qoldiq — ortiqcha to'lov.",
+	},
+}
+
+// orderDocumentData — всё, что нужно для печати документа по заказу.
+type orderDocumentData struct {
+	Document model.OrderDocument
+	Order    model.Order
+	Company  model.CompanySettings
+	Language string
+	// Макет опубликованного шаблона; без него печатается встроенный макет
+	Layout *model.DocumentLayout
+}
+
+// documentColumn — колонка таблицы документа.
+type documentColumn struct {
+	Title string
+	Width float64
+	Align int
+}
+
+// documentPDF — обёртка над gopdf: шрифты, текущая позиция и перенос на новую страницу.
+type documentPDF struct {
+	pdf gopdf.GoPdf
+	y   float64
+}
+
+// newDocumentPDF создаёт документ A4. Шрифты берутся из DOCUMENT_FONTS_DIR
+// (по умолчанию assets); пустое имя файла означает Roboto-Regular.ttf и Roboto-Bold.ttf.
+func newDocumentPDF(font, boldFont string) (*documentPDF, error) {
+	d := &documentPDF{y: docMargin}
+	d.pdf.Start(gopdf.Config{PageSize: *gopdf.PageSizeA4, Unit: gopdf.UnitPT})
+
+	if font == "" {
+		font = "Roboto-Regular.ttf"
+	}
+	if boldFont == "" {
+		boldFont = "Roboto-Bold.ttf"
+	}
+	if err := d.pdf.AddTTFFont("roboto", documentFontPath(font)); err != nil {
+		return nil, fmt.Errorf("font load error: %v", err)
+	}
+	if err := d.pdf.AddTTFFont("roboto-bold", documentFontPath(boldFont)); err != nil {
+		return nil, fmt.Errorf("bold font load error: %v", err)
+	}
+
+	d.pdf.AddPage()
+	return d, nil
+}
+
+func documentFontPath(name string) string {
+	return filepath.Join(utils.Getenv("DOCUMENT_FONTS_DIR", "assets"), filepath.Base(name))
+}
+
+func (d *documentPDF) setFont(bold bool, size float64) {
+	if bold {
+		d.pdf.SetFont("roboto-bold", "", size)
+	} else {
+		d.pdf.SetFont("roboto", "", size)
+	}
+}
+
+func (d *documentPDF) setColor(hex string) {
+	c := parseHexColor(hex)
+	d.pdf.SetTextColor(c.R, c.G, c.B)
+}
+
+// cell выводит текст в прямоугольник шириной width на высоте y.
+func (d *documentPDF) cell(x, y, width float64, text string, bold bool, size float64, align int, hex string) {
+	d.setFont(bold, size)
+	d.setColor(hex)
+	d.pdf.SetXY(x, y)
+	d.pdf.CellWithOption(&gopdf.Rect{W: width, H: size + 2}, text, gopdf.CellOption{Align: align | gopdf.Top})
+}
+
+// lines разбивает текст по ширине текущим шрифтом.
+func (d *documentPDF) lines(text string, width float64, bold bool, size float64) []string {
+	d.setFont(bold, size)
+	if strings.TrimSpace(text) == "" {
+		return []string{""}
+	}
+	lines, err := d.pdf.SplitTextWithWordWrap(text, width)
+	if err != nil || len(lines) == 0 {
+		return []string{text}
+	}
+	return lines
+}
+
+// paragraph выводит текст с переносом строк начиная с текущей позиции.
+func (d *documentPDF) paragraph(x, width float64, text string, bold bool, size float64, hex string) {
+	for _, line := range d.lines(text, width, bold, size) {
+		d.ensureSpace(docLineHeight)
+		d.cell(x, d.y, width, line, bold, size, gopdf.Left, hex)
+		d.y += size + 3
+	}
+}
+
+// ensureSpace начинает новую страницу, если блок высотой height не помещается. Возвращает true при переносе.
+func (d *documentPDF) ensureSpace(height float64) bool {
+	if d.y+height <= docBottom {
+		return false
+	}
+	d.pdf.AddPage()
+	d.y = docMargin
+	return true
+}
+
+// table выводит таблицу с переносом текста в ячейках; заголовок повторяется на каждой странице.
+func (d *documentPDF) table(columns []documentColumn, rows [][]string) {
+	header := func() {
+		height := docLineHeight + 8
+		d.pdf.SetLineWidth(0.5)
+		d.pdf.SetStrokeColor(178, 190, 195)
+		d.pdf.SetFillColor(241, 242, 246)
+		x := docMargin
+		for _, column := range columns {
+			d.pdf.Rectangle(x, d.y, x+column.Width, d.y+height, "FD", 0, 0)
+			d.cell(x+4, d.y+5, column.Width-8, column.Title, true, docFontSize, gopdf.Center, primaryColor)
+			x += column.Width
+		}
+		d.y += height
+	}
+
+	d.ensureSpace(2 * (docLineHeight + 8))
+	header()
+
+	for _, row := range rows {
+		cellLines := make([][]string, len(columns))
+		height := 0.0
+		for i, column := range columns {
+			cellLines[i] = d.lines(row[i], column.Width-8, false, docFontSize)
+			height = math.Max(height, float64(len(cellLines[i]))*docLineHeight+8)
+		}
+		if d.ensureSpace(height) {
+			header()
+		}
+
+		d.pdf.SetLineWidth(0.5)
+		d.pdf.SetStrokeColor(178, 190, 195)
+		x := docMargin
+		for i, column := range columns {
+			d.pdf.Rectangle(x, d.y, x+column.Width, d.y+height, "D", 0, 0)
+			for j, line := range cellLines[i] {
+				d.cell(x+4, d.y+4+float64(j)*docLineHeight, column.Width-8, line, false, docFontSize, column.Align, primaryColor)
+			}
+			x += column.Width
+		}
+		d.y += height
+	}
+}
+
+// signatures выводит строки для подписей в две колонки: «Должность ____ Ф. И. О.».
+func (d *documentPDF) signatures(pairs [][2]string) {
+	d.y += 25
+	d.ensureSpace(40)
+	half := docContentWidth / 2
+	for i, pair := range pairs {
+		x := docMargin + float64(i%2)*half
+		if i > 0 && i%2 == 0 {
+			d.y += 35
+			d.ensureSpace(30)
+		}
+		d.cell(x, d.y, 100, pair[0], true, docFontSize, gopdf.Left, secondaryColor)
+		d.pdf.SetLineWidth(0.5)
+		d.pdf.SetStrokeColor(99, 110, 114)
+		d.pdf.Line(x+100, d.y+12, x+half-20, d.y+12)
+		if pair[1] != "" {
+			d.cell(x+100, d.y+15, half-120, pair[1], false, docSmallSize, gopdf.Center, secondaryColor)
+		}
+	}
+	d.y += 30
+}
+
+// bytes завершает документ. Если задан pageLabel, на страницах проставляются номера.
+func (d *documentPDF) bytes(pageLabel string) ([]byte, error) {
+	// Номера страниц проставляются после вёрстки, когда известно их количество
+	total := d.pdf.GetNumberOfPages()
+	for page := 1; page <= total && pageLabel != ""; page++ {
+		if err := d.pdf.SetPage(page); err != nil {
+			return nil, err
+		}
+		d.cell(docMargin, docBottom+20, docContentWidth, fmt.Sprintf(pageLabel, page, total), false, docSmallSize, gopdf.Right, secondaryColor)
+	}
+
+	var buf bytes.Buffer
+	if _, err := d.pdf.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderOrderDocument печатает документ по заказу по макету шаблона, а без шаблона — встроенным
+// макетом: шапка с реквизитами, стороны, таблица позиций, итоги и подписи по виду документа.
+func renderOrderDocument(data orderDocumentData) ([]byte, error) {
+	labels, ok := documentLabels[data.Language]
+	if !ok {
+		labels = documentLabels["ru"]
+	}
+	if data.Layout != nil {
+		return renderDocumentLayout(*data.Layout, data, labels)
+	}
+	d, err := newDocumentPDF("", "")
+	if err != nil {
+		return nil, err
+	}
+
+	order, company, document := data.Order, data.Company, data.Document
+	location := utils.BusinessLocation()
+
+	d.companyHeader(company, labels)
+
+	// Заголовок и номер
+	d.cell(docMargin, d.y, docContentWidth, labels[document.Type], true, docTitleSize, gopdf.Center, accentColor)
+	d.y += 20
+	d.cell(docMargin, d.y, docContentWidth, fmt.Sprintf(labels["numberDate"], document.Number,
+		document.IssuedAt.In(location).Format("02.01.2006")), false, docFontSize+1, gopdf.Center, primaryColor)
+	d.y += 25
+
+	clientName := ""
+	if order.Client != nil {
+		clientName = strings.TrimSpace(order.Client.Name + " " + order.Client.Surname)
+	}
+
+	// Стороны документа
+	if document.Type != model.DocumentPickList {
+		half := docContentWidth/2 - 10
+		startY := d.y
+		seller := []string{
+			company.Name,
+			prefixed(labels["address"], company.Address),
+			prefixed(labels["taxId"], company.TaxID),
+			joinNonEmpty(", ", prefixed(labels["account"], company.BankAccount), prefixed(labels["bankCode"], company.BankCode)),
+			prefixed(labels["bank"], company.BankName),
+		}
+		buyer := []string{clientName}
+		if order.Client != nil {
+			buyer = append(buyer, prefixed(labels["address"], order.Client.Address), prefixed(labels["contact"], order.Client.ContactInfo))
+		}
+
+		endY := startY
+		for i, party := range []struct {
+			title string
+			lines []string
+		}{{labels["seller"], seller}, {labels["buyer"], buyer}} {
+			d.y = startY
+			x := docMargin + float64(i)*(half+20)
+			d.cell(x, d.y, half, party.title+":", true, docFontSize, gopdf.Left, secondaryColor)
+			d.y += docLineHeight + 2
+			for _, line := range party.lines {
+				if line != "" {
+					d.paragraph(x, half, line, false, docFontSize, primaryColor)
+				}
+			}
+			endY = math.Max(endY, d.y)
+		}
+		d.y = endY + 10
+	}
+
+	// Сведения о заказе
+	info := []string{
+		fmt.Sprintf("%s: %s", labels["order"], order.ID),
+		fmt.Sprintf("%s: %s", labels["orderDate"], order.CreatedAt.In(location).Format("02.01.2006")),
+	}
+	if order.Warehouse != nil {
+		info = append(info, fmt.Sprintf("%s: %s", labels["warehouse"], order.Warehouse.Name))
+	}
+	if order.Salesperson != nil {
+		info = append(info, fmt.Sprintf("%s: %s", labels["salesperson"], order.Salesperson.Username))
+	}
+	if document.Type != model.DocumentPickList && document.Type != model.DocumentDeliveryNote {
+		info = append(info, fmt.Sprintf("%s: %s", labels["payment"], documentLabel(labels, "pay.", order.PaymentMethod)))
+	}
+	if document.Type == model.DocumentPickList {
+		info = append(info, fmt.Sprintf("%s: %s", labels["buyer"], clientName))
+	}
+	d.paragraph(docMargin, docContentWidth, strings.Join(info, "   "), false, docFontSize, secondaryColor)
+	d.y += 10
+
+	currency := company.Currency
+	items := order.Products
+	switch document.Type {
+	case model.DocumentInvoice:
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			rows[i] = []string{strconv.Itoa(i + 1), documentProductName(item), documentLabel(labels, "unit.", documentProductUnit(item)),
+				formatDocumentQuantity(item.Quantity), formatDocumentAmount(documentUnitPrice(item)), formatDocumentAmount(item.TotalPrice)}
+		}
+		d.table([]documentColumn{
+			{labels["col.no"], 30, gopdf.Center},
+			{labels["col.name"], 215, gopdf.Left},
+			{labels["col.unit"], 40, gopdf.Center},
+			{labels["col.quantity"], 60, gopdf.Right},
+			{labels["col.price"], 80, gopdf.Right},
+			{labels["col.amount"], docContentWidth - 425, gopdf.Right},
+		}, rows)
+		d.totals(labels, order.TotalPrice, currency, data.Language)
+		d.signatures([][2]string{
+			{labels["sign.director"], company.DirectorName},
+			{labels["sign.accountant"], company.AccountantName},
+		})
+
+	case model.DocumentDeliveryNote:
+		rows := make([][]string, len(items))
+		for i, item := range items {
+			rows[i] = []string{strconv.Itoa(i + 1), documentProductName(item), documentLabel(labels, "unit.", documentProductUnit(item)),
+				formatDocumentQuantity(item.Quantity)}
+		}
+		d.table([]documentColumn{
+			{labels["col.no"], 30, gopdf.Center},
+			{labels["col.name"], 325, gopdf.Left},
+			{labels["col.unit"], 60, gopdf.Center},
+			{labels["col.quantity"], docContentWidth - 415, gopdf.Right},
+		}, rows)
+		d.y += 8
+		d.paragraph(docMargin, docContentWidth, fmt.Sprintf(labels["itemCount"], len(items)), true, docFontSize, primaryColor)
+		d.signatures([][2]string{{labels["sign.released"], ""}, {labels["sign.received"], ""}})
+
+	case model.DocumentPickList:
+		// Позиции сгруппированы по категориям, чтобы обходить склад по порядку
+		sorted := append([]model.OrderItem(nil), items...)
+		sort.SliceStable(sorted, func(i, j int) bool {
+			ci, cj := documentProductCategory(sorted[i]), documentProductCategory(sorted[j])
+			if ci != cj {
+				return ci < cj
+			}
+			return documentProductName(sorted[i]) < documentProductName(sorted[j])
+		})
+		rows := make([][]string, len(sorted))
+		for i, item := range sorted {
+			rows[i] = []string{strconv.Itoa(i + 1), documentProductName(item), documentProductCategory(item),
+				documentLabel(labels, "unit.", documentProductUnit(item)), formatDocumentQuantity(item.Quantity), ""}
+		}
+		d.table([]documentColumn{
+			{labels["col.no"], 30, gopdf.Center},
+			{labels["col.name"], 200, gopdf.Left},
+			{labels["col.category"], 115, gopdf.Left},
+			{labels["col.unit"], 45, gopdf.Center},
+			{labels["col.quantity"], 60, gopdf.Right},
+			{labels["col.picked"], docContentWidth - 450, gopdf.Center},
+		}, rows)
+		d.signatures([][2]string{{labels["sign.picked"], ""}, {labels["sign.checked"], ""}})
+
+	case model.DocumentReceipt:
+		for _, line := range [][2]string{
+			{labels["receivedFrom"], clientName},
+			{labels["basis"], fmt.Sprintf(labels["basisText"], order.ID)},
+			{labels["amount"], formatDocumentAmount(order.TotalPrice) + " " + currency},
+			{labels["inWords"], utils.AmountInWords(order.TotalPrice, currency, data.Language)},
+		} {
+			d.ensureSpace(docLineHeight * 2)
+			d.cell(docMargin, d.y, 120, line[0]+":", true, docFontSize, gopdf.Left, secondaryColor)
+			d.paragraph(docMargin+125, docContentWidth-125, line[1], false, docFontSize, primaryColor)
+			d.y += 6
+		}
+		d.signatures([][2]string{{labels["sign.cashier"], company.AccountantName}, {labels["sign.payer"], ""}})
+	}
+
+	return d.bytes(labels["page"])
+}
+
+// companyHeader выводит шапку документа: логотип, название и контакты компании.
+func (d *documentPDF) companyHeader(company model.CompanySettings, labels map[string]string) {
+	textX := docMargin
+	if company.LogoPath != "" {
+		if err := d.pdf.Image(company.LogoPath, docMargin, docMargin, &gopdf.Rect{W: 55, H: 55}); err != nil {
+			log.Printf("Document logo skipped: %v | %s", err, company.LogoPath)
+		} else {
+			textX += 70
+		}
+	}
+	d.cell(textX, d.y, docPageWidth-docMargin-textX, company.Name, true, 13, gopdf.Left, primaryColor)
+	d.y += 18
+	for _, line := range []string{company.Address, joinNonEmpty(", ", prefixed(labels["phone"], company.Phone), company.Email)} {
+		if line != "" {
+			d.cell(textX, d.y, docPageWidth-docMargin-textX, line, false, docSmallSize, gopdf.Left, secondaryColor)
+			d.y += 11
+		}
+	}
+	d.y = math.Max(d.y, docMargin+60) + 15
+}
+
+// totals выводит итог и сумму прописью под таблицей.
+func (d *documentPDF) totals(labels map[string]string, total float64, currency, language string) {
+	d.y += 8
+	d.ensureSpace(docLineHeight * 3)
+	d.cell(docMargin, d.y, docContentWidth-100, labels["total"]+":", true, docFontSize+1, gopdf.Right, primaryColor)
+	d.cell(docMargin+docContentWidth-95, d.y, 95, formatDocumentAmount(total), true, docFontSize+1, gopdf.Right, primaryColor)
+	d.y += docLineHeight + 8
+	d.paragraph(docMargin, docContentWidth, labels["inWords"]+": "+utils.AmountInWords(total, currency, language), false, docFontSize, primaryColor)
+}
+
+func documentProductName(item model.OrderItem) string {
+	if item.Product == nil {
+		return ""
+	}
+	return item.Product.Name
+}
+
+func documentProductUnit(item model.OrderItem) string {
+	if item.Product == nil {
+		return ""
+	}
+	return item.Product.Unit
+}
+
+func documentProductCategory(item model.OrderItem) string {
+	if item.Product == nil || item.Product.Category == nil {
+		return ""
+	}
+	return item.Product.Category.Name
+}
+
+// documentUnitPrice — цена за единицу на момент заказа, а не текущая цена продукта.
+func documentUnitPrice(item model.OrderItem) float64 {
+	if item.Quantity == 0 {
+		return 0
+	}
+	return item.TotalPrice / item.Quantity
+}
+
+// documentLabel переводит значение (единицу, способ оплаты); неизвестное значение выводится как есть.
+func documentLabel(labels map[string]string, prefix, value string) string {
+	if label, ok := labels[prefix+value]; ok {
+		return label
+	}
+	return value
+}
+
+func prefixed(label, value string) string {
+	if value == "" {
+		return ""
+	}
+	return label + ": " + value
+}
+
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// formatDocumentAmount форматирует сумму как «1 234 567,89».
+func formatDocumentAmount(value float64) string {
+	text := strconv.FormatFloat(math.Abs(value), 'f', 2, 64)
+	whole, fraction := text[:len(text)-3], text[len(text)-2:]
+	sign := ""
+	if value < 0 && text != "0.00" {
+		sign = "-"
+	}
+	return sign + groupThousands(whole) + "," + fraction
+}
+
+// formatDocumentQuantity выводит количество без лишних нулей: 3, 2,5.
+func formatDocumentQuantity(value float64) string {
+	return strings.Replace(strconv.FormatFloat(value, 'f', -1, 64), ".", ",", 1)
+}
+
+func groupThousands(digits string) string {
+	var b strings.Builder
+	for i, r := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func parseHexColor(hex string) (c color.RGBA) {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		panic("invalid color format")
+	}
+	rgb, _ := strconv.ParseUint(hex, 16, 32)
+	return color.RGBA{
+		R: uint8(rgb >> 16),
+		G: uint8(rgb >> 8),
+		B: uint8(rgb),
+		A: 255,
+	}
+}
@@ -0,0 +1,591 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	errInvalidTemplateID      = errors.New("invalid template id")
+	errTemplateVersionMissing = errors.New("template version not found")
+)
+
+// publishedDocumentLayout возвращает макет опубликованного шаблона для вида документа или nil.
+func publishedDocumentLayout(db *gorm.DB, docType string) (*model.DocumentLayout, error) {
+	var version model.DocumentTemplateVersion
+	err := db.Joins("JOIN document_templates ON document_templates.id = document_template_versions.template_id").
+		Where("document_templates.document_type = ? AND document_templates.published_version = document_template_versions.version", docType).
+		First(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version.Layout, nil
+}
+
+// GetDocumentTemplates Шаблоны документов
+//
+//	@Summary		Шаблоны документов
+//	@Description	Возвращает шаблоны документов по заказам. Макеты хранятся в версиях: /settings/templates/{id}/versions.
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			documentType	query		string					false	"Вид документа (invoice, delivery_note, pick_list, receipt)"
+//	@Success		200				{array}		model.DocumentTemplate	"Шаблоны"
+//	@Failure		500				{object}	APIError				"Ошибка сервера"
+//	@Router			/settings/templates [get]
+func GetDocumentTemplates(c *fiber.Ctx) error {
+	templates := []model.DocumentTemplate{}
+
+	db := database.DB.Order("document_type, name")
+	if docType := c.Query("documentType"); docType != "" {
+		db = db.Where("document_type = ?", docType)
+	}
+	if err := db.Find(&templates).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve templates",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    templates,
+	})
+}
+
+// GetDocumentTemplate Шаблон документа
+//
+//	@Summary		Шаблон документа
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID шаблона"
+//	@Success		200	{object}	model.DocumentTemplate	"Шаблон"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		404	{object}	APIError				"Шаблон не найден"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/settings/templates/{id} [get]
+func GetDocumentTemplate(c *fiber.Ctx) error {
+	template, err := findDocumentTemplate(c, database.DB)
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    template,
+	})
+}
+
+// CreateDocumentTemplate Создать шаблон документа
+//
+//	@Summary		Создать шаблон документа
+//	@Description	Создаёт шаблон с первой версией макета. Шаблон печатается только после публикации.
+//	@Description	Поля для подстановки: document.*, order.*, client.*, salesperson.name, warehouse.name, company.*, label.*; в колонках таблицы — item.no, item.name, item.category, item.unit, item.quantity, item.price, item.amount, item.blank.
+//	@Tags			Settings
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			template	body		model.CreateDocumentTemplateRequest	true	"Шаблон"
+//	@Success		201			{object}	model.DocumentTemplateVersion		"Шаблон создан"
+//	@Failure		400			{object}	APIError							"Неверный макет"
+//	@Failure		422			{object}	APIError							"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError							"Ошибка сервера"
+//	@Router			/settings/templates [post]
+func CreateDocumentTemplate(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.CreateDocumentTemplateRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+	if err := validateDocumentLayout(body.Layout); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	template := model.DocumentTemplate{
+		ID:            guuid.New(),
+		DocumentType:  body.DocumentType,
+		Name:          body.Name,
+		LatestVersion: 1,
+		CreatedByID:   user.ID,
+	}
+	version := model.DocumentTemplateVersion{
+		ID:          guuid.New(),
+		TemplateID:  template.ID,
+		Version:     1,
+		Layout:      body.Layout,
+		Comment:     body.Comment,
+		CreatedByID: user.ID,
+	}
+
+	db := database.DB
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&template).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&version).Error; err != nil {
+			return err
+		}
+		recordAudit(c, tx, model.AuditCreate, "document_template", template.ID, nil, version)
+		return nil
+	})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create template",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Template created successfully",
+		"data":    version,
+	})
+}
+
+// UpdateDocumentTemplate Новая версия шаблона
+//
+//	@Summary		Новая версия шаблона
+//	@Description	Сохраняет макет новой версией шаблона; прежние версии не меняются. Опубликованная версия остаётся прежней до публикации новой.
+//	@Tags			Settings
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string								true	"ID шаблона"
+//	@Param			template	body		model.UpdateDocumentTemplateRequest	true	"Макет"
+//	@Success		200			{object}	model.DocumentTemplateVersion		"Версия сохранена"
+//	@Failure		400			{object}	APIError							"Неверный макет"
+//	@Failure		404			{object}	APIError							"Шаблон не найден"
+//	@Failure		422			{object}	APIError							"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError							"Ошибка сервера"
+//	@Router			/settings/templates/{id} [put]
+func UpdateDocumentTemplate(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.UpdateDocumentTemplateRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+	if err := validateDocumentLayout(body.Layout); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	var version model.DocumentTemplateVersion
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		// Блокировка шаблона, чтобы параллельные сохранения не получили один номер версии
+		template, err := findDocumentTemplate(c, tx.Clauses(clause.Locking{Strength: "UPDATE"}))
+		if err != nil {
+			return err
+		}
+		before := template
+
+		template.Name = body.Name
+		template.LatestVersion++
+		if err := tx.Save(&template).Error; err != nil {
+			return err
+		}
+
+		version = model.DocumentTemplateVersion{
+			ID:          guuid.New(),
+			TemplateID:  template.ID,
+			Version:     template.LatestVersion,
+			Layout:      body.Layout,
+			Comment:     body.Comment,
+			CreatedByID: user.ID,
+		}
+		if err := tx.Create(&version).Error; err != nil {
+			return err
+		}
+
+		recordAudit(c, tx, model.AuditUpdate, "document_template", template.ID, before, version)
+		return nil
+	})
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Template version saved",
+		"data":    version,
+	})
+}
+
+// GetDocumentTemplateVersions Версии шаблона
+//
+//	@Summary		Версии шаблона
+//	@Description	Возвращает все версии шаблона с макетами, новые сначала
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"ID шаблона"
+//	@Success		200	{array}		model.DocumentTemplateVersion	"Версии"
+//	@Failure		400	{object}	APIError						"Неверный формат ID"
+//	@Failure		404	{object}	APIError						"Шаблон не найден"
+//	@Failure		500	{object}	APIError						"Ошибка сервера"
+//	@Router			/settings/templates/{id}/versions [get]
+func GetDocumentTemplateVersions(c *fiber.Ctx) error {
+	db := database.DB
+	template, err := findDocumentTemplate(c, db)
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	versions := []model.DocumentTemplateVersion{}
+	if err := db.Where("template_id = ?", template.ID).Order("version desc").Find(&versions).Error; err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    versions,
+	})
+}
+
+// PublishDocumentTemplate Опубликовать версию шаблона
+//
+//	@Summary		Опубликовать версию шаблона
+//	@Description	Делает версию шаблона печатаемой для его вида документа. Другие шаблоны того же вида снимаются с публикации. Публикация прежней версии — откат макета.
+//	@Tags			Settings
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string									true	"ID шаблона"
+//	@Param			version	body		model.PublishDocumentTemplateRequest	true	"Версия"
+//	@Success		200		{object}	model.DocumentTemplate					"Версия опубликована"
+//	@Failure		400		{object}	APIError								"Неверный формат ID"
+//	@Failure		404		{object}	APIError								"Шаблон или версия не найдены"
+//	@Failure		422		{object}	APIError								"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError								"Ошибка сервера"
+//	@Router			/settings/templates/{id}/publish [post]
+func PublishDocumentTemplate(c *fiber.Ctx) error {
+	body := new(model.PublishDocumentTemplateRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	return setPublishedVersion(c, body.Version)
+}
+
+// UnpublishDocumentTemplate Снять шаблон с публикации
+//
+//	@Summary		Снять шаблон с публикации
+//	@Description	После снятия документы этого вида печатаются встроенным макетом, пока не опубликован другой шаблон
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID шаблона"
+//	@Success		200	{object}	model.DocumentTemplate	"Шаблон снят с публикации"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		404	{object}	APIError				"Шаблон не найден"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/settings/templates/{id}/unpublish [post]
+func UnpublishDocumentTemplate(c *fiber.Ctx) error {
+	return setPublishedVersion(c, 0)
+}
+
+// setPublishedVersion публикует версию шаблона (0 — снимает с публикации).
+func setPublishedVersion(c *fiber.Ctx, versionNumber int) error {
+	var template model.DocumentTemplate
+	err := database.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		template, err = findDocumentTemplate(c, tx.Clauses(clause.Locking{Strength: "UPDATE"}))
+		if err != nil {
+			return err
+		}
+		before := template
+
+		if versionNumber > 0 {
+			var count int64
+			err := tx.Model(&model.DocumentTemplateVersion{}).
+				Where("template_id = ? AND version = ?", template.ID, versionNumber).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return errTemplateVersionMissing
+			}
+
+			// Для вида документа печатается только один шаблон
+			err = tx.Model(&model.DocumentTemplate{}).
+				Where("document_type = ? AND id <> ? AND published_version > 0", template.DocumentType, template.ID).
+				Update("published_version", 0).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		template.PublishedVersion = versionNumber
+		if err := tx.Save(&template).Error; err != nil {
+			return err
+		}
+
+		recordAudit(c, tx, model.AuditUpdate, "document_template", template.ID, before, template)
+		return nil
+	})
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	message := "Template published"
+	if versionNumber == 0 {
+		message = "Template unpublished"
+	}
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": message,
+		"data":    template,
+	})
+}
+
+// DeleteDocumentTemplate Удалить шаблон документа
+//
+//	@Summary		Удалить шаблон документа
+//	@Description	Удаляет шаблон со всеми версиями. Если он был опубликован, документы печатаются встроенным макетом.
+//	@Tags			Settings
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID шаблона"
+//	@Success		200	{object}	model.DocumentTemplate	"Шаблон удалён"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		404	{object}	APIError				"Шаблон не найден"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/settings/templates/{id} [delete]
+func DeleteDocumentTemplate(c *fiber.Ctx) error {
+	db := database.DB
+	template, err := findDocumentTemplate(c, db)
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	if err := db.Delete(&template).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not delete template",
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "document_template", template.ID, template, nil)
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Template deleted successfully",
+		"data":    template,
+	})
+}
+
+// PreviewDocumentTemplate Предпросмотр шаблона
+//
+//	@Summary		Предпросмотр шаблона
+//	@Description	Печатает версию шаблона по указанному заказу или по образцу заказа. Номер документа не присваивается, реквизиты берутся из /settings/company.
+//	@Tags			Settings
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			id		path		string		true	"ID шаблона"
+//	@Param			version	query		int			false	"Версия (по умолчанию последняя)"
+//	@Param			orderId	query		string		false	"ID заказа (по умолчанию образец)"
+//	@Param			lang	query		string		false	"Язык документа (ru, uz)"	default(ru)
+//	@Success		200		{file}		file		"PDF-документ"
+//	@Failure		400		{object}	APIError	"Неверные параметры запроса"
+//	@Failure		404		{object}	APIError	"Шаблон, версия или заказ не найдены"
+//	@Failure		500		{object}	APIError	"Ошибка сервера"
+//	@Router			/settings/templates/{id}/preview [get]
+func PreviewDocumentTemplate(c *fiber.Ctx) error {
+	db := database.DB
+	template, err := findDocumentTemplate(c, db)
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	language := c.Query("lang", "ru")
+	if _, ok := documentLabels[language]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid lang. Use ru or uz",
+		})
+	}
+	versionNumber := template.LatestVersion
+	if raw := c.Query("version"); raw != "" {
+		versionNumber, err = strconv.Atoi(raw)
+		if err != nil || versionNumber < 1 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid version",
+			})
+		}
+	}
+
+	var version model.DocumentTemplateVersion
+	err = db.Where("template_id = ? AND version = ?", template.ID, versionNumber).First(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = errTemplateVersionMissing
+	}
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	company, err := loadCompanySettings(db)
+	if err != nil {
+		return documentTemplateErrorResponse(c, err)
+	}
+
+	data := sampleOrderDocumentData(template.DocumentType, language, company)
+	if raw := c.Query("orderId"); raw != "" {
+		orderID, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid UUID format for Order ID",
+			})
+		}
+		order, err := loadOrderForPDF(db, orderID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Order not found",
+			})
+		}
+		if err != nil {
+			return documentTemplateErrorResponse(c, err)
+		}
+		data.Order = order
+
+		// Уже выданный документ печатается со своим номером; новый номер при предпросмотре не выдаётся
+		var document model.OrderDocument
+		err = db.Where("order_id = ? AND type = ?", orderID, template.DocumentType).First(&document).Error
+		if err == nil {
+			data.Document = document
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return documentTemplateErrorResponse(c, err)
+		}
+	}
+	data.Layout = &version.Layout
+
+	content, err := renderOrderDocument(data)
+	if err != nil {
+		log.Printf("Template preview failed: %v | TemplateID: %s", err, template.ID)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to render template",
+		})
+	}
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("inline; filename=preview-v%d.pdf", version.Version))
+	return c.Send(content)
+}
+
+// findDocumentTemplate загружает шаблон из параметра id.
+func findDocumentTemplate(c *fiber.Ctx, db *gorm.DB) (model.DocumentTemplate, error) {
+	var template model.DocumentTemplate
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return template, errInvalidTemplateID
+	}
+	err = db.First(&template, "id = ?", id).Error
+	return template, err
+}
+
+func documentTemplateErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errInvalidTemplateID):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Template ID",
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Template not found",
+		})
+	case errors.Is(err, errTemplateVersionMissing):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Template version not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Failed to process template",
+	})
+}
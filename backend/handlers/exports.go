@@ -3,32 +3,49 @@ package handlers
 import (
 	"backend/database"
 	"backend/model"
+	"backend/tasks"
 	"backend/utils"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
+var (
+	productExportHeaders = []string{"Name", "Price", "Category", "Width", "Height", "Unit", "Amount"}
+	productExportFields  = []string{"Name", "Price", "Category.Name", "Width", "Height", "Unit", "Amount"}
+	clientExportHeaders  = []string{"Name", "Surname", "Contact-info", "Address", "Balance", "Registered"}
+	clientExportFields   = []string{"Name", "Surname", "ContactInfo", "Address", "Balance", "CreatedAt"}
+)
+
 // ExportProductsHandler Экспорт продуктов в Excel
 //
 //	@Summary		Экспорт продуктов в Excel
 //	@Description	Эта функция позволяет экспортировать список продуктов в формате Excel
 //	@Tags			Exports
 //	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
-//	@Success		200	{file}		file					"Excel-файл с продуктами"
-//	@Failure		500	{object}	map[string]interface{}	"Ошибка при поиске продуктов"
+//	@Param			async	query		bool					false	"Сформировать файл в фоновой задаче"
+//	@Success		200		{file}		file					"Excel-файл с продуктами"
+//	@Success		202		{object}	model.Job				"Задача поставлена в очередь"
+//	@Failure		500		{object}	map[string]interface{}	"Ошибка при поиске продуктов"
 //	@Router			/exports/products [get]
 func ExportProductsHandler(ctx *fiber.Ctx) error {
-	var products []model.Product
-	if err := database.DB.Preload(clause.Associations).Find(&products).Error; err != nil {
+	if ctx.QueryBool("async") {
+		return enqueueJob(ctx, jobExportProducts, nil, "")
+	}
+
+	products, err := loadExportProducts(database.DB)
+	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to fetch products",
 		})
 	}
 
-	headers := []string{"Name", "Price", "Category", "Width", "Height", "Unit", "Amount"}
-	fields := []string{"Name", "Price", "Category.Name", "Width", "Height", "Unit", "Amount"}
-	return utils.ExportDataToExcel(ctx, products, headers, fields, "products.xlsx")
+	return utils.ExportDataToExcel(ctx, products, productExportHeaders, productExportFields, "products.xlsx")
 }
 
 // ExportClientsHandler Экспорт клиентов в Excel
@@ -37,18 +54,236 @@ func ExportProductsHandler(ctx *fiber.Ctx) error {
 //	@Description	Эта функция позволяет экспортировать список клиентов в формате Excel
 //	@Tags			Exports
 //	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
-//	@Success		200	{file}		file					"Excel-файл с клиентами"
-//	@Failure		500	{object}	map[string]interface{}	"Ошибка при поиске клиентов"
+//	@Param			async	query		bool					false	"Сформировать файл в фоновой задаче"
+//	@Success		200		{file}		file					"Excel-файл с клиентами"
+//	@Success		202		{object}	model.Job				"Задача поставлена в очередь"
+//	@Failure		500		{object}	map[string]interface{}	"Ошибка при поиске клиентов"
 //	@Router			/exports/clients [get]
 func ExportClientsHandler(ctx *fiber.Ctx) error {
-	var clients []model.Client
-	if err := database.DB.Preload(clause.Associations).Find(&clients).Error; err != nil {
+	if ctx.QueryBool("async") {
+		return enqueueJob(ctx, jobExportClients, nil, "")
+	}
+
+	clients, err := loadExportClients(database.DB)
+	if err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to fetch clients",
 		})
 	}
 
-	headers := []string{"Name", "Surname", "Contact-info", "Address", "Balance", "Registered"}
-	fields := []string{"Name", "Surname", "ContactInfo", "Address", "Balance", "CreatedAt"}
-	return utils.ExportDataToExcel(ctx, clients, headers, fields, "clients.xlsx")
+	return utils.ExportDataToExcel(ctx, clients, clientExportHeaders, clientExportFields, "clients.xlsx")
+}
+
+// Колонки выгрузок заказов по умолчанию, в порядке вывода. Доступны все поля наборов orders
+// и order_items конструктора отчётов.
+var (
+	orderExportColumns = []string{
+		"id", "createdAt", "status", "paymentMethod", "clientName", "sellerName",
+		"warehouseName", "totalPrice", "acceptedAt", "deliveredAt",
+	}
+	orderItemExportColumns = []string{
+		"orderId", "createdAt", "status", "clientName", "sellerName", "productName",
+		"categoryName", "unit", "quantity", "totalPrice", "unitCost", "totalCost",
+	}
+)
+
+// ExportOrders Экспорт заказов
+//
+//	@Summary		Экспорт заказов
+//	@Description	Выгружает заказы с теми же фильтрами, что и список заказов. Файл формируется потоком, поэтому подходит для больших выгрузок; для более чем миллиона строк используйте CSV.
+//	@Tags			Exports
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv
+//	@Security		BearerAuth
+//	@Param			format		query		string		false	"Формат (xlsx, csv)"	default(xlsx)
+//	@Param			columns		query		string		false	"Колонки через запятую: id, createdAt, status, paymentMethod, totalPrice, acceptedAt, deliveredAt, clientId, clientName, sellerId, sellerName, warehouseId, warehouseName"
+//	@Param			dateGte		query		string		false	"Фильтр по дате (не раньше, чем) в формате YYYY-MM-DD"
+//	@Param			dateLte		query		string		false	"Фильтр по дате (не позже, чем) в формате YYYY-MM-DD"
+//	@Param			warehouseId	query		string		false	"Фильтр по складу"
+//	@Param			async		query		bool		false	"Сформировать файл в фоновой задаче"
+//	@Success		200			{file}		file		"Файл с заказами"
+//	@Success		202			{object}	model.Job	"Задача поставлена в очередь"
+//	@Failure		400			{object}	APIError	"Неверные параметры запроса"
+//	@Failure		500			{object}	APIError	"Ошибка сервера"
+//	@Router			/exports/orders [get]
+func ExportOrders(c *fiber.Ctx) error {
+	return exportOrderDataset(c, "orders", orderExportColumns, "orders")
+}
+
+// ExportOrderItems Экспорт позиций заказов
+//
+//	@Summary		Экспорт позиций заказов
+//	@Description	Выгружает позиции заказов с теми же фильтрами по заказу, что и список заказов. Файл формируется потоком, поэтому подходит для больших выгрузок; для более чем миллиона строк используйте CSV.
+//	@Tags			Exports
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,text/csv
+//	@Security		BearerAuth
+//	@Param			format		query		string		false	"Формат (xlsx, csv)"	default(xlsx)
+//	@Param			columns		query		string		false	"Колонки через запятую: orderId, productId, productName, unit, categoryId, categoryName, quantity, totalPrice, unitCost, totalCost, margin, status, paymentMethod, createdAt, clientId, clientName, sellerId, sellerName, warehouseId"
+//	@Param			dateGte		query		string		false	"Фильтр по дате заказа (не раньше, чем) в формате YYYY-MM-DD"
+//	@Param			dateLte		query		string		false	"Фильтр по дате заказа (не позже, чем) в формате YYYY-MM-DD"
+//	@Param			warehouseId	query		string		false	"Фильтр по складу"
+//	@Param			async		query		bool		false	"Сформировать файл в фоновой задаче"
+//	@Success		200			{file}		file		"Файл с позициями заказов"
+//	@Success		202			{object}	model.Job	"Задача поставлена в очередь"
+//	@Failure		400			{object}	APIError	"Неверные параметры запроса"
+//	@Failure		500			{object}	APIError	"Ошибка сервера"
+//	@Router			/exports/order-items [get]
+func ExportOrderItems(c *fiber.Ctx) error {
+	return exportOrderDataset(c, "order_items", orderItemExportColumns, "order-items")
+}
+
+// orderExport — параметры выгрузки заказов. В фоновой задаче передаётся как payload.
+type orderExport struct {
+	Dataset  string      `json:"dataset"`
+	Columns  []string    `json:"columns"`
+	Format   string      `json:"format"`
+	FileName string      `json:"fileName"`
+	Filter   orderFilter `json:"filter"`
+}
+
+// exportOrderDataset выгружает выбранные колонки набора данных конструктора отчётов
+// с фильтрами списка заказов, не загружая результат в память. С async=true выгрузка
+// ставится в очередь.
+func exportOrderDataset(c *fiber.Ctx, name string, defaultColumns []string, filename string) error {
+	export := orderExport{
+		Dataset:  name,
+		Columns:  append([]string(nil), defaultColumns...),
+		Format:   c.Query("format", "xlsx"),
+		FileName: filename + "." + c.Query("format", "xlsx"),
+		Filter:   orderFilterFromQuery(c),
+	}
+	if raw := c.Query("columns"); raw != "" {
+		export.Columns = strings.Split(raw, ",")
+	}
+
+	query, err := export.query()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	if c.QueryBool("async") {
+		return enqueueJob(c, jobExportOrders, export, "")
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to fetch orders",
+		})
+	}
+
+	if export.Format == "csv" {
+		return utils.StreamRowsToCSV(c, export.Columns, rows, export.FileName)
+	}
+	return utils.StreamRowsToExcel(c, export.Columns, rows, export.FileName)
+}
+
+// query проверяет параметры выгрузки и строит запрос. Названия колонок нормализуются.
+func (e *orderExport) query() (*gorm.DB, error) {
+	if e.Format != "xlsx" && e.Format != "csv" {
+		return nil, errors.New("Invalid format. Use xlsx or csv")
+	}
+
+	dataset, ok := reportDatasets[e.Dataset]
+	if !ok {
+		return nil, fmt.Errorf("Unknown dataset %q", e.Dataset)
+	}
+
+	selects := make([]string, len(e.Columns))
+	seen := map[string]bool{}
+	for i, column := range e.Columns {
+		column = strings.TrimSpace(column)
+		field, ok := dataset.Fields[column]
+		if !ok || seen[column] {
+			return nil, fmt.Errorf("Unknown or duplicate column %q", column)
+		}
+		seen[column] = true
+		e.Columns[i] = column
+
+		selects[i] = field.Expr
+		if field.Kind == reportUUID {
+			selects[i] += "::text"
+		}
+	}
+
+	query, err := e.Filter.apply(database.DB.Table(dataset.Source))
+	if err != nil {
+		return nil, err
+	}
+	return query.Select(strings.Join(selects, ", ")).Order("orders.created_at, orders.id"), nil
+}
+
+// exportOrdersJob выполняет выгрузку заказов в фоновой задаче.
+func exportOrdersJob(job *tasks.JobContext) (tasks.JobResult, error) {
+	var export orderExport
+	if err := job.DecodePayload(&export); err != nil {
+		return tasks.JobResult{}, err
+	}
+	query, err := export.query()
+	if err != nil {
+		return tasks.JobResult{}, tasks.PermanentJobError(err)
+	}
+
+	contentType := utils.ExcelContentType
+	if export.Format == "csv" {
+		contentType = utils.CSVContentType
+	}
+	return tasks.JobResult{
+		FileName:    export.FileName,
+		ContentType: contentType,
+		WriteFile: func(w io.Writer) error {
+			rows, err := query.Rows()
+			if err != nil {
+				return err
+			}
+			if export.Format == "csv" {
+				return utils.WriteRowsToCSV(w, export.Columns, rows)
+			}
+			return utils.WriteRowsToExcel(w, export.Columns, rows)
+		},
+	}, nil
+}
+
+// exportStructsJob выгружает продукты или клиентов в фоновой задаче.
+func exportStructsJob(load func(db *gorm.DB) (interface{}, error), headers, fields []string, filename string) tasks.JobHandler {
+	return func(job *tasks.JobContext) (tasks.JobResult, error) {
+		data, err := load(database.DB)
+		if err != nil {
+			return tasks.JobResult{}, err
+		}
+		rows, err := utils.StructRows(data, fields)
+		if err != nil {
+			return tasks.JobResult{}, tasks.PermanentJobError(err)
+		}
+		content, err := utils.RowsToExcel(headers, rows)
+		if err != nil {
+			return tasks.JobResult{}, err
+		}
+
+		return tasks.JobResult{
+			FileName:    filename,
+			ContentType: utils.ExcelContentType,
+			WriteFile: func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			},
+		}, nil
+	}
+}
+
+func loadExportProducts(db *gorm.DB) (interface{}, error) {
+	var products []model.Product
+	err := db.Preload(clause.Associations).Find(&products).Error
+	return products, err
+}
+
+func loadExportClients(db *gorm.DB) (interface{}, error) {
+	var clients []model.Client
+	err := db.Preload(clause.Associations).Find(&clients).Error
+	return clients, err
 }
@@ -0,0 +1,384 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+)
+
+type WeeklyForecast struct {
+	WeekStart string  `json:"weekStart"`
+	Quantity  float64 `json:"quantity"`
+}
+
+// ProductForecast — прогноз спроса на продукт и рекомендуемый объём производства.
+type ProductForecast struct {
+	ProductID           guuid.UUID       `json:"productId"`
+	ProductName         string           `json:"productName"`
+	Unit                string           `json:"unit"`
+	History             []float64        `json:"history"`
+	Forecast            []WeeklyForecast `json:"forecast"`
+	ForecastTotal       float64          `json:"forecastTotal"`
+	Trend               float64          `json:"trend"`
+	Stock               float64          `json:"stock"`
+	Pending             float64          `json:"pending"`
+	MinStock            float64          `json:"minStock"`
+	SuggestedProduction float64          `json:"suggestedProduction"`
+}
+
+// forecastParams — параметры прогноза в неделях.
+type forecastParams struct {
+	Horizon int
+	History int
+	Window  int
+	Season  int
+}
+
+// GetDemandForecast Прогноз спроса и план производства
+//
+//	@Summary		Прогноз спроса и план производства
+//	@Description	По недельным продажам (см. REVENUE_RECOGNITION) за последние history полных недель прогнозирует спрос на weeks недель вперёд: скользящее среднее за window недель плюс линейный тренд, скорректированные на сезонные индексы с периодом season недель (0 — без сезонности; индексы считаются, если истории хватает хотя бы на два периода). Рекомендуемый объём производства покрывает прогноз, ожидающие подтверждения заказы и минимальный остаток за вычетом текущего остатка и округляется вверх до кратного объёма пополнения.
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			weeks		query		int					false	"Горизонт прогноза в неделях"				default(4)
+//	@Param			history		query		int					false	"Глубина истории в неделях"				default(26)
+//	@Param			window		query		int					false	"Окно скользящего среднего в неделях"	default(4)
+//	@Param			season		query		int					false	"Период сезонности в неделях"			default(4)
+//	@Param			productId	query		string				false	"ID продукта"
+//	@Param			warehouseId	query		string				false	"Сравнивать с остатком этого склада вместо общего"
+//	@Success		200			{array}		ProductForecast		"Прогноз по продуктам"
+//	@Failure		400			{object}	APIError			"Неверные параметры запроса"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/warehouse/forecast [get]
+func GetDemandForecast(c *fiber.Ctx) error {
+	params, err := parseForecastParams(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	var productID, warehouseID *guuid.UUID
+	if raw := c.Query("productId"); raw != "" {
+		id, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid productId format",
+			})
+		}
+		productID = &id
+	}
+	if raw := c.Query("warehouseId"); raw != "" {
+		id, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid warehouseId format",
+			})
+		}
+		warehouseID = &id
+	}
+
+	db := database.DB
+
+	// История заканчивается последней полной неделей; текущая неделя не учитывается
+	currentWeek := truncateToBucket(utils.BusinessNow(), "week")
+	historyFrom := currentWeek.AddDate(0, 0, -7*params.History)
+
+	var weekly []struct {
+		ProductID guuid.UUID
+		Week      int
+		Quantity  float64
+	}
+	query := db.Model(&model.DailySalesRollup{}).
+		Select("product_id, (day - ?::date) / 7 AS week, SUM(quantity) AS quantity", historyFrom.Format("2006-01-02")).
+		Where("day >= ? AND day < ?", historyFrom.Format("2006-01-02"), currentWeek.Format("2006-01-02")).
+		Group("product_id, week")
+	if productID != nil {
+		query = query.Where("product_id = ?", *productID)
+	}
+	if err := query.Scan(&weekly).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve sales history",
+		})
+	}
+
+	series := map[guuid.UUID][]float64{}
+	for _, row := range weekly {
+		if _, ok := series[row.ProductID]; !ok {
+			series[row.ProductID] = make([]float64, params.History)
+		}
+		if row.Week >= 0 && row.Week < params.History {
+			series[row.ProductID][row.Week] += row.Quantity
+		}
+	}
+
+	// Заказы в статусе pending ещё не списаны со склада, но уже ждут продукцию
+	var pendingRows []struct {
+		ProductID guuid.UUID
+		Quantity  float64
+	}
+	pendingQuery := db.Model(&model.OrderItem{}).
+		Select("order_items.product_id, SUM(order_items.quantity) AS quantity").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.status = ?", "pending").
+		Group("order_items.product_id")
+	if warehouseID != nil {
+		pendingQuery = pendingQuery.Where("orders.warehouse_id = ?", *warehouseID)
+	}
+	if productID != nil {
+		pendingQuery = pendingQuery.Where("order_items.product_id = ?", *productID)
+	}
+	if err := pendingQuery.Scan(&pendingRows).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve pending orders",
+		})
+	}
+	pending := map[guuid.UUID]float64{}
+	for _, row := range pendingRows {
+		pending[row.ProductID] = row.Quantity
+	}
+
+	var products []model.Product
+	productQuery := db.Model(&model.Product{})
+	if productID != nil {
+		productQuery = productQuery.Where("id = ?", *productID)
+	} else {
+		ids := make([]guuid.UUID, 0, len(series)+len(pending))
+		for id := range series {
+			ids = append(ids, id)
+		}
+		for id := range pending {
+			ids = append(ids, id)
+		}
+		productQuery = productQuery.Where("id IN ?", ids)
+	}
+	if err := productQuery.Find(&products).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve products",
+		})
+	}
+	if productID != nil && len(products) == 0 {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Product not found",
+		})
+	}
+
+	result := make([]ProductForecast, 0, len(products))
+	for _, product := range products {
+		history, ok := series[product.ID]
+		if !ok {
+			history = make([]float64, params.History)
+		}
+
+		stock := product.Amount
+		if warehouseID != nil {
+			stock, err = availableStock(db, *warehouseID, product.ID)
+			if err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"status":  500,
+					"success": false,
+					"message": "Failed to retrieve stock",
+				})
+			}
+		}
+
+		forecast, trend := forecastDemand(history, params)
+		row := ProductForecast{
+			ProductID:   product.ID,
+			ProductName: product.Name,
+			Unit:        product.Unit,
+			History:     history,
+			Forecast:    make([]WeeklyForecast, len(forecast)),
+			Trend:       math.Round(trend*100) / 100,
+			Stock:       stock,
+			Pending:     pending[product.ID],
+			MinStock:    product.MinStock,
+		}
+		for i, quantity := range forecast {
+			quantity = math.Round(quantity*100) / 100
+			row.Forecast[i] = WeeklyForecast{
+				WeekStart: currentWeek.AddDate(0, 0, 7*i).Format("2006-01-02"),
+				Quantity:  quantity,
+			}
+			row.ForecastTotal += quantity
+		}
+		row.SuggestedProduction = suggestedProduction(row.ForecastTotal+row.Pending+row.MinStock-row.Stock, product)
+		result = append(result, row)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].SuggestedProduction != result[j].SuggestedProduction {
+			return result[i].SuggestedProduction > result[j].SuggestedProduction
+		}
+		return result[i].ProductName < result[j].ProductName
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    result,
+	})
+}
+
+// forecastDemand прогнозирует недельный спрос на params.Horizon недель начиная с текущей.
+// Ряд очищается от сезонности, уровень берётся как скользящее среднее последних недель,
+// наклон — по методу наименьших квадратов. Возвращает прогноз и недельный тренд.
+func forecastDemand(series []float64, params forecastParams) ([]float64, float64) {
+	n := len(series)
+	indices := seasonalIndices(series, params.Season)
+
+	adjusted := make([]float64, n)
+	for i, value := range series {
+		adjusted[i] = value
+		if indices != nil && indices[i%params.Season] > 0 {
+			adjusted[i] = value / indices[i%params.Season]
+		}
+	}
+
+	window := min(params.Window, n)
+	var level float64
+	for _, value := range adjusted[n-window:] {
+		level += value
+	}
+	level /= float64(window)
+
+	trend := linearSlope(adjusted)
+	// Скользящее среднее отстаёт от последней недели на половину окна
+	lag := float64(window-1) / 2
+
+	forecast := make([]float64, params.Horizon)
+	for h := 1; h <= params.Horizon; h++ {
+		value := level + trend*(lag+float64(h))
+		if indices != nil {
+			value *= indices[(n-1+h)%params.Season]
+		}
+		forecast[h-1] = math.Max(0, value)
+	}
+	return forecast, trend
+}
+
+// seasonalIndices возвращает мультипликативные индексы для каждой позиции в периоде:
+// среднее отношение значения к центрированному скользящему среднему за период.
+// Возвращает nil, если сезонность выключена или истории меньше двух периодов.
+func seasonalIndices(series []float64, season int) []float64 {
+	n := len(series)
+	if season < 2 || n < 2*season {
+		return nil
+	}
+
+	sums := make([]float64, season)
+	counts := make([]int, season)
+	half := season / 2
+	for i := half; i < n-half; i++ {
+		// Для чётного периода крайние точки окна берутся с половинным весом
+		var average float64
+		for j := i - half; j <= i+half; j++ {
+			weight := 1.0
+			if season%2 == 0 && (j == i-half || j == i+half) {
+				weight = 0.5
+			}
+			average += weight * series[j]
+		}
+		average /= float64(season)
+		if average <= 0 {
+			continue
+		}
+		sums[i%season] += series[i] / average
+		counts[i%season]++
+	}
+
+	indices := make([]float64, season)
+	var total float64
+	for k := range indices {
+		if counts[k] == 0 {
+			return nil
+		}
+		indices[k] = sums[k] / float64(counts[k])
+		total += indices[k]
+	}
+	if total <= 0 {
+		return nil
+	}
+	// Нормировка: среднее индексов равно единице
+	for k := range indices {
+		indices[k] *= float64(season) / total
+	}
+	return indices
+}
+
+// linearSlope — наклон прямой, приближающей ряд по методу наименьших квадратов.
+func linearSlope(series []float64) float64 {
+	n := float64(len(series))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, value := range series {
+		x := float64(i)
+		sumX += x
+		sumY += value
+		sumXY += x * value
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// suggestedProduction округляет нехватку вверх: до целых штук и до кратного объёма пополнения.
+func suggestedProduction(shortage float64, product model.Product) float64 {
+	if shortage <= 0 {
+		return 0
+	}
+	if product.ReorderQuantity > 0 {
+		return math.Ceil(shortage/product.ReorderQuantity) * product.ReorderQuantity
+	}
+	if product.Unit == "piece" {
+		return math.Ceil(shortage)
+	}
+	return math.Round(shortage*100) / 100
+}
+
+func parseForecastParams(c *fiber.Ctx) (forecastParams, error) {
+	params := forecastParams{
+		Horizon: c.QueryInt("weeks", 4),
+		History: c.QueryInt("history", 26),
+		Window:  c.QueryInt("window", 4),
+		Season:  c.QueryInt("season", 4),
+	}
+	switch {
+	case params.Horizon < 1 || params.Horizon > 52:
+		return params, errors.New("weeks must be between 1 and 52")
+	case params.History < 2 || params.History > 260:
+		return params, errors.New("history must be between 2 and 260")
+	case params.Window < 1 || params.Window > params.History:
+		return params, errors.New("window must be between 1 and history")
+	case params.Season < 0 || params.Season > 52:
+		return params, errors.New("season must be between 0 and 52")
+	}
+	return params, nil
+}
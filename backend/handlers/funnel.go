@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/model"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Этапы выполнения заказа после создания. Отклонить можно только ожидающий заказ,
+// поэтому заказ в любом из этих статусов прошёл все предыдущие этапы.
+var orderStages = []string{"accepted", "in_production", "ready", "delivered"}
+
+type StatusCount struct {
+	Status string  `json:"status"`
+	Count  int     `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+type FunnelStage struct {
+	Status string `json:"status"`
+	// Заказы, дошедшие до этапа
+	Count int `json:"count"`
+	// Доля от предыдущего этапа и от всех созданных заказов, %
+	StepConversion    *float64 `json:"stepConversion"`
+	OverallConversion *float64 `json:"overallConversion"`
+}
+
+type StatusFunnel struct {
+	From          time.Time     `json:"from"`
+	To            time.Time     `json:"to"`
+	Created       int           `json:"created"`
+	Rejected      int           `json:"rejected"`
+	RejectionRate *float64      `json:"rejectionRate"`
+	Stages        []FunnelStage `json:"stages"`
+	Breakdown     []StatusCount `json:"breakdown"`
+}
+
+// GetStatusFunnel Воронка статусов заказов
+//
+//	@Summary		Воронка статусов заказов
+//	@Description	Для заказов, созданных за период, возвращает разбивку по текущим статусам и воронку создан → принят → в производстве → готов → доставлен с конверсией и долей отклонённых.
+//	@Tags			Statistics
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			period	query		string			false	"Календарный период (day, week, month, quarter, year)"	default(month)
+//	@Param			from	query		string			false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to		query		string			false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Success		200		{object}	StatusFunnel	"Воронка статусов"
+//	@Failure		400		{object}	APIError		"Неверные параметры запроса"
+//	@Failure		500		{object}	APIError		"Ошибка сервера"
+//	@Router			/statistics/funnel [get]
+func GetStatusFunnel(c *fiber.Ctx) error {
+	r, err := parseStatsRange(c, "month", false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	breakdown := []StatusCount{}
+	err = database.DB.Model(&model.Order{}).
+		Select("status, COUNT(id) AS count, COALESCE(SUM(total_price), 0) AS amount").
+		Where("created_at >= ? AND created_at < ?", r.From, r.To).
+		Group("status").
+		Order("count DESC").
+		Scan(&breakdown).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to build status funnel",
+		})
+	}
+
+	funnel := StatusFunnel{From: r.From, To: r.To, Breakdown: breakdown}
+
+	byStatus := map[string]int{}
+	for _, row := range breakdown {
+		byStatus[row.Status] = row.Count
+		funnel.Created += row.Count
+	}
+	funnel.Rejected = byStatus["rejected"]
+	funnel.RejectionRate = ratio(funnel.Rejected, funnel.Created)
+
+	funnel.Stages = append(funnel.Stages, FunnelStage{
+		Status:            "created",
+		Count:             funnel.Created,
+		StepConversion:    ratio(funnel.Created, funnel.Created),
+		OverallConversion: ratio(funnel.Created, funnel.Created),
+	})
+
+	// Дошедшие до этапа — все заказы в этом статусе и в последующих
+	previous := funnel.Created
+	for i, status := range orderStages {
+		count := 0
+		for _, later := range orderStages[i:] {
+			count += byStatus[later]
+		}
+
+		funnel.Stages = append(funnel.Stages, FunnelStage{
+			Status:            status,
+			Count:             count,
+			StepConversion:    ratio(count, previous),
+			OverallConversion: ratio(count, funnel.Created),
+		})
+		previous = count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"data":    funnel,
+	})
+}
+
+type LeadTime struct {
+	FromStatus  string  `json:"fromStatus"`
+	ToStatus    string  `json:"toStatus"`
+	Orders      int     `json:"orders"`
+	AvgHours    float64 `json:"avgHours"`
+	MedianHours float64 `json:"medianHours"`
+}
+
+// Переходы, для которых считаются сроки, и колонки с их временем
+var leadTimeSteps = []struct {
+	from, to             string
+	fromColumn, toColumn string
+}{
+	{"pending", "accepted", "created_at", "accepted_at"},
+	{"pending", "rejected", "created_at", "rejected_at"},
+	{"accepted", "in_production", "accepted_at", "in_production_at"},
+	{"in_production", "ready", "in_production_at", "ready_at"},
+	{"ready", "delivered", "ready_at", "delivered_at"},
+	{"pending", "delivered", "created_at", "delivered_at"},
+}
+
+// GetLeadTimes Сроки между статусами заказов
+//
+//	@Summary		Сроки между статусами заказов
+//	@Description	Среднее и медианное время в часах между статусами для заказов, созданных за период. Учитываются только переходы, время которых записано.
+//	@Tags			Statistics
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			period	query		string		false	"Календарный период (day, week, month, quarter, year)"	default(month)
+//	@Param			from	query		string		false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to		query		string		false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Success		200		{array}		LeadTime	"Сроки по переходам"
+//	@Failure		400		{object}	APIError	"Неверные параметры запроса"
+//	@Failure		500		{object}	APIError	"Ошибка сервера"
+//	@Router			/statistics/lead-times [get]
+func GetLeadTimes(c *fiber.Ctx) error {
+	r, err := parseStatsRange(c, "month", false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	leadTimes := make([]LeadTime, 0, len(leadTimeSteps))
+	for _, step := range leadTimeSteps {
+		hours := "EXTRACT(EPOCH FROM (" + step.toColumn + " - " + step.fromColumn + ")) / 3600"
+
+		leadTime := LeadTime{FromStatus: step.from, ToStatus: step.to}
+		err := database.DB.Model(&model.Order{}).
+			Select("COUNT(id) AS orders, COALESCE(AVG("+hours+"), 0) AS avg_hours, "+
+				"COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY "+hours+"), 0) AS median_hours").
+			Where("created_at >= ? AND created_at < ?", r.From, r.To).
+			Where(step.fromColumn + " IS NOT NULL AND " + step.toColumn + " IS NOT NULL").
+			Scan(&leadTime).Error
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to calculate lead times",
+			})
+		}
+		leadTimes = append(leadTimes, leadTime)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"data":    leadTimes,
+	})
+}
+
+// ratio возвращает долю part от total в процентах или nil, если total равен нулю.
+func ratio(part, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	value := float64(part) / float64(total) * 100
+	return &value
+}
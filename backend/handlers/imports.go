@@ -0,0 +1,548 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/tasks"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	maxImportRows     = 5000
+	maxImportFileSize = 4 * 1024 * 1024 // лимит тела запроса Fiber по умолчанию
+
+	importCreate = "create"
+	importUpdate = "update"
+)
+
+// ImportRowError — ошибка в строке файла. Row — номер строки в файле (заголовок — строка 1).
+type ImportRowError struct {
+	Row     int    `json:"row" example:"3"`
+	Column  string `json:"column,omitempty" example:"Price"`
+	Message string `json:"message" example:"must be greater than or equal to 0"`
+}
+
+// ImportRowResult — что импорт сделает (или сделал) со строкой файла.
+type ImportRowResult struct {
+	Row    int        `json:"row" example:"2"`
+	Action string     `json:"action" example:"create"`
+	ID     guuid.UUID `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Key    string     `json:"key" example:"Стол дубовый"`
+}
+
+type ImportResult struct {
+	DryRun  bool              `json:"dryRun"`
+	Total   int               `json:"total"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Rows    []ImportRowResult `json:"rows"`
+	Errors  []ImportRowError  `json:"errors"`
+}
+
+// importRowFunc создаёт или обновляет запись по строке файла в транзакции tx. Ошибки данных
+// возвращаются как rowErrors; err — только сбой базы, после которого импорт прерывается.
+type importRowFunc func(tx *gorm.DB, row utils.ImportRow) (result ImportRowResult, rowErrors []ImportRowError, err error)
+
+// importKind описывает импорт одного вида записей: колонки файла и обработку строк.
+type importKind struct {
+	JobType  string
+	Headers  []string
+	Required []string
+	Prepare  func(tx *gorm.DB, actor auditActor) (importRowFunc, error)
+}
+
+var (
+	productImport = importKind{
+		JobType:  jobImportProducts,
+		Headers:  productExportHeaders,
+		Required: []string{"Name"},
+		Prepare: func(tx *gorm.DB, actor auditActor) (importRowFunc, error) {
+			var categories []model.Category
+			if err := tx.Find(&categories).Error; err != nil {
+				return nil, err
+			}
+			categoryIDs := make(map[string]guuid.UUID, len(categories))
+			for _, category := range categories {
+				categoryIDs[strings.ToLower(category.Name)] = category.ID
+			}
+
+			warehouseID, err := defaultWarehouseID(tx)
+			if err != nil {
+				return nil, err
+			}
+
+			seen := make(map[string]int)
+			return func(tx *gorm.DB, row utils.ImportRow) (ImportRowResult, []ImportRowError, error) {
+				return importProductRow(actor, tx, row, categoryIDs, warehouseID, seen)
+			}, nil
+		},
+	}
+	clientImport = importKind{
+		JobType:  jobImportClients,
+		Headers:  clientExportHeaders,
+		Required: []string{"Name", "Surname"},
+		Prepare: func(tx *gorm.DB, actor auditActor) (importRowFunc, error) {
+			if actor.ID == nil {
+				return nil, errors.New("import requires a user to assign clients to")
+			}
+			seen := make(map[string]int)
+			return func(tx *gorm.DB, row utils.ImportRow) (ImportRowResult, []ImportRowError, error) {
+				return importClientRow(actor, tx, row, *actor.ID, seen)
+			}, nil
+		},
+	}
+)
+
+// importJobPayload — параметры фонового импорта; файл сохраняется в JOBS_DIR до удаления задачи.
+type importJobPayload struct {
+	FileName string     `json:"fileName"`
+	Path     string     `json:"path"`
+	DryRun   bool       `json:"dryRun"`
+	Actor    auditActor `json:"actor"`
+}
+
+// ImportProducts Импорт продуктов из Excel или CSV
+//
+//	@Summary		Импорт продуктов
+//	@Description	Загружает продукты из XLSX или CSV с колонками выгрузки /exports/products (Name, Price, Category, Width, Height, Unit, Amount).
+//	@Description	Продукт ищется по названию без учёта регистра: найденный обновляется, иначе создаётся. Категория указывается по названию и должна существовать.
+//	@Description	Отсутствующие в файле колонки не меняются. Количество приходуется на основной склад.
+//	@Description	Все строки проверяются и сохраняются в одной транзакции: при любой ошибке ничего не сохраняется.
+//	@Description	С async=true файл проверяется и ставится в очередь, результат доступен в /jobs/{id}.
+//	@Tags			Imports
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file			true	"Файл .xlsx или .csv"
+//	@Param			dryRun	query		bool			false	"Только проверить файл и показать изменения, не сохраняя их"
+//	@Param			async	query		bool			false	"Выполнить в фоновой задаче"
+//	@Success		200		{object}	ImportResult	"Результат импорта"
+//	@Success		202		{object}	model.Job		"Задача поставлена в очередь"
+//	@Failure		400		{object}	APIError		"Файл не передан или не читается"
+//	@Failure		422		{object}	ImportResult	"Ошибки в строках файла"
+//	@Failure		500		{object}	APIError		"Ошибка сервера"
+//	@Router			/imports/products [post]
+func ImportProducts(c *fiber.Ctx) error {
+	return runImport(c, productImport)
+}
+
+// ImportClients Импорт клиентов из Excel или CSV
+//
+//	@Summary		Импорт клиентов
+//	@Description	Загружает клиентов из XLSX или CSV с колонками выгрузки /exports/clients (Name, Surname, Contact-info, Address, Balance).
+//	@Description	Клиент ищется по Contact-info, а если она не заполнена — по имени и фамилии среди клиентов без контактов.
+//	@Description	Balance задаёт начальный баланс (долг клиента, входит в сальдо выписки) только новых клиентов; Registered игнорируется. Новые клиенты закрепляются за загрузившим пользователем.
+//	@Description	Все строки проверяются и сохраняются в одной транзакции: при любой ошибке ничего не сохраняется.
+//	@Description	С async=true файл проверяется и ставится в очередь, результат доступен в /jobs/{id}.
+//	@Tags			Imports
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file			true	"Файл .xlsx или .csv"
+//	@Param			dryRun	query		bool			false	"Только проверить файл и показать изменения, не сохраняя их"
+//	@Param			async	query		bool			false	"Выполнить в фоновой задаче"
+//	@Success		200		{object}	ImportResult	"Результат импорта"
+//	@Success		202		{object}	model.Job		"Задача поставлена в очередь"
+//	@Failure		400		{object}	APIError		"Файл не передан или не читается"
+//	@Failure		422		{object}	ImportResult	"Ошибки в строках файла"
+//	@Failure		500		{object}	APIError		"Ошибка сервера"
+//	@Router			/imports/clients [post]
+func ImportClients(c *fiber.Ctx) error {
+	return runImport(c, clientImport)
+}
+
+// runImport читает загруженный файл и импортирует его сразу или ставит в очередь (async=true).
+// Структура файла проверяется в обоих случаях, чтобы ошибка в заголовке не ждала воркера.
+func runImport(c *fiber.Ctx, kind importKind) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "File is required",
+		})
+	}
+	if file.Size > maxImportFileSize {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "File size exceeds 4 MB",
+		})
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Could not read file",
+		})
+	}
+	rows, err := utils.ReadImportFile(file.Filename, src, kind.Headers, kind.Required, maxImportRows)
+	src.Close()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	actor := auditActorFrom(c)
+	dryRun := c.QueryBool("dryRun")
+
+	if c.QueryBool("async") {
+		dir := filepath.Join(tasks.JobsDir(), "uploads")
+		path := filepath.Join(dir, guuid.NewString()+strings.ToLower(filepath.Ext(file.Filename)))
+		if err := os.MkdirAll(dir, 0o750); err == nil {
+			err = c.SaveFile(file, path)
+		}
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to save file",
+			})
+		}
+		return enqueueJob(c, kind.JobType, importJobPayload{
+			FileName: file.Filename,
+			Path:     path,
+			DryRun:   dryRun,
+			Actor:    actor,
+		}, path)
+	}
+
+	result, err := importRows(kind, rows, dryRun, actor, nil)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	if len(result.Errors) > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": "File contains errors, nothing was imported",
+			"data":    result,
+		})
+	}
+
+	message := "Import completed"
+	if dryRun {
+		message = "Dry run completed, nothing was imported"
+	}
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": message,
+		"data":    result,
+	})
+}
+
+// importRows обрабатывает все строки в одной транзакции. Транзакция фиксируется, только если
+// ошибок нет и это не пробный прогон, поэтому dryRun показывает ровно то, что сделал бы
+// настоящий импорт. progress, если задан, вызывается после каждых 100 строк.
+func importRows(kind importKind, rows []utils.ImportRow, dryRun bool, actor auditActor, progress func(done, total int)) (ImportResult, error) {
+	result := ImportResult{
+		DryRun: dryRun,
+		Total:  len(rows),
+		Rows:   []ImportRowResult{},
+		Errors: []ImportRowError{},
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	process, err := kind.Prepare(tx, actor)
+	if err != nil {
+		return result, errors.New("Could not prepare import")
+	}
+
+	for i, row := range rows {
+		rowResult, rowErrors, err := process(tx, row)
+		if err != nil {
+			return result, fmt.Errorf("Import failed at row %d", row.Number)
+		}
+		if progress != nil && (i+1)%100 == 0 {
+			progress(i+1, len(rows))
+		}
+		if len(rowErrors) > 0 {
+			result.Errors = append(result.Errors, rowErrors...)
+			continue
+		}
+
+		result.Rows = append(result.Rows, rowResult)
+		if rowResult.Action == importCreate {
+			result.Created++
+		} else {
+			result.Updated++
+		}
+	}
+
+	if len(result.Errors) > 0 || dryRun {
+		return result, nil
+	}
+	if err := tx.Commit().Error; err != nil {
+		return result, errors.New("Failed to commit transaction")
+	}
+	return result, nil
+}
+
+// runImportJob — фоновый вариант runImport. Ошибки в строках не исправятся повтором,
+// поэтому задача с ними сразу завершается, а итог с ошибками сохраняется в результате.
+func runImportJob(kind importKind) tasks.JobHandler {
+	return func(job *tasks.JobContext) (tasks.JobResult, error) {
+		var payload importJobPayload
+		if err := job.DecodePayload(&payload); err != nil {
+			return tasks.JobResult{}, err
+		}
+
+		src, err := os.Open(payload.Path)
+		if err != nil {
+			return tasks.JobResult{}, tasks.PermanentJobError(err)
+		}
+		rows, err := utils.ReadImportFile(payload.FileName, src, kind.Headers, kind.Required, maxImportRows)
+		src.Close()
+		if err != nil {
+			return tasks.JobResult{}, tasks.PermanentJobError(err)
+		}
+
+		result, err := importRows(kind, rows, payload.DryRun, payload.Actor, func(done, total int) {
+			job.SetProgress(done*100/total, fmt.Sprintf("Обработано строк: %d из %d", done, total))
+		})
+		if err != nil {
+			return tasks.JobResult{}, err
+		}
+		if len(result.Errors) > 0 {
+			return tasks.JobResult{Data: result}, tasks.PermanentJobError(errors.New("file contains errors, nothing was imported"))
+		}
+		return tasks.JobResult{Data: result}, nil
+	}
+}
+
+func importProductRow(actor auditActor, tx *gorm.DB, row utils.ImportRow, categoryIDs map[string]guuid.UUID, warehouseID guuid.UUID, seen map[string]int) (ImportRowResult, []ImportRowError, error) {
+	name := row.Values["Name"]
+	result := ImportRowResult{Row: row.Number, Key: name}
+	if name == "" {
+		return result, []ImportRowError{{Row: row.Number, Column: "Name", Message: "value is required"}}, nil
+	}
+	key := strings.ToLower(name)
+	if first, ok := seen[key]; ok {
+		return result, []ImportRowError{{Row: row.Number, Column: "Name", Message: fmt.Sprintf("duplicate of row %d", first)}}, nil
+	}
+	seen[key] = row.Number
+
+	// Товар блокируется до конца импорта, чтобы сохранение не затёрло остаток и себестоимость,
+	// которые параллельно меняют движения склада и приходы
+	var product model.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("lower(name) = ?", key).First(&product).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, nil, err
+	}
+	exists := err == nil
+	before := product
+
+	var rowErrors []ImportRowError
+	product.Name = name
+	// Пустая ячейка с числом не меняет значение, чтобы случайно не обнулить цену или остаток
+	if row.Values["Price"] != "" {
+		price, err := row.Float("Price")
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: row.Number, Column: "Price", Message: "must be a number"})
+		}
+		product.Price = price
+	}
+	amount := product.Amount
+	if row.Values["Amount"] != "" {
+		value, err := row.Float("Amount")
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: row.Number, Column: "Amount", Message: "must be a number"})
+		}
+		amount = value
+	}
+	if row.Has("Category") {
+		categoryID, ok := categoryIDs[strings.ToLower(row.Values["Category"])]
+		if !ok {
+			rowErrors = append(rowErrors, ImportRowError{Row: row.Number, Column: "Category", Message: "category not found"})
+		}
+		product.CategoryID = categoryID.String()
+	}
+	if row.Has("Width") {
+		product.Width = row.Values["Width"]
+	}
+	if row.Has("Height") {
+		product.Height = row.Values["Height"]
+	}
+	if row.Has("Unit") {
+		product.Unit = row.Values["Unit"]
+	}
+	if len(rowErrors) > 0 {
+		return result, rowErrors, nil
+	}
+
+	// Новые строки проверяются как при создании продукта, найденные — как при обновлении
+	var validationErr error
+	if exists {
+		validationErr = validator.New().Struct(UpdateProductRequest{
+			Name:       &product.Name,
+			CategoryID: &product.CategoryID,
+			Width:      &product.Width,
+			Height:     &product.Height,
+			Price:      &product.Price,
+			Unit:       &product.Unit,
+			Amount:     &amount,
+		})
+	} else {
+		product.Amount = amount
+		validationErr = validator.New().Struct(product)
+	}
+	if rowErrors := importValidationErrors(row.Number, validationErr, map[string]string{"CategoryID": "Category"}); len(rowErrors) > 0 {
+		return result, rowErrors, nil
+	}
+
+	if !exists {
+		product.ID = guuid.New()
+		product.Amount = 0
+		if err := tx.Create(&product).Error; err != nil {
+			return result, nil, err
+		}
+		if err := adjustStock(tx, warehouseID, product.ID, amount); err != nil {
+			return result, nil, err
+		}
+		product.Amount = amount
+
+		recordAuditAs(actor, tx, model.AuditCreate, "product", product.ID, nil, product)
+		result.Action, result.ID = importCreate, product.ID
+		return result, nil, nil
+	}
+
+	if amount != product.Amount {
+		if err := adjustStock(tx, warehouseID, product.ID, amount-product.Amount); err != nil {
+			var stockErr *insufficientStockError
+			if errors.As(err, &stockErr) {
+				return result, []ImportRowError{{Row: row.Number, Column: "Amount", Message: stockErr.Error()}}, nil
+			}
+			return result, nil, err
+		}
+		product.Amount = amount
+	}
+	if err := tx.Omit("Stocks").Save(&product).Error; err != nil {
+		return result, nil, err
+	}
+
+	recordAuditAs(actor, tx, model.AuditUpdate, "product", product.ID, before, product)
+	result.Action, result.ID = importUpdate, product.ID
+	return result, nil, nil
+}
+
+func importClientRow(actor auditActor, tx *gorm.DB, row utils.ImportRow, salespersonID guuid.UUID, seen map[string]int) (ImportRowResult, []ImportRowError, error) {
+	name, surname, contactInfo := row.Values["Name"], row.Values["Surname"], row.Values["Contact-info"]
+
+	result := ImportRowResult{Row: row.Number, Key: contactInfo}
+	key := "contact:" + contactInfo
+	query := tx.Where("contact_info = ?", contactInfo)
+	if contactInfo == "" {
+		result.Key = strings.TrimSpace(name + " " + surname)
+		key = "name:" + strings.ToLower(result.Key)
+		query = tx.Where("lower(name) = lower(?) AND lower(surname) = lower(?) AND coalesce(contact_info, '') = ''", name, surname)
+	}
+	if first, ok := seen[key]; ok {
+		return result, []ImportRowError{{Row: row.Number, Message: fmt.Sprintf("duplicate of row %d", first)}}, nil
+	}
+	seen[key] = row.Number
+
+	var client model.Client
+	err := query.First(&client).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, nil, err
+	}
+	exists := err == nil
+	before := client
+
+	columns := map[string]string{"ContactInfo": "Contact-info"}
+	if exists {
+		request := UpdateClientRequest{Name: &name, Surname: &surname}
+		if row.Has("Address") {
+			address := row.Values["Address"]
+			request.Address = &address
+		}
+		if rowErrors := importValidationErrors(row.Number, validator.New().Struct(request), columns); len(rowErrors) > 0 {
+			return result, rowErrors, nil
+		}
+
+		client.Name, client.Surname = name, surname
+		if request.Address != nil {
+			client.Address = *request.Address
+		}
+		if err := tx.Omit("PurchaseHistory").Save(&client).Error; err != nil {
+			return result, nil, err
+		}
+
+		recordAuditAs(actor, tx, model.AuditUpdate, "client", client.ID, before, client)
+		result.Action, result.ID = importUpdate, client.ID
+		return result, nil, nil
+	}
+
+	client = model.Client{
+		ID:            guuid.New(),
+		Name:          name,
+		Surname:       surname,
+		ContactInfo:   contactInfo,
+		Address:       row.Values["Address"],
+		SalespersonID: salespersonID,
+	}
+	if row.Values["Balance"] != "" {
+		balance, err := row.Float("Balance")
+		if err != nil || balance != math.Trunc(balance) {
+			return result, []ImportRowError{{Row: row.Number, Column: "Balance", Message: "must be a whole number"}}, nil
+		}
+		client.Balance = int64(balance)
+	}
+	if rowErrors := importValidationErrors(row.Number, validator.New().Struct(client), columns); len(rowErrors) > 0 {
+		return result, rowErrors, nil
+	}
+
+	if err := tx.Create(&client).Error; err != nil {
+		return result, nil, err
+	}
+
+	recordAuditAs(actor, tx, model.AuditCreate, "client", client.ID, nil, client)
+	result.Action, result.ID = importCreate, client.ID
+	return result, nil, nil
+}
+
+// importValidationErrors переводит ошибки validator в ошибки строки; columns сопоставляет поля
+// структуры с колонками файла там, где названия отличаются.
+func importValidationErrors(row int, err error, columns map[string]string) []ImportRowError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	rowErrors := make([]ImportRowError, 0, len(validationErrors))
+	for _, fieldErr := range validationErrors {
+		column, ok := columns[fieldErr.Field()]
+		if !ok {
+			column = fieldErr.Field()
+		}
+		message := "failed on '" + fieldErr.Tag() + "' validation"
+		if fieldErr.Param() != "" {
+			message = fmt.Sprintf("failed on '%s=%s' validation", fieldErr.Tag(), fieldErr.Param())
+		}
+		rowErrors = append(rowErrors, ImportRowError{Row: row, Column: column, Message: message})
+	}
+	return rowErrors
+}
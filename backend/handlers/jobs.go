@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/tasks"
+	"backend/utils"
+	"errors"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Типы фоновых задач
+const (
+	jobExportOrders   = "export.orders"
+	jobExportProducts = "export.products"
+	jobExportClients  = "export.clients"
+	jobImportProducts = "import.products"
+	jobImportClients  = "import.clients"
+	jobOrderPDF       = "pdf.order"
+)
+
+// JobHandlers возвращает обработчики всех типов фоновых задач для tasks.StartJobWorkers.
+func JobHandlers() map[string]tasks.JobHandler {
+	return map[string]tasks.JobHandler{
+		jobExportOrders:   exportOrdersJob,
+		jobExportProducts: exportStructsJob(loadExportProducts, productExportHeaders, productExportFields, "products.xlsx"),
+		jobExportClients:  exportStructsJob(loadExportClients, clientExportHeaders, clientExportFields, "clients.xlsx"),
+		jobImportProducts: runImportJob(productImport),
+		jobImportClients:  runImportJob(clientImport),
+		jobOrderPDF:       orderPDFJob,
+	}
+}
+
+var (
+	errInvalidJobID = errors.New("invalid job id")
+	errJobNotOwned  = errors.New("job belongs to another user")
+)
+
+// enqueueJob ставит задачу от имени текущего пользователя и отвечает 202 со статусом задачи.
+func enqueueJob(c *fiber.Ctx, jobType string, payload interface{}, inputPath string) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	job, err := tasks.EnqueueJob(database.DB, jobType, payload, inputPath, user.ID)
+	if err != nil {
+		if inputPath != "" {
+			os.Remove(inputPath)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to queue job",
+		})
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status":  202,
+		"success": true,
+		"message": "Job queued",
+		"data":    job,
+	})
+}
+
+// GetJobs Список фоновых задач
+//
+//	@Summary		Список фоновых задач
+//	@Description	Возвращает задачи текущего пользователя, новые сначала. Администратор видит задачи всех пользователей.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string		false	"Статус (queued, running, succeeded, failed)"
+//	@Param			type		query		string		false	"Тип задачи (export.orders, export.products, export.clients, import.products, import.clients, pdf.order)"
+//	@Param			page		query		int			false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int			false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.Job	"Задачи с информацией о пагинации"
+//	@Failure		500			{object}	APIError	"Ошибка сервера"
+//	@Router			/jobs [get]
+func GetJobs(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+	jobs := []model.Job{}
+
+	db := database.DB.Order("created_at desc")
+	if user.Role != model.AdminRole {
+		db = db.Where("created_by_id = ?", user.ID)
+	}
+	if status := c.Query("status"); status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if jobType := c.Query("type"); jobType != "" {
+		db = db.Where("type = ?", jobType)
+	}
+
+	response, err := utils.Paginate(db, c, nil, &jobs)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve jobs",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetJobByID Статус фоновой задачи
+//
+//	@Summary		Статус фоновой задачи
+//	@Description	Возвращает статус, прогресс и результат задачи. Когда status = succeeded и задан resultFileName, файл доступен по /jobs/{id}/download.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string		true	"ID задачи"
+//	@Success		200	{object}	model.Job	"Задача"
+//	@Failure		400	{object}	APIError	"Неверный формат ID"
+//	@Failure		403	{object}	APIError	"Задача другого пользователя"
+//	@Failure		404	{object}	APIError	"Задача не найдена"
+//	@Failure		500	{object}	APIError	"Ошибка сервера"
+//	@Router			/jobs/{id} [get]
+func GetJobByID(c *fiber.Ctx) error {
+	job, err := findJob(c, database.DB)
+	if err != nil {
+		return jobErrorResponse(c, err)
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Job found",
+		"data":    job,
+	})
+}
+
+// DownloadJobResult Скачать результат фоновой задачи
+//
+//	@Summary		Скачать результат фоновой задачи
+//	@Tags			Jobs
+//	@Produce		application/octet-stream
+//	@Security		BearerAuth
+//	@Param			id	path		string		true	"ID задачи"
+//	@Success		200	{file}		file		"Файл результата"
+//	@Failure		400	{object}	APIError	"Неверный формат ID"
+//	@Failure		403	{object}	APIError	"Задача другого пользователя"
+//	@Failure		404	{object}	APIError	"Задача не найдена или файл ещё не готов"
+//	@Failure		500	{object}	APIError	"Ошибка сервера"
+//	@Router			/jobs/{id}/download [get]
+func DownloadJobResult(c *fiber.Ctx) error {
+	job, err := findJob(c, database.DB)
+	if err != nil {
+		return jobErrorResponse(c, err)
+	}
+	if job.Status != model.JobSucceeded || job.ResultPath == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Job has no result file",
+		})
+	}
+
+	if _, err := os.Stat(job.ResultPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Result file not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to read result file",
+		})
+	}
+
+	return c.Download(job.ResultPath, job.ResultFileName)
+}
+
+// DeleteJob Удалить фоновую задачу
+//
+//	@Summary		Удалить фоновую задачу
+//	@Description	Отменяет задачу в очереди или удаляет завершённую вместе с файлами. Выполняющуюся задачу удалить нельзя.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string		true	"ID задачи"
+//	@Success		200	{object}	APIError	"Задача удалена"
+//	@Failure		400	{object}	APIError	"Неверный формат ID"
+//	@Failure		403	{object}	APIError	"Задача другого пользователя"
+//	@Failure		404	{object}	APIError	"Задача не найдена"
+//	@Failure		409	{object}	APIError	"Задача выполняется"
+//	@Failure		500	{object}	APIError	"Ошибка сервера"
+//	@Router			/jobs/{id} [delete]
+func DeleteJob(c *fiber.Ctx) error {
+	job, err := findJob(c, database.DB)
+	if err != nil {
+		return jobErrorResponse(c, err)
+	}
+
+	// Условие на статус защищает от воркера, который забрал задачу после чтения
+	result := database.DB.Where("id = ? AND status <> ?", job.ID, model.JobRunning).Delete(&model.Job{})
+	if result.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to delete job",
+		})
+	}
+	if result.RowsAffected == 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status":  409,
+			"success": false,
+			"message": "Job is running and cannot be deleted",
+		})
+	}
+
+	tasks.RemoveJobFiles(job)
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Job deleted",
+	})
+}
+
+// findJob загружает задачу из параметра id. Пользователь видит только свои задачи, администратор — все.
+func findJob(c *fiber.Ctx, db *gorm.DB) (model.Job, error) {
+	var job model.Job
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return job, errInvalidJobID
+	}
+	if err := db.First(&job, "id = ?", id).Error; err != nil {
+		return job, err
+	}
+
+	user := c.Locals("user").(*auth.Claims)
+	if user.Role != model.AdminRole && job.CreatedByID != user.ID {
+		return job, errJobNotOwned
+	}
+	return job, nil
+}
+
+func jobErrorResponse(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, errInvalidJobID):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Job ID",
+		})
+	case errors.Is(err, errJobNotOwned):
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "You do not have access to this job",
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Job not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Failed to retrieve job",
+	})
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+)
+
+type SellerPerformance struct {
+	Rank              int        `json:"rank"`
+	SellerID          guuid.UUID `json:"sellerId"`
+	Username          string     `json:"username"`
+	Active            bool       `json:"active"`
+	Revenue           float64    `json:"revenue"`
+	Orders            int        `json:"orders"`
+	RevenueOrders     int        `json:"revenueOrders"`
+	Rejected          int        `json:"rejected"`
+	NewClients        int        `json:"newClients"`
+	AverageOrderValue float64    `json:"averageOrderValue"`
+	RejectionRate     *float64   `json:"rejectionRate"`
+}
+
+// Поля, по которым можно отсортировать рейтинг; доля отклонённых сортируется по возрастанию
+var leaderboardSorts = map[string]func(a, b SellerPerformance) bool{
+	"revenue":           func(a, b SellerPerformance) bool { return a.Revenue > b.Revenue },
+	"orders":            func(a, b SellerPerformance) bool { return a.Orders > b.Orders },
+	"newClients":        func(a, b SellerPerformance) bool { return a.NewClients > b.NewClients },
+	"averageOrderValue": func(a, b SellerPerformance) bool { return a.AverageOrderValue > b.AverageOrderValue },
+	"rejectionRate": func(a, b SellerPerformance) bool {
+		if a.RejectionRate == nil || b.RejectionRate == nil {
+			return b.RejectionRate == nil && a.RejectionRate != nil
+		}
+		return *a.RejectionRate < *b.RejectionRate
+	},
+}
+
+// GetSellerLeaderboard Рейтинг продавцов
+//
+//	@Summary		Рейтинг продавцов
+//	@Description	Выручка (см. REVENUE_RECOGNITION), число заказов, новые клиенты, средний чек и доля отклонённых заказов по каждому продавцу за период. Деактивированные продавцы с продажами за период тоже попадают в рейтинг.
+//	@Tags			Statistics
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			period	query		string				false	"Календарный период (day, week, month, quarter, year)"	default(month)
+//	@Param			from	query		string				false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to		query		string				false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Param			sortBy	query		string				false	"Сортировка (revenue, orders, newClients, averageOrderValue, rejectionRate)"	default(revenue)
+//	@Success		200		{array}		SellerPerformance	"Рейтинг продавцов"
+//	@Failure		400		{object}	APIError			"Неверные параметры запроса"
+//	@Failure		500		{object}	APIError			"Ошибка сервера"
+//	@Router			/statistics/sellers [get]
+func GetSellerLeaderboard(c *fiber.Ctx) error {
+	r, err := parseStatsRange(c, "month", false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	sortBy := c.Query("sortBy", "revenue")
+	less, ok := leaderboardSorts[sortBy]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid sortBy parameter",
+		})
+	}
+
+	db := database.DB
+
+	var orderStats []struct {
+		SalespersonID guuid.UUID
+		Revenue       float64
+		Orders        int
+		RevenueOrders int
+		Rejected      int
+	}
+	err = db.Model(&model.Order{}).
+		Select(`salesperson_id,
+			COALESCE(SUM(total_price) FILTER (WHERE status IN (?)), 0) AS revenue,
+			COUNT(id) AS orders,
+			COUNT(id) FILTER (WHERE status IN (?)) AS revenue_orders,
+			COUNT(id) FILTER (WHERE status = 'rejected') AS rejected`, utils.RevenueStatuses(), utils.RevenueStatuses()).
+		Where("created_at >= ? AND created_at < ?", r.From, r.To).
+		Group("salesperson_id").
+		Scan(&orderStats).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to build seller leaderboard",
+		})
+	}
+
+	var clientStats []struct {
+		SalespersonID guuid.UUID
+		NewClients    int
+	}
+	err = db.Model(&model.Client{}).
+		Select("salesperson_id, COUNT(id) AS new_clients").
+		Where("created_at >= ? AND created_at < ?", r.From, r.To).
+		Group("salesperson_id").
+		Scan(&clientStats).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to build seller leaderboard",
+		})
+	}
+
+	// Все текущие продавцы, даже без продаж, и бывшие — если у них есть заказы за период
+	var sellers []model.User
+	err = db.Unscoped().
+		Where("(role = ? AND deleted_at IS NULL) OR id IN (SELECT salesperson_id FROM orders WHERE created_at >= ? AND created_at < ?)", model.Seller, r.From, r.To).
+		Find(&sellers).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to build seller leaderboard",
+		})
+	}
+
+	rows := map[guuid.UUID]*SellerPerformance{}
+	for _, seller := range sellers {
+		rows[seller.ID] = &SellerPerformance{SellerID: seller.ID, Username: seller.Username, Active: seller.Active}
+	}
+	for _, stat := range orderStats {
+		row, ok := rows[stat.SalespersonID]
+		if !ok {
+			continue
+		}
+		row.Revenue = stat.Revenue
+		row.Orders = stat.Orders
+		row.RevenueOrders = stat.RevenueOrders
+		row.Rejected = stat.Rejected
+		if stat.RevenueOrders > 0 {
+			row.AverageOrderValue = stat.Revenue / float64(stat.RevenueOrders)
+		}
+		row.RejectionRate = ratio(stat.Rejected, stat.Orders)
+	}
+	for _, stat := range clientStats {
+		if row, ok := rows[stat.SalespersonID]; ok {
+			row.NewClients = stat.NewClients
+		}
+	}
+
+	leaderboard := make([]SellerPerformance, 0, len(rows))
+	for _, row := range rows {
+		leaderboard = append(leaderboard, *row)
+	}
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		if less(leaderboard[i], leaderboard[j]) != less(leaderboard[j], leaderboard[i]) {
+			return less(leaderboard[i], leaderboard[j])
+		}
+		return leaderboard[i].Username < leaderboard[j].Username
+	})
+	for i := range leaderboard {
+		leaderboard[i].Rank = i + 1
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"data":    leaderboard,
+	})
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/utils"
+	"sort"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// MarginRow — выручка, себестоимость и валовая маржа по одной группе.
+type MarginRow struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Revenue       float64 `json:"revenue"`
+	Cost          float64 `json:"cost"`
+	Margin        float64 `json:"margin"`
+	MarginPercent float64 `json:"marginPercent"`
+	Quantity      float64 `json:"quantity"`
+	OrderCount    int     `json:"orderCount"`
+}
+
+type MarginReport struct {
+	GroupBy string      `json:"groupBy"`
+	From    time.Time   `json:"from"`
+	To      time.Time   `json:"to"`
+	Total   MarginRow   `json:"total"`
+	Rows    []MarginRow `json:"rows"`
+}
+
+// marginGroups описывает группировки отчёта по марже: ключ, название и дополнительное соединение.
+var marginGroups = map[string]struct {
+	key  string
+	name string
+	join string
+}{
+	"product":  {"products.id", "products.name", ""},
+	"category": {"categories.id", "categories.name", "JOIN categories ON categories.id = products.category_id"},
+	"client":   {"clients.id", "CONCAT(clients.name, ' ', clients.surname)", "JOIN clients ON clients.id = orders.client_id"},
+	"seller":   {"users.id", "users.username", "JOIN users ON users.id = orders.salesperson_id"},
+}
+
+// GetMarginReport Отчёт о валовой марже
+//
+//	@Summary		Отчёт о валовой марже
+//	@Description	Выручка, себестоимость (зафиксированная в позициях заказов) и валовая маржа по продуктам, категориям, клиентам или продавцам. Учитываются только заказы, входящие в выручку (см. REVENUE_RECOGNITION).
+//	@Tags			Statistics
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			groupBy	query		string			false	"Группировка (product, category, client, seller)"	default(product)
+//	@Param			period	query		string			false	"Календарный период (day, week, month, quarter, year)"	default(month)
+//	@Param			from	query		string			false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to		query		string			false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Success		200		{object}	MarginReport	"Отчёт о марже"
+//	@Failure		400		{object}	APIError		"Неверные параметры запроса"
+//	@Failure		500		{object}	APIError		"Ошибка сервера"
+//	@Router			/statistics/margins [get]
+func GetMarginReport(c *fiber.Ctx) error {
+	groupBy := c.Query("groupBy", "product")
+	group, ok := marginGroups[groupBy]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid groupBy parameter. Use product, category, client or seller",
+		})
+	}
+
+	r, err := parseStatsRange(c, "month", false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	rows := []MarginRow{}
+	query := database.DB.Table("order_items").
+		Select(group.key + "::text AS id, " + group.name + ` AS name,
+			COALESCE(SUM(order_items.total_price), 0) AS revenue,
+			COALESCE(SUM(order_items.total_cost), 0) AS cost,
+			COALESCE(SUM(order_items.quantity), 0) AS quantity,
+			COUNT(DISTINCT orders.id) AS order_count`).
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Joins("JOIN products ON products.id = order_items.product_id")
+	if group.join != "" {
+		query = query.Joins(group.join)
+	}
+	err = query.
+		Where("orders.status IN (?)", utils.RevenueStatuses()).
+		Where("orders.created_at >= ? AND orders.created_at < ?", r.From, r.To).
+		Group(group.key + ", " + group.name).
+		Scan(&rows).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to build margin report",
+		})
+	}
+
+	total := MarginRow{ID: "total", Name: "Итого"}
+	for i := range rows {
+		rows[i].Margin = rows[i].Revenue - rows[i].Cost
+		rows[i].MarginPercent = marginPercent(rows[i].Margin, rows[i].Revenue)
+
+		total.Revenue += rows[i].Revenue
+		total.Cost += rows[i].Cost
+		total.Quantity += rows[i].Quantity
+	}
+	total.Margin = total.Revenue - total.Cost
+	total.MarginPercent = marginPercent(total.Margin, total.Revenue)
+
+	// Заказ может попасть в несколько групп, поэтому общее число заказов считаем отдельно
+	var orderCount int64
+	err = database.DB.Table("orders").
+		Where("status IN (?)", utils.RevenueStatuses()).
+		Where("created_at >= ? AND created_at < ?", r.From, r.To).
+		Count(&orderCount).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to build margin report",
+		})
+	}
+	total.OrderCount = int(orderCount)
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Margin > rows[j].Margin })
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"data": MarginReport{
+			GroupBy: groupBy,
+			From:    r.From,
+			To:      r.To,
+			Total:   total,
+			Rows:    rows,
+		},
+	})
+}
+
+func marginPercent(margin, revenue float64) float64 {
+	if revenue == 0 {
+		return 0
+	}
+	return margin / revenue * 100
+}
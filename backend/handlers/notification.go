@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GetNotifications Мои уведомления
+//
+//	@Summary		Мои уведомления
+//	@Description	Возвращает уведомления текущего пользователя, новые сначала
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			unread		query		bool				false	"Только непрочитанные"
+//	@Param			page		query		int					false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int					false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.Notification	"Уведомления с информацией о пагинации"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/notifications [get]
+func GetNotifications(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+	notifications := []model.Notification{}
+
+	db := database.DB.Where("user_id = ?", user.ID).Order("created_at desc")
+	if c.QueryBool("unread") {
+		db = db.Where("read_at IS NULL")
+	}
+
+	response, err := utils.Paginate(db, c, nil, &notifications)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve notifications",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// MarkNotificationRead Отметить уведомление прочитанным
+//
+//	@Summary		Отметить уведомление прочитанным
+//	@Tags			Notifications
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string				true	"ID уведомления"
+//	@Success		200	{object}	model.Notification	"Уведомление прочитано"
+//	@Failure		400	{object}	APIError			"Неверный формат ID"
+//	@Failure		404	{object}	APIError			"Уведомление не найдено"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/notifications/{id}/read [post]
+func MarkNotificationRead(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid ID format",
+		})
+	}
+
+	var notification model.Notification
+	if err := database.DB.First(&notification, "id = ? AND user_id = ?", id, user.ID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Notification not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
+	if notification.ReadAt == nil {
+		now := time.Now()
+		notification.ReadAt = &now
+		if err := database.DB.Model(&notification).Update("read_at", now).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update notification",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Notification marked as read",
+		"data":    notification,
+	})
+}
@@ -5,20 +5,14 @@ import (
 	"backend/database"
 	"backend/model"
 	"backend/utils"
-	"bytes"
 	"errors"
 	"fmt"
-	"image/color"
 	"log"
-	"os"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	guuid "github.com/google/uuid"
-	"github.com/signintech/gopdf"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -90,8 +84,19 @@ func CreateOrder(c *fiber.Ctx) error {
 	tx := database.DB.Begin()
 	defer tx.Rollback()
 
+	// Заказ выполняется с выбранного склада, иначе — с основного
+	warehouseID, err := resolveWarehouseID(tx, order.WarehouseID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Warehouse not found",
+		})
+	}
+
 	order.ID = guuid.New()
 	order.SalespersonID = user.ID
+	order.WarehouseID = &warehouseID
 	order.TotalPrice = 0
 
 	if err := tx.Omit("Products").Create(&order).Error; err != nil {
@@ -119,15 +124,26 @@ func CreateOrder(c *fiber.Ctx) error {
 			})
 		}
 
-		if dbProduct.Amount < product.Quantity {
+		available, err := availableStock(tx, warehouseID, dbProduct.ID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to check product stock",
+			})
+		}
+		if available < product.Quantity {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 				"status":  400,
 				"success": false,
-				"message": fmt.Sprintf("Product named '%s' is not available in sufficient quantity. Only %f left.", dbProduct.Name, dbProduct.Amount),
+				"message": (&insufficientStockError{ProductName: dbProduct.Name, Available: available}).Error(),
 			})
 		}
 
 		product.TotalPrice = dbProduct.Price * product.Quantity
+		// Себестоимость фиксируется на момент заказа, чтобы последующие закупки не меняли маржу
+		product.UnitCost = dbProduct.CostPrice
+		product.TotalCost = dbProduct.CostPrice * product.Quantity
 		order.TotalPrice += product.TotalPrice // Увеличиваем TotalPrice в памяти
 
 		// Сохраняем продукт заказа
@@ -151,6 +167,8 @@ func CreateOrder(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditCreate, "order", order.ID, nil, order)
+
 	// Коммит транзакции
 	if err := tx.Commit().Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -191,7 +209,7 @@ func GetOrderByID(c *fiber.Ctx) error {
 		})
 	}
 
-	db := database.DB.Preload(clause.Associations).Preload("Products.Product")
+	db := database.DB.Preload(clause.Associations).Preload("Salesperson", unscoped).Preload("Products.Product")
 	Order := model.Order{}
 
 	err = db.Where("id = ?", id).First(&Order).Error
@@ -231,6 +249,7 @@ func GetOrderByID(c *fiber.Ctx) error {
 //	@Param			limit	query		int							false	"Количество элементов на странице"	default(10)
 //	@Param			dateGte	query		string						false	"Фильтр по дате (не раньше, чем) в формате YYYY-MM-DD"
 //	@Param			dateLte	query		string						false	"Фильтр по дате (не позже, чем) в формате YYYY-MM-DD"
+//	@Param			warehouseId	query		string						false	"Фильтр по складу"
 //	@Success		200		{object}	model.CreateOrderRequest	"Список заказов с информацией о пагинации"
 //	@Failure		400		{object}	APIError					"Ошибка валидации параметров запроса"
 //	@Failure		500		{object}	APIError					"Ошибка сервера при получении списка заказов"
@@ -239,49 +258,80 @@ func GetAllOrders(c *fiber.Ctx) error {
 	Orders := []model.Order{}
 
 	// Инициализация запроса
-	db := database.DB
+	db, err := applyOrderFilters(c, database.DB)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	// Выполняем пагинацию
+	response, err := utils.Paginate(db, c, nil, &Orders)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve orders",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// orderFilter — фильтры списка заказов. Хранится в параметрах фоновых выгрузок, поэтому
+// даты и склад остаются строками из запроса.
+type orderFilter struct {
+	DateGte     string `json:"dateGte,omitempty"`
+	DateLte     string `json:"dateLte,omitempty"`
+	WarehouseID string `json:"warehouseId,omitempty"`
+}
 
+func orderFilterFromQuery(c *fiber.Ctx) orderFilter {
+	return orderFilter{
+		DateGte:     c.Query("dateGte"),
+		DateLte:     c.Query("dateLte"),
+		WarehouseID: c.Query("warehouseId"),
+	}
+}
+
+// applyOrderFilters добавляет к запросу фильтры списка заказов: dateGte, dateLte и warehouseId.
+// Колонки указаны с именем таблицы, чтобы фильтры работали и в запросах с JOIN.
+func applyOrderFilters(c *fiber.Ctx, db *gorm.DB) (*gorm.DB, error) {
+	return orderFilterFromQuery(c).apply(db)
+}
+
+func (f orderFilter) apply(db *gorm.DB) (*gorm.DB, error) {
 	// Обрабатываем параметр dateGte
-	dateGte := c.Query("dateGte")
-	if dateGte != "" {
-		parsedDate, err := time.Parse("2006-01-02", dateGte)
+	if f.DateGte != "" {
+		parsedDate, err := time.Parse("2006-01-02", f.DateGte)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  400,
-				"success": false,
-				"message": "Invalid dateGte format, expected YYYY-MM-DD",
-			})
+			return nil, errors.New("Invalid dateGte format, expected YYYY-MM-DD")
 		}
 		// Добавляем фильтр по дате "с"
-		db = db.Where("created_at >= ?", parsedDate)
+		db = db.Where("orders.created_at >= ?", parsedDate)
 	}
 
 	// Обрабатываем параметр dateLte
-	dateLte := c.Query("dateLte")
-	if dateLte != "" {
-		parsedDate, err := time.Parse("2006-01-02", dateLte)
+	if f.DateLte != "" {
+		parsedDate, err := time.Parse("2006-01-02", f.DateLte)
 		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"status":  400,
-				"success": false,
-				"message": "Invalid dateLte format, expected YYYY-MM-DD",
-			})
+			return nil, errors.New("Invalid dateLte format, expected YYYY-MM-DD")
 		}
 		// Добавляем фильтр по дате "до"
-		db = db.Where("created_at <= ?", parsedDate)
+		db = db.Where("orders.created_at <= ?", parsedDate)
 	}
 
-	// Выполняем пагинацию
-	response, err := utils.Paginate(db, c, nil, &Orders)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"status":  500,
-			"success": false,
-			"message": "Failed to retrieve orders",
-		})
+	if f.WarehouseID != "" {
+		id, err := guuid.Parse(f.WarehouseID)
+		if err != nil {
+			return nil, errors.New("Invalid warehouseId format")
+		}
+		db = db.Where("orders.warehouse_id = ?", id)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(response)
+	return db, nil
 }
 
 // UpdateOrder Изменить заказ
@@ -326,7 +376,7 @@ func UpdateOrder(c *fiber.Ctx) error {
 	tx := database.DB.Begin()
 	defer tx.Rollback()
 
-	if err := tx.Preload(clause.Associations).Preload("Products.Product").First(&order, "id = ?", id).Error; err != nil {
+	if err := tx.Preload(clause.Associations).Preload("Salesperson", unscoped).Preload("Products.Product").First(&order, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"status":  404,
@@ -341,6 +391,8 @@ func UpdateOrder(c *fiber.Ctx) error {
 		})
 	}
 
+	before := order
+
 	if body.Products != nil {
 		if len(*body.Products) <= 0 {
 			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -386,6 +438,8 @@ func UpdateOrder(c *fiber.Ctx) error {
 			}
 
 			product.TotalPrice = dbProduct.Price * product.Quantity
+			product.UnitCost = dbProduct.CostPrice
+			product.TotalCost = dbProduct.CostPrice * product.Quantity
 			order.TotalPrice += product.TotalPrice
 
 			if err := tx.Create(&product).Error; err != nil {
@@ -405,8 +459,8 @@ func UpdateOrder(c *fiber.Ctx) error {
 	if body.ClientID != nil {
 		order.ClientID = *body.ClientID
 	}
-	if body.Status != nil {
-		order.Status = *body.Status
+	if body.Status != nil && *body.Status != order.Status {
+		setOrderStatus(&order, *body.Status)
 	}
 	if body.PaymentMethod != nil {
 		order.PaymentMethod = *body.PaymentMethod
@@ -420,6 +474,19 @@ func UpdateOrder(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditUpdate, "order", order.ID, before, order)
+
+	// Продавец или клиент могли измениться, поэтому обновляем итоги и для прежних значений
+	for _, o := range []model.Order{before, order} {
+		if err := refreshOrderRollup(tx, o); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update sales rollup",
+			})
+		}
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":   500,
@@ -428,7 +495,7 @@ func UpdateOrder(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := database.DB.Preload(clause.Associations).Preload("Products.Product").First(&order, "id = ?", id).Error; err != nil {
+	if err := database.DB.Preload(clause.Associations).Preload("Salesperson", unscoped).Preload("Products.Product").First(&order, "id = ?", id).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":   500,
 			"succeess": false,
@@ -468,6 +535,22 @@ func DeleteOrder(c *fiber.Ctx) error {
 	tx := db.Begin()
 	defer tx.Rollback()
 
+	var order model.Order
+	if err := tx.Preload("Products").First(&order, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Order not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
 	err = tx.Where("order_id = ?", id).Delete(&model.OrderItem{}).Error
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -486,6 +569,16 @@ func DeleteOrder(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditDelete, "order", order.ID, order, nil)
+
+	if err := refreshOrderRollup(tx, order); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update sales rollup",
+		})
+	}
+
 	err = tx.Commit().Error
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -505,249 +598,23 @@ func DeleteOrder(c *fiber.Ctx) error {
 // GetOrderPDF Экспорт заказа в PDF
 //
 //	@Summary		Экспорт заказа в PDF
-//	@Description	Эта функция позволяет экспортировать информацию о заказе в формате PDF
+//	@Description	Печатает счёт-фактуру по заказу; то же, что /orders/{id}/documents/invoice. С async=true PDF формируется в фоновой задаче.
 //	@Tags			Orders
 //	@Produce		application/pdf
-//	@Success		200	{file}		file					"PDF-файл с информацией о заказе"
-//	@Failure		500	{object}	map[string]interface{}	"Ошибка генерации PDF"
+//	@Param			lang	query		string					false	"Язык документа (ru, uz)"	default(ru)
+//	@Param			async	query		bool					false	"Сформировать PDF в фоновой задаче"
+//	@Success		200		{file}		file					"PDF-файл с информацией о заказе"
+//	@Success		202		{object}	model.Job				"Задача поставлена в очередь"
+//	@Failure		500		{object}	map[string]interface{}	"Ошибка генерации PDF"
 //	@Router			/orders/pdf/{id} [get]
 func GetOrderPDF(c *fiber.Ctx) error {
-	id := c.Params("id")
-	db := database.DB
-
-	var order model.Order
-	if err := db.Preload(clause.Associations).Preload("Products.Product").
-		First(&order, "id = ?", id).Error; err != nil {
-
-		// Добавляем логирование ошибок
-		log.Printf("Order not found: %v | ID: %s", err, id)
-		return c.Status(404).JSON(fiber.Map{
-			"error": "Заказ не найден",
-		})
-	}
-
-	pdfData, err := generatePDF(order)
-	if err != nil {
-		// Логирование ошибок генерации PDF
-		log.Printf("PDF generation failed: %v | OrderID: %s", err, id)
-		return c.Status(500).JSON(fiber.Map{
-			"error": "Ошибка генерации PDF",
-		})
-	}
-
-	// Устанавливаем правильные заголовки
-	c.Set("Content-Type", "application/pdf")
-	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=order_%s.pdf", id))
-
-	// Отправка бинарных данных
-	return c.Send(pdfData)
+	return sendOrderDocument(c, model.DocumentInvoice)
 }
 
-func generatePDF(order model.Order) ([]byte, error) {
-	pdf := gopdf.GoPdf{}
-
-	pdf.Start(gopdf.Config{
-		PageSize: *gopdf.PageSizeA4,
-		Unit:     gopdf.UnitPT,
-	})
-
-	pdf.AddPage()
-
-	// Константы стилей
-	const (
-		margin         = 40.0
-		headerFontSize = 24.0
-		titleFontSize  = 18.0
-		bodyFontSize   = 12.0
-		smallFontSize  = 10.0
-		primaryColor   = "#2d3436"
-		secondaryColor = "#636e72"
-		accentColor    = "#0984e3"
-		lineHeight     = 1.4
-		logoPath       = "assets/logo.png"
-	)
-
-	// 3. Загрузка шрифтов перед использованием
-	if err := pdf.AddTTFFont("roboto", "assets/Roboto-Regular.ttf"); err != nil {
-		return nil, fmt.Errorf("font load error: %v", err)
-	}
-	if err := pdf.AddTTFFont("roboto-bold", "assets/Roboto-Bold.ttf"); err != nil {
-		return nil, fmt.Errorf("bold font load error: %v", err)
-	}
-
-	// Хелпер-функции
-	setFont := func(font string, size float64) {
-		pdf.SetFont(font, "", size)
-	}
-
-	setColor := func(hex string) {
-		if hex == "" {
-			hex = primaryColor
-		}
-		c := parseHexColor(hex)
-		pdf.SetTextColor(c.R, c.G, c.B)
-	}
-
-	addText := func(x, y float64, text string, font string, size float64, color string) {
-		setFont(font, size)
-		setColor(color)
-		pdf.SetX(x)
-		pdf.SetY(y)
-		pdf.Cell(nil, text)
-	}
-
-	// 4. Безопасная загрузка изображения
-	if _, err := os.Stat(logoPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("logo file not found: %s", logoPath)
-	}
-	if err := pdf.Image(logoPath, margin, margin, &gopdf.Rect{W: 60, H: 60}); err != nil {
-		return nil, fmt.Errorf("image load failed: %v", err)
-	}
-
-	// Заголовок
-	addText(margin+80, margin+15, "INVOICE", "roboto-bold", headerFontSize, accentColor)
-
-	// Информация о компании
-	companyInfo := []string{
-		"Awesome Company Inc.",
-		"123 Business Street, City, Country",
-		"Phone: +1 234 567 890",
-		"Email: contact@company.com",
-	}
-	currentY := margin + 40
-	for _, line := range companyInfo {
-		addText(margin+80, currentY, line, "roboto", smallFontSize, secondaryColor)
-		currentY += 15
-	}
-
-	// Основная информация
-	infoBlockY := margin + 120
-	colWidth := 350.0
-	infoPairs := []struct {
-		Label string
-		Value string
-	}{
-		{"Invoice ID", order.ID.String()},
-		{"Date Issued", order.CreatedAt.Format("02 Jan 2006")},
-		{"Due Date", order.CreatedAt.AddDate(0, 0, 7).Format("02 Jan 2006")},
-		{"Client", order.Client.Name},
-		{"Salesperson", order.Salesperson.Username},
-		{"Status", order.Status},
-		{"Payment", order.PaymentMethod},
-	}
-
-	for i, pair := range infoPairs {
-		x := margin + float64(i%2)*colWidth
-		y := infoBlockY + float64(i/2)*20
-
-		addText(x, y, pair.Label+":", "roboto-bold", bodyFontSize, secondaryColor)
-		addText(x+80, y, pair.Value, "roboto", bodyFontSize, primaryColor)
-	}
-
-	// Таблица товаров
-	tableTop := infoBlockY + 80.0
-	header := []struct {
-		Title string
-		Width float64
-		Align string
-	}{
-		{"Наименование", 160, "left"},
-		{"Количество", 120, "right"},
-		{"Цена за м/шт", 120, "right"},
-		{"Сумма", 120, "right"},
-	}
-
-	// Заголовок таблицы
-	pdf.SetLineWidth(0.5)
-	pdf.SetStrokeColor(223, 230, 233)
-	pdf.SetFillColor(241, 242, 246)
-	pdf.Rectangle(margin, tableTop, margin+530, tableTop+30, "FD", 0, 0)
-
-	xPos := margin
-	for _, h := range header {
-		addText(xPos+10, tableTop+8, h.Title, "roboto-bold", bodyFontSize, primaryColor)
-		xPos += h.Width
-	}
-
-	// Строки таблицы
-	currentY = tableTop + 30
-	for _, item := range order.Products {
-		if currentY > 750 { // Проверка на новый лист
-			pdf.AddPage()
-			currentY = margin + 30
-		}
-
-		x := margin
-		pdf.SetLineWidth(0.2)
-		pdf.Line(margin, currentY+20, margin+580, currentY+20)
-
-		// Название товара
-		addText(x+10, currentY+5, item.Product.Name, "roboto", bodyFontSize, primaryColor)
-		x += header[0].Width
-
-		// Количество
-		addText(x+10, currentY+5, fmt.Sprint(int(item.Quantity)), "roboto", bodyFontSize, primaryColor)
-		x += header[1].Width
-
-		// Цена
-		addText(x+10, currentY+5, fmt.Sprintf("%.2f", item.Product.Price), "roboto", bodyFontSize, primaryColor)
-		x += header[2].Width
-
-		// Сумма
-		addText(x+10, currentY+5, fmt.Sprintf("%.2f", item.TotalPrice), "roboto-bold", bodyFontSize, accentColor)
-
-		currentY += 25
-	}
-
-	// Итоги
-	totalsY := currentY + 30
-	totals := []struct {
-		Label string
-		Value float64
-	}{
-		{"Total", order.TotalPrice},
-		// {"Tax (10%)", order.TotalPrice * 0.1},
-		// {"Total", order.TotalPrice * 1.1},
-	}
-
-	for _, t := range totals {
-		addText(margin+400, totalsY, t.Label+":", "roboto-bold", bodyFontSize, secondaryColor)
-		addText(margin+470, totalsY, fmt.Sprintf("%.2f", t.Value), "roboto-bold", bodyFontSize, primaryColor)
-		totalsY += 20
-	}
-
-	// Подпись и номера страниц
-	pdf.SetFont("roboto", "", smallFontSize)
-	pdf.SetTextColor(99, 110, 114)
-	addText(margin, 780, "Thank you for your business!", "roboto-bold", smallFontSize, accentColor)
-	addText(500, 780, fmt.Sprintf("Page %d of 1", 1), "roboto", smallFontSize, secondaryColor)
-
-	// Графическая подпись
-	pdf.SetLineWidth(1)
-	pdf.Line(margin, 760, margin+200, 760)
-	addText(margin, 765, "Authorized Signature", "roboto", smallFontSize, secondaryColor)
-
-	// Сохранение в буфер
-	var buf bytes.Buffer
-	if _, err := pdf.WriteTo(&buf); err != nil {
-		return nil, err
-	}
-	return buf.Bytes(), nil
-}
-
-func parseHexColor(hex string) (c color.RGBA) {
-	hex = strings.TrimPrefix(hex, "#")
-	if len(hex) == 3 {
-		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
-	}
-	if len(hex) != 6 {
-		panic("invalid color format")
-	}
-	rgb, _ := strconv.ParseUint(hex, 16, 32)
-	return color.RGBA{
-		R: uint8(rgb >> 16),
-		G: uint8(rgb >> 8),
-		B: uint8(rgb),
-		A: 255,
-	}
+// loadOrderForPDF загружает заказ со всем, что печатается в документах, включая удалённые продукты.
+func loadOrderForPDF(db *gorm.DB, id interface{}) (model.Order, error) {
+	var order model.Order
+	err := db.Preload(clause.Associations).Preload("Salesperson", unscoped).Preload("Products.Product", unscoped).Preload("Products.Product.Category", unscoped).
+		First(&order, "id = ?", id).Error
+	return order, err
 }
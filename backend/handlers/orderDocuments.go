@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/tasks"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var errOrderRejected = errors.New("documents are not issued for rejected orders")
+
+type orderPDFPayload struct {
+	OrderID  guuid.UUID `json:"orderId"`
+	Type     string     `json:"type"`
+	Language string     `json:"language"`
+}
+
+// GetOrderDocuments Документы заказа
+//
+//	@Summary		Документы заказа
+//	@Description	Возвращает выданные по заказу документы с номерами
+//	@Tags			Orders
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID заказа"
+//	@Success		200	{array}		model.OrderDocument		"Документы заказа"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/orders/{id}/documents [get]
+func GetOrderDocuments(c *fiber.Ctx) error {
+	orderID, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Order ID",
+		})
+	}
+
+	documents := []model.OrderDocument{}
+	if err := database.DB.Where("order_id = ?", orderID).Order("issued_at").Find(&documents).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve order documents",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Order documents found",
+		"data":    documents,
+	})
+}
+
+// GetOrderDocument Печать документа по заказу
+//
+//	@Summary		Печать документа по заказу
+//	@Description	Печатает документ по заказу: счёт-фактуру (invoice), накладную без цен (delivery_note), лист комплектации (pick_list) или квитанцию об оплате (receipt).
+//	@Description	Номер присваивается при первой печати (например, INV-2026-000123) и не меняется при повторной. Реквизиты берутся из /settings/company.
+//	@Tags			Orders
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			id		path		string		true	"ID заказа"
+//	@Param			type	path		string		true	"Вид документа (invoice, delivery_note, pick_list, receipt)"
+//	@Param			lang	query		string		false	"Язык документа (ru, uz)"	default(ru)
+//	@Param			async	query		bool		false	"Сформировать PDF в фоновой задаче"
+//	@Success		200		{file}		file		"PDF-документ"
+//	@Success		202		{object}	model.Job	"Задача поставлена в очередь"
+//	@Failure		400		{object}	APIError	"Неверный вид документа или язык"
+//	@Failure		404		{object}	APIError	"Заказ не найден"
+//	@Failure		409		{object}	APIError	"Заказ отклонён"
+//	@Failure		500		{object}	APIError	"Ошибка сервера"
+//	@Router			/orders/{id}/documents/{type} [get]
+func GetOrderDocument(c *fiber.Ctx) error {
+	return sendOrderDocument(c, c.Params("type"))
+}
+
+// sendOrderDocument выдаёт (при первой печати) и отдаёт документ заказа из параметра id.
+func sendOrderDocument(c *fiber.Ctx, docType string) error {
+	if _, ok := model.DocumentPrefixes[docType]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid document type. Use invoice, delivery_note, pick_list or receipt",
+		})
+	}
+	language := c.Query("lang", "ru")
+	if _, ok := documentLabels[language]; !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid lang. Use ru or uz",
+		})
+	}
+	orderID, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Order ID",
+		})
+	}
+
+	document, err := issueOrderDocument(c, database.DB, orderID, docType)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Order not found",
+			})
+		}
+		if errors.Is(err, errOrderRejected) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"status":  409,
+				"success": false,
+				"message": "Documents are not issued for rejected orders",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to issue document",
+		})
+	}
+
+	if c.QueryBool("async") {
+		return enqueueJob(c, jobOrderPDF, orderPDFPayload{OrderID: orderID, Type: docType, Language: language}, "")
+	}
+
+	content, err := buildOrderDocument(database.DB, document, language)
+	if err != nil {
+		log.Printf("PDF generation failed: %v | OrderID: %s", err, orderID)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to generate document",
+		})
+	}
+
+	c.Set("Content-Type", "application/pdf")
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.pdf", document.Number))
+	return c.Send(content)
+}
+
+// issueOrderDocument возвращает документ заказа, а если он ещё не выдавался — присваивает
+// следующий номер. Заказ блокируется, поэтому параллельная печать не выдаст два номера,
+// а счётчик обновляется одним INSERT ... ON CONFLICT. Нумерация своя для каждого вида и года.
+func issueOrderDocument(c *fiber.Ctx, db *gorm.DB, orderID guuid.UUID, docType string) (model.OrderDocument, error) {
+	var document model.OrderDocument
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var order model.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "status").First(&order, "id = ?", orderID).Error
+		if err != nil {
+			return err
+		}
+		if order.Status == "rejected" {
+			return errOrderRejected
+		}
+
+		err = tx.Where("order_id = ? AND type = ?", orderID, docType).First(&document).Error
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		now := utils.BusinessNow()
+		var number int
+		err = tx.Raw(`INSERT INTO document_sequences (type, year, last_number) VALUES (?, ?, 1)
+			ON CONFLICT (type, year) DO UPDATE SET last_number = document_sequences.last_number + 1
+			RETURNING last_number`, docType, now.Year()).Scan(&number).Error
+		if err != nil {
+			return err
+		}
+
+		user := c.Locals("user").(*auth.Claims)
+		document = model.OrderDocument{
+			ID:         guuid.New(),
+			OrderID:    orderID,
+			Type:       docType,
+			Number:     fmt.Sprintf("%s-%d-%06d", model.DocumentPrefixes[docType], now.Year(), number),
+			IssuedAt:   now,
+			IssuedByID: user.ID,
+		}
+		if err := tx.Create(&document).Error; err != nil {
+			return err
+		}
+
+		recordAudit(c, tx, model.AuditCreate, "order_document", document.ID, nil, document)
+		return nil
+	})
+	return document, err
+}
+
+// buildOrderDocument печатает выданный документ с текущими реквизитами компании
+// по опубликованному шаблону его вида, а без шаблона — встроенным макетом.
+func buildOrderDocument(db *gorm.DB, document model.OrderDocument, language string) ([]byte, error) {
+	order, err := loadOrderForPDF(db, document.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	company, err := loadCompanySettings(db)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := publishedDocumentLayout(db, document.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderOrderDocument(orderDocumentData{
+		Document: document,
+		Order:    order,
+		Company:  company,
+		Language: language,
+		Layout:   layout,
+	})
+}
+
+// orderPDFJob печатает документ заказа в фоновой задаче. Номер выдаётся при постановке в очередь.
+func orderPDFJob(job *tasks.JobContext) (tasks.JobResult, error) {
+	var payload orderPDFPayload
+	if err := job.DecodePayload(&payload); err != nil {
+		return tasks.JobResult{}, err
+	}
+	if payload.Type == "" {
+		payload.Type = model.DocumentInvoice
+	}
+
+	var document model.OrderDocument
+	err := database.DB.Where("order_id = ? AND type = ?", payload.OrderID, payload.Type).First(&document).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tasks.JobResult{}, tasks.PermanentJobError(errors.New("order document not found"))
+	}
+	if err != nil {
+		return tasks.JobResult{}, err
+	}
+
+	content, err := buildOrderDocument(database.DB, document, payload.Language)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return tasks.JobResult{}, tasks.PermanentJobError(errors.New("order not found"))
+	}
+	if err != nil {
+		return tasks.JobResult{}, err
+	}
+
+	return tasks.JobResult{
+		FileName:    document.Number + ".pdf",
+		ContentType: "application/pdf",
+		WriteFile: func(w io.Writer) error {
+			_, err := w.Write(content)
+			return err
+		},
+	}, nil
+}
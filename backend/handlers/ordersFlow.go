@@ -3,9 +3,11 @@ package handlers
 import (
 	"backend/database"
 	"backend/model"
+	"backend/tasks"
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	guuid "github.com/google/uuid"
@@ -73,7 +75,8 @@ func AcceptOrder(c *fiber.Ctx) error {
 		})
 	}
 
-	order.Status = "accepted"
+	before := order
+	setOrderStatus(&order, "accepted")
 	if err := tx.Save(&order).Error; err != nil {
 		tx.Rollback()
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -82,6 +85,26 @@ func AcceptOrder(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditUpdate, "order", order.ID, before, order)
+
+	if err := refreshOrderRollup(tx, order); err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to update sales rollup",
+		})
+	}
+
+	// Заказы, созданные до появления складов, списываются с основного склада
+	warehouseID, err := resolveWarehouseID(tx, order.WarehouseID)
+	if err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Warehouse not found",
+		})
+	}
+
 	for _, item := range order.Products {
 		if item.Product == nil {
 			tx.Rollback()
@@ -91,17 +114,15 @@ func AcceptOrder(c *fiber.Ctx) error {
 			})
 		}
 
-		if item.Product.Amount < item.Quantity {
-			tx.Rollback()
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"success": false,
-				"message": fmt.Sprintf("Not enough stock for product %s", item.Product.ID),
-			})
-		}
-
-		item.Product.Amount -= item.Quantity
-		if err := tx.Save(item.Product).Error; err != nil {
+		if err := adjustStock(tx, warehouseID, item.ProductID, -item.Quantity); err != nil {
 			tx.Rollback()
+			var stockErr *insufficientStockError
+			if errors.As(err, &stockErr) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"success": false,
+					"message": stockErr.Error(),
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"success": false,
 				"message": "Failed to update product quantity",
@@ -178,7 +199,8 @@ func RejectOrder(c *fiber.Ctx) error {
 		})
 	}
 
-	order.Status = "rejected"
+	before := order
+	setOrderStatus(&order, "rejected")
 	if err := tx.Save(&order).Error; err != nil {
 		tx.Rollback()
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -187,6 +209,16 @@ func RejectOrder(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditUpdate, "order", order.ID, before, order)
+
+	if err := refreshOrderRollup(tx, order); err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to update sales rollup",
+		})
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -211,6 +243,7 @@ func RejectOrder(c *fiber.Ctx) error {
 //	@Param			id								path		string			true	"ID заказа"
 //	@Success		201								{object}	ResponseSuccess	"Заказ успешно изменен"
 //	@Failure		400								{object}	APIError		"Неверный формат UUID"
+//	@Failure		409								{object}	APIError		"Не хватает материалов на складе"
 //	@Router			/warehouse/{id}/in_production	[POST]
 func InProduction(c *fiber.Ctx) error {
 	id, err := guuid.Parse(c.Params("id"))
@@ -257,7 +290,51 @@ func InProduction(c *fiber.Ctx) error {
 		})
 	}
 
-	order.Status = "in_production"
+	allowed, err := warehouseAllowed(c, tx, order.WarehouseID)
+	if err != nil || !allowed {
+		tx.Rollback()
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Order belongs to another warehouse",
+		})
+	}
+
+	// Перед запуском в производство проверяем, что на складе заказа хватает материалов
+	warehouseID, err := resolveWarehouseID(tx, order.WarehouseID)
+	if err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Warehouse not found",
+		})
+	}
+
+	requirements, err := materialRequirements(tx, order.Products, &warehouseID)
+	if err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to check materials",
+		})
+	}
+
+	var shortages []MaterialRequirement
+	for _, requirement := range requirements {
+		if requirement.Shortage > 0 {
+			shortages = append(shortages, requirement)
+		}
+	}
+	if len(shortages) > 0 {
+		tx.Rollback()
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"success": false,
+			"message": "Not enough materials to start production",
+			"data":    shortages,
+		})
+	}
+
+	before := order
+	setOrderStatus(&order, "in_production")
 	if err := tx.Save(&order).Error; err != nil {
 		tx.Rollback()
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -266,6 +343,16 @@ func InProduction(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditUpdate, "order", order.ID, before, order)
+
+	if err := refreshOrderRollup(tx, order); err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to update sales rollup",
+		})
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -336,7 +423,17 @@ func OrderReady(c *fiber.Ctx) error {
 		})
 	}
 
-	order.Status = "ready"
+	allowed, err := warehouseAllowed(c, tx, order.WarehouseID)
+	if err != nil || !allowed {
+		tx.Rollback()
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Order belongs to another warehouse",
+		})
+	}
+
+	before := order
+	setOrderStatus(&order, "ready")
 	if err := tx.Save(&order).Error; err != nil {
 		tx.Rollback()
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -345,6 +442,16 @@ func OrderReady(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditUpdate, "order", order.ID, before, order)
+
+	if err := refreshOrderRollup(tx, order); err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to update sales rollup",
+		})
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -415,7 +522,17 @@ func Delivered(c *fiber.Ctx) error {
 		})
 	}
 
-	order.Status = "delivered"
+	allowed, err := warehouseAllowed(c, tx, order.WarehouseID)
+	if err != nil || !allowed {
+		tx.Rollback()
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"success": false,
+			"message": "Order belongs to another warehouse",
+		})
+	}
+
+	before := order
+	setOrderStatus(&order, "delivered")
 	if err := tx.Save(&order).Error; err != nil {
 		tx.Rollback()
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -424,6 +541,16 @@ func Delivered(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditUpdate, "order", order.ID, before, order)
+
+	if err := refreshOrderRollup(tx, order); err != nil {
+		tx.Rollback()
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to update sales rollup",
+		})
+	}
+
 	if err := tx.Commit().Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"success": false,
@@ -437,3 +564,27 @@ func Delivered(c *fiber.Ctx) error {
 		"data":    order,
 	})
 }
+
+// setOrderStatus меняет статус заказа и запоминает время перехода.
+func setOrderStatus(order *model.Order, status string) {
+	now := time.Now()
+	order.Status = status
+
+	switch status {
+	case "accepted":
+		order.AcceptedAt = &now
+	case "rejected":
+		order.RejectedAt = &now
+	case "in_production":
+		order.InProductionAt = &now
+	case "ready":
+		order.ReadyAt = &now
+	case "delivered":
+		order.DeliveredAt = &now
+	}
+}
+
+// refreshOrderRollup обновляет дневные итоги продаж, в которые входит заказ.
+func refreshOrderRollup(tx *gorm.DB, order model.Order) error {
+	return tasks.RefreshSalesRollup(tx, order.CreatedAt, order.SalespersonID, order.ClientID)
+}
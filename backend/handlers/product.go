@@ -24,6 +24,10 @@ type UpdateProductRequest struct {
 	Unit       *string  `json:"unit" validate:"omitempty,oneof=piece meter"`
 	Amount     *float64 `json:"amount" validate:"omitempty,gte=0"`
 	Image      *string  `json:"image" validate:"omitempty,min=5"`
+
+	CostPrice       *float64 `json:"costPrice" validate:"omitempty,gte=0"`
+	MinStock        *float64 `json:"minStock" validate:"omitempty,gte=0"`
+	ReorderQuantity *float64 `json:"reorderQuantity" validate:"omitempty,gte=0"`
 }
 type ProductStatistics struct {
 	ProductID        string  `json:"productId" example:"d6c9b3be-652f-45d4-8384-a5eab99f03a6"`
@@ -53,7 +57,7 @@ func GetAllProducts(c *fiber.Ctx) error {
 		filter = map[string]interface{}{"category_id": categoryId}
 	}
 
-	respons, err := utils.Paginate(database.DB.Preload("Category"), c, filter, &Products)
+	respons, err := utils.Paginate(database.DB.Preload("Category").Preload("Stocks.Warehouse"), c, filter, &Products)
 
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -92,7 +96,7 @@ func GetProductById(c *fiber.Ctx) error {
 	}
 
 	Product := new(model.Product)
-	err = db.Where("id = ?", id).Preload(clause.Associations).First(Product).Error
+	err = db.Where("id = ?", id).Preload(clause.Associations).Preload("Stocks.Warehouse").First(Product).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -153,7 +157,20 @@ func CreateProduct(c *fiber.Ctx) error {
 		})
 	}
 
+	// Начальный остаток приходуется на указанный склад, иначе — на основной
+	var target struct {
+		WarehouseID *guuid.UUID `json:"warehouseId"`
+	}
+	if err := c.BodyParser(&target); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid warehouseId format",
+		})
+	}
+
 	product.ID = guuid.New()
+	product.Stocks = nil
 
 	DB := database.DB
 	var category model.Category
@@ -173,7 +190,22 @@ func CreateProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	err = DB.Create(&product).Error
+	tx := DB.Begin()
+	defer tx.Rollback()
+
+	warehouseID, err := resolveWarehouseID(tx, target.WarehouseID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Warehouse not found",
+		})
+	}
+
+	amount := product.Amount
+	product.Amount = 0
+
+	err = tx.Create(&product).Error
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  500,
@@ -182,6 +214,25 @@ func CreateProduct(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := adjustStock(tx, warehouseID, product.ID, amount); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create product stock",
+		})
+	}
+	product.Amount = amount
+
+	recordAudit(c, tx, model.AuditCreate, "product", product.ID, nil, product)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"status":  201,
 		"success": true,
@@ -235,7 +286,12 @@ func UpdateProduct(c *fiber.Ctx) error {
 	var product model.Product
 	db := database.DB
 
-	if err := db.First(&product, "id = ?", id).Error; err != nil {
+	tx := db.Begin()
+	defer tx.Rollback()
+
+	// Строка товара блокируется до конца транзакции, чтобы сохранение не затёрло остаток и
+	// себестоимость, которые параллельно меняют движения склада и приходы
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"status":  404,
@@ -250,6 +306,8 @@ func UpdateProduct(c *fiber.Ctx) error {
 		})
 	}
 
+	before := product
+
 	if body.Name != nil {
 		product.Name = *body.Name
 	}
@@ -268,15 +326,21 @@ func UpdateProduct(c *fiber.Ctx) error {
 	if body.Unit != nil {
 		product.Unit = *body.Unit
 	}
-	if body.Amount != nil {
-		product.Amount = *body.Amount
-	}
 	if body.Image != nil {
 		product.Image = *body.Image
 	}
+	if body.CostPrice != nil {
+		product.CostPrice = *body.CostPrice
+	}
+	if body.MinStock != nil {
+		product.MinStock = *body.MinStock
+	}
+	if body.ReorderQuantity != nil {
+		product.ReorderQuantity = *body.ReorderQuantity
+	}
 
 	var category model.Category
-	err = db.First(&category, "id = ?", product.CategoryID).Error
+	err = tx.First(&category, "id = ?", product.CategoryID).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
@@ -292,7 +356,35 @@ func UpdateProduct(c *fiber.Ctx) error {
 		})
 	}
 
-	if err := db.Save(&product).Error; err != nil {
+	// Ручная корректировка общего количества применяется к остатку основного склада
+	if body.Amount != nil && *body.Amount != product.Amount {
+		warehouseID, err := defaultWarehouseID(tx)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Default warehouse not found",
+			})
+		}
+		if err := adjustStock(tx, warehouseID, product.ID, *body.Amount-product.Amount); err != nil {
+			var stockErr *insufficientStockError
+			if errors.As(err, &stockErr) {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"status":  422,
+					"success": false,
+					"message": stockErr.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update product stock",
+			})
+		}
+		product.Amount = *body.Amount
+	}
+
+	if err := tx.Omit("Stocks").Save(&product).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  500,
 			"success": false,
@@ -300,6 +392,16 @@ func UpdateProduct(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditUpdate, "product", product.ID, before, product)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  200,
 		"success": true,
@@ -331,6 +433,22 @@ func DeleteProduct(c *fiber.Ctx) error {
 	}
 
 	db := database.DB
+	var product model.Product
+	if err := db.First(&product, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Product not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
 	err = db.Where("id = ?", id).Delete(&model.Product{}).Error
 
 	if err != nil {
@@ -341,6 +459,8 @@ func DeleteProduct(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, db, model.AuditDelete, "product", product.ID, product, nil)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  200,
 		"success": true,
@@ -0,0 +1,532 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"fmt"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreatePurchaseOrder Создать заказ поставщику
+//
+//	@Summary		Создать заказ поставщику
+//	@Description	Создаёт заказ поставщику на склад (по умолчанию — основной). Закупочные цены сохраняются в истории цен поставщика.
+//	@Tags			Purchases
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			purchaseOrder	body		model.CreatePurchaseOrderRequest	true	"Данные заказа поставщику"
+//	@Success		201				{object}	model.PurchaseOrder					"Заказ создан"
+//	@Failure		400				{object}	APIError							"Некорректный запрос"
+//	@Failure		404				{object}	APIError							"Поставщик, склад или продукт не найден"
+//	@Failure		422				{object}	APIError							"Ошибка валидации данных"
+//	@Failure		500				{object}	APIError							"Ошибка сервера"
+//	@Router			/purchase-orders [post]
+func CreatePurchaseOrder(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.CreatePurchaseOrderRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var supplier model.Supplier
+	if err := tx.First(&supplier, "id = ?", body.SupplierID).Error; err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Supplier not found",
+		})
+	}
+
+	warehouseID, err := resolveWarehouseID(tx, body.WarehouseID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Warehouse not found",
+		})
+	}
+
+	purchaseOrder := model.PurchaseOrder{
+		ID:          guuid.New(),
+		SupplierID:  supplier.ID,
+		WarehouseID: warehouseID,
+		Status:      model.PurchaseOrdered,
+		ExpectedAt:  body.ExpectedAt,
+		Note:        body.Note,
+		CreatedByID: user.ID,
+	}
+
+	seen := make(map[guuid.UUID]bool, len(body.Items))
+	for _, item := range body.Items {
+		if seen[item.ProductID] {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"status":  422,
+				"success": false,
+				"message": fmt.Sprintf("Product %s is listed more than once", item.ProductID),
+			})
+		}
+		seen[item.ProductID] = true
+
+		var product model.Product
+		if err := tx.First(&product, "id = ?", item.ProductID).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Product not found",
+			})
+		}
+
+		totalCost := item.Quantity * item.UnitCost
+		purchaseOrder.TotalCost += totalCost
+		purchaseOrder.Items = append(purchaseOrder.Items, model.PurchaseOrderItem{
+			ID:              guuid.New(),
+			PurchaseOrderID: purchaseOrder.ID,
+			ProductID:       product.ID,
+			Quantity:        item.Quantity,
+			UnitCost:        item.UnitCost,
+			TotalCost:       totalCost,
+		})
+	}
+
+	if err := tx.Create(&purchaseOrder).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to create purchase order",
+		})
+	}
+
+	for _, item := range purchaseOrder.Items {
+		if err := trackSupplierPrice(tx, supplier.ID, item.ProductID, item.UnitCost, &purchaseOrder.ID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to save supplier price",
+			})
+		}
+	}
+
+	recordAudit(c, tx, model.AuditCreate, "purchase_order", purchaseOrder.ID, nil, purchaseOrder)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Purchase order created successfully",
+		"data":    purchaseOrder,
+	})
+}
+
+// GetPurchaseOrders Список заказов поставщикам
+//
+//	@Summary		Список заказов поставщикам
+//	@Tags			Purchases
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string				false	"Статус (ordered, partially_received, received, cancelled)"
+//	@Param			supplierId	query		string				false	"ID поставщика"
+//	@Param			page		query		int					false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int					false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.PurchaseOrder	"Заказы с информацией о пагинации"
+//	@Failure		400			{object}	APIError			"Неверный формат ID поставщика"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/purchase-orders [get]
+func GetPurchaseOrders(c *fiber.Ctx) error {
+	purchaseOrders := []model.PurchaseOrder{}
+	db := database.DB.Preload("Supplier", unscoped).Preload("Warehouse").Preload("Items.Product", unscoped).Order("created_at desc")
+
+	if status := c.Query("status"); status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if supplierID := c.Query("supplierId"); supplierID != "" {
+		id, err := guuid.Parse(supplierID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid supplierId format",
+			})
+		}
+		db = db.Where("supplier_id = ?", id)
+	}
+
+	response, err := utils.Paginate(db, c, nil, &purchaseOrders)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve purchase orders",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetPurchaseOrderByID Получить заказ поставщику
+//
+//	@Summary		Получить заказ поставщику
+//	@Description	Возвращает заказ поставщику со строками и всеми приёмками
+//	@Tags			Purchases
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string				true	"ID заказа поставщику"
+//	@Success		200	{object}	model.PurchaseOrder	"Заказ поставщику"
+//	@Failure		400	{object}	APIError			"Неверный формат ID"
+//	@Failure		404	{object}	APIError			"Заказ не найден"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/purchase-orders/{id} [get]
+func GetPurchaseOrderByID(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Purchase order ID",
+		})
+	}
+
+	var purchaseOrder model.PurchaseOrder
+	err = database.DB.Preload("Supplier", unscoped).
+		Preload("Warehouse").
+		Preload("Items.Product", unscoped).
+		Preload("Receipts.Items").
+		First(&purchaseOrder, "id = ?", id).Error
+	if err != nil {
+		return purchaseOrderErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    purchaseOrder,
+	})
+}
+
+// ReceivePurchaseOrder Принять товар по заказу поставщику
+//
+//	@Summary		Принять товар по заказу поставщику
+//	@Description	Приходует полученное количество на склад заказа и пересчитывает себестоимость продуктов по средневзвешенной цене. Допускается частичная приёмка.
+//	@Tags			Purchases
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"ID заказа поставщику"
+//	@Param			receipt	body		model.ReceivePurchaseOrderRequest	true	"Полученные продукты"
+//	@Success		200		{object}	model.PurchaseOrder					"Товар принят"
+//	@Failure		400		{object}	APIError							"Заказ закрыт или получено больше заказанного"
+//	@Failure		403		{object}	APIError							"Склад недоступен менеджеру"
+//	@Failure		404		{object}	APIError							"Заказ не найден"
+//	@Failure		422		{object}	APIError							"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError							"Ошибка сервера"
+//	@Router			/purchase-orders/{id}/receive [post]
+func ReceivePurchaseOrder(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Purchase order ID",
+		})
+	}
+
+	body := new(model.ReceivePurchaseOrderRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var purchaseOrder model.PurchaseOrder
+	err = tx.Preload("Items").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&purchaseOrder, "id = ?", id).Error
+	if err != nil {
+		return purchaseOrderErrorResponse(c, err)
+	}
+
+	if purchaseOrder.Status != model.PurchaseOrdered && purchaseOrder.Status != model.PurchasePartiallyReceived {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Purchase order is already closed",
+		})
+	}
+
+	allowed, err := warehouseAllowed(c, tx, &purchaseOrder.WarehouseID)
+	if err != nil || !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "This purchase order is delivered to another warehouse",
+		})
+	}
+
+	items := make(map[guuid.UUID]*model.PurchaseOrderItem, len(purchaseOrder.Items))
+	for i := range purchaseOrder.Items {
+		items[purchaseOrder.Items[i].ProductID] = &purchaseOrder.Items[i]
+	}
+
+	before := purchaseOrder
+	receipt := model.PurchaseReceipt{
+		ID:              guuid.New(),
+		PurchaseOrderID: purchaseOrder.ID,
+		Note:            body.Note,
+		ReceivedByID:    user.ID,
+	}
+
+	for _, received := range body.Items {
+		item, ok := items[received.ProductID]
+		if !ok {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": fmt.Sprintf("Product %s is not part of this purchase order", received.ProductID),
+			})
+		}
+
+		remaining := item.Quantity - item.ReceivedQuantity
+		if received.Quantity > remaining {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": fmt.Sprintf("Only %g of product %s is still expected", remaining, received.ProductID),
+			})
+		}
+
+		if err := applyIncomingCost(tx, item.ProductID, received.Quantity, item.UnitCost); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update product cost",
+			})
+		}
+
+		if err := adjustStock(tx, purchaseOrder.WarehouseID, item.ProductID, received.Quantity); err != nil {
+			return stockErrorResponse(c, err)
+		}
+
+		item.ReceivedQuantity += received.Quantity
+		if err := tx.Model(item).Update("received_quantity", item.ReceivedQuantity).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update purchase order",
+			})
+		}
+
+		receipt.Items = append(receipt.Items, model.PurchaseReceiptItem{
+			ID:                  guuid.New(),
+			ReceiptID:           receipt.ID,
+			PurchaseOrderItemID: item.ID,
+			ProductID:           item.ProductID,
+			Quantity:            received.Quantity,
+			UnitCost:            item.UnitCost,
+		})
+	}
+
+	if err := tx.Create(&receipt).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to save receipt",
+		})
+	}
+
+	purchaseOrder.Status = model.PurchaseReceived
+	for _, item := range purchaseOrder.Items {
+		if item.ReceivedQuantity < item.Quantity {
+			purchaseOrder.Status = model.PurchasePartiallyReceived
+			break
+		}
+	}
+
+	if err := tx.Model(&purchaseOrder).Update("status", purchaseOrder.Status).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update purchase order",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditUpdate, "purchase_order", purchaseOrder.ID, before, purchaseOrder)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	purchaseOrder.Receipts = []model.PurchaseReceipt{receipt}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Goods received successfully",
+		"data":    purchaseOrder,
+	})
+}
+
+// CancelPurchaseOrder Отменить заказ поставщику
+//
+//	@Summary		Отменить заказ поставщику
+//	@Description	Закрывает заказ поставщику. Уже принятый товар остаётся на складе.
+//	@Tags			Purchases
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string				true	"ID заказа поставщику"
+//	@Success		200	{object}	model.PurchaseOrder	"Заказ отменён"
+//	@Failure		400	{object}	APIError			"Заказ уже закрыт"
+//	@Failure		404	{object}	APIError			"Заказ не найден"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/purchase-orders/{id}/cancel [post]
+func CancelPurchaseOrder(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Purchase order ID",
+		})
+	}
+
+	db := database.DB
+	var purchaseOrder model.PurchaseOrder
+	if err := db.First(&purchaseOrder, "id = ?", id).Error; err != nil {
+		return purchaseOrderErrorResponse(c, err)
+	}
+
+	if purchaseOrder.Status != model.PurchaseOrdered && purchaseOrder.Status != model.PurchasePartiallyReceived {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Purchase order is already closed",
+		})
+	}
+
+	before := purchaseOrder
+	purchaseOrder.Status = model.PurchaseCancelled
+	if err := db.Model(&purchaseOrder).Update("status", purchaseOrder.Status).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to cancel purchase order",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "purchase_order", purchaseOrder.ID, before, purchaseOrder)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Purchase order cancelled",
+		"data":    purchaseOrder,
+	})
+}
+
+// trackSupplierPrice добавляет запись в историю цен, если цена поставщика на продукт изменилась.
+func trackSupplierPrice(tx *gorm.DB, supplierID, productID guuid.UUID, price float64, purchaseOrderID *guuid.UUID) error {
+	var last model.SupplierPrice
+	err := tx.Where("supplier_id = ? AND product_id = ?", supplierID, productID).
+		Order("created_at desc").
+		First(&last).Error
+	if err == nil && last.Price == price {
+		return nil
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return tx.Create(&model.SupplierPrice{
+		ID:              guuid.New(),
+		SupplierID:      supplierID,
+		ProductID:       productID,
+		Price:           price,
+		PurchaseOrderID: purchaseOrderID,
+	}).Error
+}
+
+// applyIncomingCost пересчитывает себестоимость продукта по средневзвешенной цене с учётом
+// остатка до поступления (закупка или выпуск продукции). Вызывается до изменения остатка.
+func applyIncomingCost(tx *gorm.DB, productID guuid.UUID, quantity, unitCost float64) error {
+	var product model.Product
+	err := tx.Unscoped().
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "amount", "cost_price").
+		First(&product, "id = ?", productID).Error
+	if err != nil {
+		return err
+	}
+
+	onHand := product.Amount
+	if onHand < 0 {
+		onHand = 0
+	}
+	costPrice := (onHand*product.CostPrice + quantity*unitCost) / (onHand + quantity)
+
+	return tx.Model(&model.Product{}).Where("id = ?", productID).UpdateColumn("cost_price", costPrice).Error
+}
+
+func purchaseOrderErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Purchase order not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
@@ -0,0 +1,438 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RunReport Выполнить отчёт
+//
+//	@Summary		Выполнить отчёт
+//	@Description	Выполняет определение отчёта без сохранения. Наборы данных: orders, order_items, clients, products. Группировки — поля набора (для дат — с шагом, например createdAt:month), показатели — count, count_distinct, sum, avg, min, max. Период применяется к дате создания записи.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		BearerAuth
+//	@Param			definition	body		model.ReportDefinition	true	"Определение отчёта"
+//	@Param			format		query		string					false	"Формат (json, csv, xlsx)"	default(json)
+//	@Success		200			{object}	ReportResult			"Результат отчёта"
+//	@Failure		400			{object}	APIError				"Неверное определение отчёта"
+//	@Failure		422			{object}	APIError				"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError				"Ошибка сервера"
+//	@Router			/reports/run [post]
+func RunReport(c *fiber.Ctx) error {
+	def := new(model.ReportDefinition)
+	if err := c.BodyParser(def); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(def); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	return sendReport(c, *def, "report")
+}
+
+// CreateSavedReport Сохранить отчёт
+//
+//	@Summary		Сохранить отчёт
+//	@Description	Сохраняет определение отчёта для текущего пользователя. Определение проверяется так же, как при выполнении.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			report	body		model.SaveReportRequest	true	"Отчёт"
+//	@Success		201		{object}	model.SavedReport		"Отчёт сохранён"
+//	@Failure		400		{object}	APIError				"Неверное определение отчёта"
+//	@Failure		422		{object}	APIError				"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError				"Ошибка сервера"
+//	@Router			/reports [post]
+func CreateSavedReport(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.SaveReportRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	// Определение проверяется до сохранения, чтобы не хранить отчёты, которые нельзя выполнить
+	if _, err := buildReportQuery(body.Definition); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	report := model.SavedReport{
+		ID:          guuid.New(),
+		Name:        body.Name,
+		Description: body.Description,
+		OwnerID:     user.ID,
+		Shared:      body.Shared,
+		Definition:  body.Definition,
+	}
+
+	db := database.DB
+	if err := db.Create(&report).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not save report",
+		})
+	}
+
+	recordAudit(c, db, model.AuditCreate, "saved_report", report.ID, nil, report)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Report saved successfully",
+		"data":    report,
+	})
+}
+
+// GetSavedReports Получить сохранённые отчёты
+//
+//	@Summary		Получить сохранённые отчёты
+//	@Description	Возвращает отчёты текущего пользователя и общие отчёты других пользователей
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		model.SavedReport	"Список отчётов"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/reports [get]
+func GetSavedReports(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	var reports []model.SavedReport
+	err := database.DB.Preload("Owner").
+		Where("owner_id = ? OR shared", user.ID).
+		Order("name").
+		Find(&reports).Error
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve reports",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    reports,
+	})
+}
+
+// GetSavedReportByID Получить сохранённый отчёт
+//
+//	@Summary		Получить сохранённый отчёт
+//	@Description	Возвращает собственный или общий отчёт по ID; администратору доступны все отчёты
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string				true	"ID отчёта"
+//	@Success		200	{object}	model.SavedReport	"Отчёт"
+//	@Failure		400	{object}	APIError			"Неверный формат ID"
+//	@Failure		404	{object}	APIError			"Отчёт не найден"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/reports/{id} [get]
+func GetSavedReportByID(c *fiber.Ctx) error {
+	report, err := findSavedReport(c, database.DB, false)
+	if err != nil {
+		return savedReportErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    report,
+	})
+}
+
+// UpdateSavedReport Обновить сохранённый отчёт
+//
+//	@Summary		Обновить сохранённый отчёт
+//	@Description	Заменяет название, описание, доступ и определение отчёта. Изменять отчёт может только его владелец.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"ID отчёта"
+//	@Param			report	body		model.SaveReportRequest	true	"Отчёт"
+//	@Success		200		{object}	model.SavedReport		"Отчёт обновлён"
+//	@Failure		400		{object}	APIError				"Неверное определение отчёта"
+//	@Failure		403		{object}	APIError				"Отчёт принадлежит другому пользователю"
+//	@Failure		404		{object}	APIError				"Отчёт не найден"
+//	@Failure		422		{object}	APIError				"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError				"Ошибка сервера"
+//	@Router			/reports/{id} [put]
+func UpdateSavedReport(c *fiber.Ctx) error {
+	db := database.DB
+
+	report, err := findSavedReport(c, db, true)
+	if err != nil {
+		return savedReportErrorResponse(c, err)
+	}
+
+	body := new(model.SaveReportRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	// Определение проверяется до сохранения, чтобы не хранить отчёты, которые нельзя выполнить
+	if _, err := buildReportQuery(body.Definition); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	before := report
+	report.Name = body.Name
+	report.Description = body.Description
+	report.Shared = body.Shared
+	report.Definition = body.Definition
+
+	if err := db.Save(&report).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not update report",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "saved_report", report.ID, before, report)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Report updated successfully",
+		"data":    report,
+	})
+}
+
+// DeleteSavedReport Удалить сохранённый отчёт
+//
+//	@Summary		Удалить сохранённый отчёт
+//	@Description	Удаляет отчёт. Удалить отчёт может его владелец или администратор.
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string		true	"ID отчёта"
+//	@Success		200	{object}	model.SavedReport	"Отчёт удалён"
+//	@Failure		400	{object}	APIError			"Неверный формат ID"
+//	@Failure		403	{object}	APIError			"Отчёт принадлежит другому пользователю"
+//	@Failure		404	{object}	APIError			"Отчёт не найден"
+//	@Failure		500	{object}	APIError	"Ошибка сервера"
+//	@Router			/reports/{id} [delete]
+func DeleteSavedReport(c *fiber.Ctx) error {
+	db := database.DB
+	user := c.Locals("user").(*auth.Claims)
+
+	report, err := findSavedReport(c, db, user.Role != model.AdminRole)
+	if err != nil {
+		return savedReportErrorResponse(c, err)
+	}
+
+	if err := db.Delete(&report).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not delete report",
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "saved_report", report.ID, report, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Report deleted successfully",
+		"data":    report,
+	})
+}
+
+// RunSavedReport Выполнить сохранённый отчёт
+//
+//	@Summary		Выполнить сохранённый отчёт
+//	@Description	Выполняет собственный или общий отчёт. Параметры period, from и to заменяют период из определения.
+//	@Tags			Reports
+//	@Produce		json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		BearerAuth
+//	@Param			id		path		string			true	"ID отчёта"
+//	@Param			format	query		string			false	"Формат (json, csv, xlsx)"	default(json)
+//	@Param			period	query		string			false	"Календарный период (day, week, month, quarter, year)"
+//	@Param			from	query		string			false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to		query		string			false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Success		200		{object}	ReportResult	"Результат отчёта"
+//	@Failure		400		{object}	APIError		"Неверные параметры запроса"
+//	@Failure		404		{object}	APIError		"Отчёт не найден"
+//	@Failure		500		{object}	APIError		"Ошибка сервера"
+//	@Router			/reports/{id}/run [get]
+func RunSavedReport(c *fiber.Ctx) error {
+	report, err := findSavedReport(c, database.DB, false)
+	if err != nil {
+		return savedReportErrorResponse(c, err)
+	}
+
+	def := report.Definition
+	if period, from, to := c.Query("period"), c.Query("from"), c.Query("to"); period != "" || from != "" || to != "" {
+		def.Period, def.Rolling, def.From, def.To = period, false, from, to
+	}
+
+	return sendReport(c, def, "report")
+}
+
+// sendReport выполняет отчёт и отдаёт его в формате из параметра format.
+func sendReport(c *fiber.Ctx, def model.ReportDefinition, filename string) error {
+	format := c.Query("format", "json")
+	if format != "json" && format != "csv" && format != "xlsx" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid format. Use json, csv or xlsx",
+		})
+	}
+
+	result, err := runReport(database.DB, def)
+	if err != nil {
+		var definitionErr *reportDefinitionError
+		if errors.As(err, &definitionErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to run report",
+		})
+	}
+
+	switch format {
+	case "csv":
+		return utils.ExportRowsToCSV(c, result.Columns, result.values, filename+".csv")
+	case "xlsx":
+		return utils.ExportRowsToExcel(c, result.Columns, result.values, filename+".xlsx")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    result,
+	})
+}
+
+var (
+	errInvalidSavedReportID = errors.New("invalid saved report id")
+	errSavedReportNotOwned  = errors.New("saved report belongs to another user")
+)
+
+// findSavedReport ищет отчёт, доступный текущему пользователю: свой, общий или, для администратора,
+// любой. Если owned, подходит только свой отчёт.
+func findSavedReport(c *fiber.Ctx, db *gorm.DB, owned bool) (model.SavedReport, error) {
+	user := c.Locals("user").(*auth.Claims)
+	var report model.SavedReport
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return report, errInvalidSavedReportID
+	}
+
+	query := db.Where("id = ?", id)
+	switch {
+	case owned:
+		query = query.Where("owner_id = ?", user.ID)
+	case user.Role != model.AdminRole:
+		query = query.Where("owner_id = ? OR shared", user.ID)
+	}
+	err = query.First(&report).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) && owned {
+		// Общий отчёт другого пользователя виден, но изменять его нельзя
+		var count int64
+		if err := db.Model(&model.SavedReport{}).Where("id = ? AND shared", id).Count(&count).Error; err != nil {
+			return report, err
+		}
+		if count > 0 {
+			return report, errSavedReportNotOwned
+		}
+	}
+	return report, err
+}
+
+func savedReportErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidSavedReportID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Report ID",
+		})
+	}
+	if errors.Is(err, errSavedReportNotOwned) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "Only the owner can change this report",
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Report not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
@@ -0,0 +1,531 @@
+package handlers
+
+import (
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Максимальное число строк в результате отчёта
+const maxReportRows = 10000
+
+const (
+	reportText   = "text"
+	reportNumber = "number"
+	reportDate   = "date"
+	reportUUID   = "uuid"
+)
+
+// reportField — поле набора данных: SQL-выражение и тип значения.
+// В запрос попадают только выражения из этого списка, пользовательские строки — только аргументами.
+type reportField struct {
+	Expr string
+	Kind string
+}
+
+type reportDataset struct {
+	Source string
+	// Условие, которое всегда добавляется к запросу
+	Scope string
+	// Поле, по которому применяется период отчёта
+	DateField string
+	Fields    map[string]reportField
+}
+
+var reportDatasets = map[string]reportDataset{
+	"orders": {
+		Source: `orders
+			JOIN clients ON clients.id = orders.client_id
+			LEFT JOIN users ON users.id = orders.salesperson_id
+			LEFT JOIN warehouses ON warehouses.id = orders.warehouse_id`,
+		DateField: "createdAt",
+		Fields: map[string]reportField{
+			"id":            {"orders.id", reportUUID},
+			"status":        {"orders.status", reportText},
+			"paymentMethod": {"orders.payment_method", reportText},
+			"totalPrice":    {"orders.total_price", reportNumber},
+			"createdAt":     {"orders.created_at", reportDate},
+			"acceptedAt":    {"orders.accepted_at", reportDate},
+			"deliveredAt":   {"orders.delivered_at", reportDate},
+			"clientId":      {"orders.client_id", reportUUID},
+			"clientName":    {"CONCAT(clients.name, ' ', clients.surname)", reportText},
+			"sellerId":      {"orders.salesperson_id", reportUUID},
+			"sellerName":    {"users.username", reportText},
+			"warehouseId":   {"orders.warehouse_id", reportUUID},
+			"warehouseName": {"warehouses.name", reportText},
+		},
+	},
+	"order_items": {
+		Source: `order_items
+			JOIN orders ON orders.id = order_items.order_id
+			JOIN clients ON clients.id = orders.client_id
+			LEFT JOIN users ON users.id = orders.salesperson_id
+			LEFT JOIN products ON products.id = order_items.product_id
+			LEFT JOIN categories ON categories.id = products.category_id`,
+		DateField: "createdAt",
+		Fields: map[string]reportField{
+			"orderId":       {"order_items.order_id", reportUUID},
+			"productId":     {"order_items.product_id", reportUUID},
+			"productName":   {"products.name", reportText},
+			"unit":          {"products.unit", reportText},
+			"categoryId":    {"products.category_id", reportUUID},
+			"categoryName":  {"categories.name", reportText},
+			"quantity":      {"order_items.quantity", reportNumber},
+			"totalPrice":    {"order_items.total_price", reportNumber},
+			"unitCost":      {"order_items.unit_cost", reportNumber},
+			"totalCost":     {"order_items.total_cost", reportNumber},
+			"margin":        {"order_items.total_price - order_items.total_cost", reportNumber},
+			"status":        {"orders.status", reportText},
+			"paymentMethod": {"orders.payment_method", reportText},
+			"createdAt":     {"orders.created_at", reportDate},
+			"clientId":      {"orders.client_id", reportUUID},
+			"clientName":    {"CONCAT(clients.name, ' ', clients.surname)", reportText},
+			"sellerId":      {"orders.salesperson_id", reportUUID},
+			"sellerName":    {"users.username", reportText},
+			"warehouseId":   {"orders.warehouse_id", reportUUID},
+		},
+	},
+	"clients": {
+		Source: `clients
+			LEFT JOIN users ON users.id = clients.salesperson_id`,
+		DateField: "createdAt",
+		Fields: map[string]reportField{
+			"id":         {"clients.id", reportUUID},
+			"name":       {"CONCAT(clients.name, ' ', clients.surname)", reportText},
+			"address":    {"clients.address", reportText},
+			"balance":    {"clients.balance", reportNumber},
+			"sellerId":   {"clients.salesperson_id", reportUUID},
+			"sellerName": {"users.username", reportText},
+			"createdAt":  {"clients.created_at", reportDate},
+		},
+	},
+	"products": {
+		Source: `products
+			LEFT JOIN categories ON categories.id = products.category_id`,
+		Scope:     "products.deleted_at IS NULL",
+		DateField: "createdAt",
+		Fields: map[string]reportField{
+			"id":           {"products.id", reportUUID},
+			"name":         {"products.name", reportText},
+			"categoryId":   {"products.category_id", reportUUID},
+			"categoryName": {"categories.name", reportText},
+			"unit":         {"products.unit", reportText},
+			"price":        {"products.price", reportNumber},
+			"costPrice":    {"products.cost_price", reportNumber},
+			"amount":       {"products.amount", reportNumber},
+			"minStock":     {"products.min_stock", reportNumber},
+			"createdAt":    {"products.created_at", reportDate},
+		},
+	},
+}
+
+// Форматы подписей для группировки по дате
+var reportDateFormats = map[string]string{
+	"day":     "YYYY-MM-DD",
+	"week":    "YYYY-MM-DD",
+	"month":   "YYYY-MM",
+	"quarter": `YYYY-"Q"Q`,
+	"year":    "YYYY",
+}
+
+// ReportResult — результат отчёта. Columns задаёт порядок колонок, Rows — строки по ключам колонок.
+type ReportResult struct {
+	Columns []string                 `json:"columns"`
+	Rows    []map[string]interface{} `json:"rows"`
+	values  [][]interface{}
+}
+
+// reportQuery — собранный SQL-запрос отчёта.
+type reportQuery struct {
+	SQL     string
+	Args    []interface{}
+	Columns []string
+}
+
+// buildReportQuery собирает запрос по определению отчёта. Поля, агрегаты и операторы
+// проверяются по белому списку набора данных, значения фильтров передаются аргументами.
+func buildReportQuery(def model.ReportDefinition) (reportQuery, error) {
+	var query reportQuery
+
+	dataset, ok := reportDatasets[def.Dataset]
+	if !ok {
+		return query, fmt.Errorf("unknown dataset %q", def.Dataset)
+	}
+	if len(def.Measures) == 0 {
+		return query, errors.New("at least one measure is required")
+	}
+
+	location := utils.BusinessLocation().String()
+	var selects, where []string
+	var selectArgs, whereArgs []interface{}
+	seen := map[string]bool{}
+
+	addColumn := func(key, expr string, args ...interface{}) error {
+		if seen[key] {
+			return fmt.Errorf("duplicate column %q", key)
+		}
+		seen[key] = true
+		query.Columns = append(query.Columns, key)
+		selects = append(selects, expr)
+		selectArgs = append(selectArgs, args...)
+		return nil
+	}
+
+	for _, dimension := range def.Dimensions {
+		name, granularity, _ := strings.Cut(dimension, ":")
+		field, ok := dataset.Fields[name]
+		if !ok {
+			return query, fmt.Errorf("unknown field %q in dataset %s", name, def.Dataset)
+		}
+
+		var err error
+		switch {
+		case field.Kind == reportDate:
+			if granularity == "" {
+				granularity = "day"
+			}
+			format, ok := reportDateFormats[granularity]
+			if !ok {
+				return query, fmt.Errorf("invalid granularity %q. Use day, week, month, quarter or year", granularity)
+			}
+			err = addColumn(dimension, fmt.Sprintf("to_char(date_trunc('%s', %s AT TIME ZONE ?), '%s')", granularity, field.Expr, format), location)
+		case granularity != "":
+			return query, fmt.Errorf("granularity is only allowed for date fields, got %q", dimension)
+		case field.Kind == reportUUID:
+			err = addColumn(dimension, field.Expr+"::text")
+		default:
+			err = addColumn(dimension, field.Expr)
+		}
+		if err != nil {
+			return query, err
+		}
+	}
+
+	for _, measure := range def.Measures {
+		expr, args, err := reportMeasureExpr(dataset, measure, location)
+		if err != nil {
+			return query, err
+		}
+		if err := addColumn(reportMeasureKey(measure), expr, args...); err != nil {
+			return query, err
+		}
+	}
+
+	if dataset.Scope != "" {
+		where = append(where, dataset.Scope)
+	}
+	for _, filter := range def.Filters {
+		field, ok := dataset.Fields[filter.Field]
+		if !ok {
+			return query, fmt.Errorf("unknown field %q in dataset %s", filter.Field, def.Dataset)
+		}
+		condition, args, err := reportFilterCondition(field, filter)
+		if err != nil {
+			return query, err
+		}
+		where = append(where, condition)
+		whereArgs = append(whereArgs, args...)
+	}
+
+	from, to, err := reportPeriod(def)
+	if err != nil {
+		return query, err
+	}
+	dateField := dataset.Fields[dataset.DateField]
+	if from != nil {
+		where = append(where, dateField.Expr+" >= ?")
+		whereArgs = append(whereArgs, *from)
+	}
+	if to != nil {
+		where = append(where, dateField.Expr+" < ?")
+		whereArgs = append(whereArgs, *to)
+	}
+
+	var sql strings.Builder
+	sql.WriteString("SELECT ")
+	for i, expr := range selects {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString(expr)
+	}
+	sql.WriteString(" FROM ")
+	sql.WriteString(dataset.Source)
+	if len(where) > 0 {
+		sql.WriteString(" WHERE ")
+		sql.WriteString(strings.Join(where, " AND "))
+	}
+
+	// Группировка и сортировка — по номерам колонок, чтобы не повторять выражения с аргументами
+	positions := map[string]int{}
+	for i, key := range query.Columns {
+		positions[key] = i + 1
+	}
+	if len(def.Dimensions) > 0 {
+		groups := make([]string, len(def.Dimensions))
+		for i := range def.Dimensions {
+			groups[i] = fmt.Sprint(i + 1)
+		}
+		sql.WriteString(" GROUP BY ")
+		sql.WriteString(strings.Join(groups, ", "))
+	}
+
+	if def.SortBy != "" {
+		position, ok := positions[def.SortBy]
+		if !ok {
+			return query, fmt.Errorf("sortBy must be one of the report columns, got %q", def.SortBy)
+		}
+		direction := "ASC"
+		if def.SortDesc {
+			direction = "DESC"
+		}
+		fmt.Fprintf(&sql, " ORDER BY %d %s NULLS LAST", position, direction)
+	} else if len(def.Dimensions) > 0 {
+		sql.WriteString(" ORDER BY 1")
+	}
+
+	limit := maxReportRows
+	if def.Limit > 0 && def.Limit < limit {
+		limit = def.Limit
+	}
+	fmt.Fprintf(&sql, " LIMIT %d", limit)
+
+	query.SQL = sql.String()
+	query.Args = append(selectArgs, whereArgs...)
+	return query, nil
+}
+
+func reportMeasureKey(measure model.ReportMeasure) string {
+	if measure.Field == "" {
+		return measure.Aggregate
+	}
+	return measure.Aggregate + ":" + measure.Field
+}
+
+func reportMeasureExpr(dataset reportDataset, measure model.ReportMeasure, location string) (string, []interface{}, error) {
+	if measure.Field == "" {
+		if measure.Aggregate != "count" {
+			return "", nil, fmt.Errorf("aggregate %s requires a field", measure.Aggregate)
+		}
+		return "COUNT(*)", nil, nil
+	}
+
+	field, ok := dataset.Fields[measure.Field]
+	if !ok {
+		return "", nil, fmt.Errorf("unknown field %q", measure.Field)
+	}
+
+	switch measure.Aggregate {
+	case "count":
+		return fmt.Sprintf("COUNT(%s)", field.Expr), nil, nil
+	case "count_distinct":
+		return fmt.Sprintf("COUNT(DISTINCT %s)", field.Expr), nil, nil
+	case "sum", "avg":
+		if field.Kind != reportNumber {
+			return "", nil, fmt.Errorf("aggregate %s requires a numeric field, got %q", measure.Aggregate, measure.Field)
+		}
+		return fmt.Sprintf("COALESCE(%s(%s), 0)::float8", strings.ToUpper(measure.Aggregate), field.Expr), nil, nil
+	case "min", "max":
+		switch field.Kind {
+		case reportNumber:
+			return fmt.Sprintf("%s(%s)::float8", strings.ToUpper(measure.Aggregate), field.Expr), nil, nil
+		case reportDate:
+			return fmt.Sprintf("to_char(%s(%s AT TIME ZONE ?), 'YYYY-MM-DD HH24:MI')", strings.ToUpper(measure.Aggregate), field.Expr),
+				[]interface{}{location}, nil
+		case reportText:
+			return fmt.Sprintf("%s(%s)", strings.ToUpper(measure.Aggregate), field.Expr), nil, nil
+		}
+		return "", nil, fmt.Errorf("aggregate %s is not supported for %q", measure.Aggregate, measure.Field)
+	}
+	return "", nil, fmt.Errorf("unknown aggregate %q", measure.Aggregate)
+}
+
+// Операторы, допустимые для каждого типа поля
+var reportOperators = map[string]map[string]bool{
+	reportText:   {"eq": true, "ne": true, "in": true, "contains": true},
+	reportNumber: {"eq": true, "ne": true, "gt": true, "gte": true, "lt": true, "lte": true, "in": true},
+	reportDate:   {"eq": true, "gt": true, "gte": true, "lt": true, "lte": true},
+	reportUUID:   {"eq": true, "ne": true, "in": true},
+}
+
+var reportComparisons = map[string]string{"eq": "=", "gt": ">", "gte": ">=", "lt": "<", "lte": "<="}
+
+func reportFilterCondition(field reportField, filter model.ReportFilter) (string, []interface{}, error) {
+	if !reportOperators[field.Kind][filter.Operator] {
+		return "", nil, fmt.Errorf("operator %s is not supported for field %q", filter.Operator, filter.Field)
+	}
+
+	if filter.Operator == "in" {
+		raw, ok := filter.Value.([]interface{})
+		if !ok || len(raw) == 0 {
+			return "", nil, fmt.Errorf("filter on %q: in requires a non-empty array", filter.Field)
+		}
+		values := make([]interface{}, len(raw))
+		for i, item := range raw {
+			value, err := reportFilterValue(field, filter.Field, item)
+			if err != nil {
+				return "", nil, err
+			}
+			values[i] = value
+		}
+		return field.Expr + " IN ?", []interface{}{values}, nil
+	}
+
+	value, err := reportFilterValue(field, filter.Field, filter.Value)
+	if err != nil {
+		return "", nil, err
+	}
+
+	switch {
+	case filter.Operator == "contains":
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value.(string)) + "%"
+		return field.Expr + " ILIKE ?", []interface{}{pattern}, nil
+	case filter.Operator == "ne":
+		return field.Expr + " IS DISTINCT FROM ?", []interface{}{value}, nil
+	case field.Kind == reportDate:
+		// Дата в фильтре — целый день по часовому поясу бизнеса
+		day := value.(time.Time)
+		next := day.AddDate(0, 0, 1)
+		switch filter.Operator {
+		case "eq":
+			return field.Expr + " >= ? AND " + field.Expr + " < ?", []interface{}{day, next}, nil
+		case "gt":
+			return field.Expr + " >= ?", []interface{}{next}, nil
+		case "gte":
+			return field.Expr + " >= ?", []interface{}{day}, nil
+		case "lt":
+			return field.Expr + " < ?", []interface{}{day}, nil
+		default:
+			return field.Expr + " < ?", []interface{}{next}, nil
+		}
+	}
+	return fmt.Sprintf("%s %s ?", field.Expr, reportComparisons[filter.Operator]), []interface{}{value}, nil
+}
+
+func reportFilterValue(field reportField, name string, raw interface{}) (interface{}, error) {
+	switch field.Kind {
+	case reportNumber:
+		if value, ok := raw.(float64); ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("filter on %q expects a number", name)
+	case reportDate:
+		if value, ok := raw.(string); ok {
+			day, err := time.ParseInLocation("2006-01-02", value, utils.BusinessLocation())
+			if err == nil {
+				return day, nil
+			}
+		}
+		return nil, fmt.Errorf("filter on %q expects a date YYYY-MM-DD", name)
+	case reportUUID:
+		if value, ok := raw.(string); ok {
+			if id, err := guuid.Parse(value); err == nil {
+				return id, nil
+			}
+		}
+		return nil, fmt.Errorf("filter on %q expects a UUID", name)
+	default:
+		if value, ok := raw.(string); ok {
+			return value, nil
+		}
+		return nil, fmt.Errorf("filter on %q expects a string", name)
+	}
+}
+
+// reportPeriod возвращает границы периода отчёта; nil — граница не задана.
+// from и to имеют приоритет над period, как в статистике.
+func reportPeriod(def model.ReportDefinition) (*time.Time, *time.Time, error) {
+	location := utils.BusinessLocation()
+
+	if def.From != "" || def.To != "" {
+		var from, to *time.Time
+		if def.From != "" {
+			day, err := time.ParseInLocation("2006-01-02", def.From, location)
+			if err != nil {
+				return nil, nil, errors.New("invalid from format, expected YYYY-MM-DD")
+			}
+			from = &day
+		}
+		if def.To != "" {
+			day, err := time.ParseInLocation("2006-01-02", def.To, location)
+			if err != nil {
+				return nil, nil, errors.New("invalid to format, expected YYYY-MM-DD")
+			}
+			// Включаем весь указанный день
+			day = day.AddDate(0, 0, 1)
+			to = &day
+		}
+		if from != nil && to != nil && !from.Before(*to) {
+			return nil, nil, errors.New("from must not be after to")
+		}
+		return from, to, nil
+	}
+
+	if def.Period == "" {
+		return nil, nil, nil
+	}
+	r, err := periodRange(def.Period, utils.BusinessNow(), def.Rolling)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &r.From, &r.To, nil
+}
+
+// runReport выполняет отчёт и приводит значения к строкам и числам.
+func runReport(db *gorm.DB, def model.ReportDefinition) (ReportResult, error) {
+	result := ReportResult{Rows: []map[string]interface{}{}}
+
+	query, err := buildReportQuery(def)
+	if err != nil {
+		return result, &reportDefinitionError{err}
+	}
+	result.Columns = query.Columns
+
+	rows, err := db.Raw(query.SQL, query.Args...).Rows()
+	if err != nil {
+		return result, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		values := make([]interface{}, len(query.Columns))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return result, err
+		}
+
+		row := make(map[string]interface{}, len(values))
+		for i, value := range values {
+			if bytes, ok := value.([]byte); ok {
+				value = string(bytes)
+				values[i] = value
+			}
+			row[query.Columns[i]] = value
+		}
+		result.Rows = append(result.Rows, row)
+		result.values = append(result.values, values)
+	}
+	return result, rows.Err()
+}
+
+// reportDefinitionError — ошибка в определении отчёта, а не при его выполнении.
+type reportDefinitionError struct {
+	err error
+}
+
+func (e *reportDefinitionError) Error() string {
+	return e.err.Error()
+}
+
+func (e *reportDefinitionError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"backend/model"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestBuildReportQueryRejects(t *testing.T) {
+	sum := []model.ReportMeasure{{Field: "totalPrice", Aggregate: "sum"}}
+
+	tests := []struct {
+		name    string
+		def     model.ReportDefinition
+		wantErr string
+	}{
+		{
+			name:    "unknown dataset",
+			def:     model.ReportDefinition{Dataset: "users", Measures: sum},
+			wantErr: `unknown dataset "users"`,
+		},
+		{
+			name:    "dataset as sql",
+			def:     model.ReportDefinition{Dataset: "orders; DROP TABLE orders", Measures: sum},
+			wantErr: "unknown dataset",
+		},
+		{
+			name:    "no measures",
+			def:     model.ReportDefinition{Dataset: "orders"},
+			wantErr: "at least one measure is required",
+		},
+		{
+			name:    "unknown dimension field",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"password"}, Measures: sum},
+			wantErr: `unknown field "password"`,
+		},
+		{
+			name:    "dimension as sql expression",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"orders.status"}, Measures: sum},
+			wantErr: "unknown field",
+		},
+		{
+			name:    "field from another dataset",
+			def:     model.ReportDefinition{Dataset: "clients", Dimensions: []string{"productName"}, Measures: []model.ReportMeasure{{Aggregate: "count"}}},
+			wantErr: `unknown field "productName" in dataset clients`,
+		},
+		{
+			name:    "unknown measure field",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: []model.ReportMeasure{{Field: "secret", Aggregate: "sum"}}},
+			wantErr: `unknown field "secret"`,
+		},
+		{
+			name:    "unknown aggregate",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: []model.ReportMeasure{{Field: "totalPrice", Aggregate: "pg_sleep"}}},
+			wantErr: `unknown aggregate "pg_sleep"`,
+		},
+		{
+			name:    "aggregate without field",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: []model.ReportMeasure{{Aggregate: "sum"}}},
+			wantErr: "aggregate sum requires a field",
+		},
+		{
+			name:    "sum of text field",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: []model.ReportMeasure{{Field: "status", Aggregate: "sum"}}},
+			wantErr: "requires a numeric field",
+		},
+		{
+			name:    "unknown filter field",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: sum, Filters: []model.ReportFilter{{Field: "1=1 OR status", Operator: "eq", Value: "x"}}},
+			wantErr: "unknown field",
+		},
+		{
+			name:    "unknown operator",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: sum, Filters: []model.ReportFilter{{Field: "status", Operator: "LIKE", Value: "x"}}},
+			wantErr: "operator LIKE is not supported",
+		},
+		{
+			name:    "operator not allowed for field type",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: sum, Filters: []model.ReportFilter{{Field: "status", Operator: "gt", Value: "x"}}},
+			wantErr: "operator gt is not supported",
+		},
+		{
+			name:    "filter value of wrong type",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: sum, Filters: []model.ReportFilter{{Field: "totalPrice", Operator: "gt", Value: "0 OR 1=1"}}},
+			wantErr: "expects a number",
+		},
+		{
+			name:    "empty in list",
+			def:     model.ReportDefinition{Dataset: "orders", Measures: sum, Filters: []model.ReportFilter{{Field: "status", Operator: "in", Value: []interface{}{}}}},
+			wantErr: "in requires a non-empty array",
+		},
+		{
+			name:    "granularity outside formats",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"createdAt:hour"}, Measures: sum},
+			wantErr: `invalid granularity "hour"`,
+		},
+		{
+			name:    "granularity as sql",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"createdAt:day', now()) --"}, Measures: sum},
+			wantErr: "invalid granularity",
+		},
+		{
+			name:    "granularity on non-date field",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"status:month"}, Measures: sum},
+			wantErr: "granularity is only allowed for date fields",
+		},
+		{
+			name:    "sortBy not a report column",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"status"}, Measures: sum, SortBy: "totalPrice"},
+			wantErr: "sortBy must be one of the report columns",
+		},
+		{
+			name:    "sortBy as sql",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"status"}, Measures: sum, SortBy: "1; DROP TABLE orders"},
+			wantErr: "sortBy must be one of the report columns",
+		},
+		{
+			name:    "duplicate column",
+			def:     model.ReportDefinition{Dataset: "orders", Dimensions: []string{"status", "status"}, Measures: sum},
+			wantErr: `duplicate column "status"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := buildReportQuery(tt.def)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("buildReportQuery() error = %v, want %q (SQL: %s)", err, tt.wantErr, query.SQL)
+			}
+		})
+	}
+}
+
+func TestBuildReportQueryFilterValuesAreArgs(t *testing.T) {
+	const injection = "x'; DROP TABLE orders; --"
+	const sellerID = "123e4567-e89b-12d3-a456-426614174000"
+
+	def := model.ReportDefinition{
+		Dataset:    "orders",
+		Dimensions: []string{"sellerName", "createdAt:month"},
+		Measures:   []model.ReportMeasure{{Field: "totalPrice", Aggregate: "sum"}, {Aggregate: "count"}},
+		Filters: []model.ReportFilter{
+			{Field: "status", Operator: "eq", Value: injection},
+			{Field: "clientName", Operator: "contains", Value: injection},
+			{Field: "paymentMethod", Operator: "in", Value: []interface{}{injection, "cash"}},
+			{Field: "totalPrice", Operator: "gte", Value: 1234.5},
+			{Field: "sellerId", Operator: "eq", Value: sellerID},
+		},
+		SortBy:   "sum:totalPrice",
+		SortDesc: true,
+	}
+
+	query, err := buildReportQuery(def)
+	if err != nil {
+		t.Fatalf("buildReportQuery() error: %v", err)
+	}
+
+	for _, value := range []string{injection, "DROP TABLE", "cash", "1234.5", sellerID} {
+		if strings.Contains(query.SQL, value) {
+			t.Errorf("SQL contains filter value %q: %s", value, query.SQL)
+		}
+	}
+
+	args := fmt.Sprint(query.Args...)
+	for _, value := range []string{injection, `%x'; DROP TABLE orders; --%`, "cash", "1234.5", sellerID} {
+		if !strings.Contains(args, value) {
+			t.Errorf("Args %v do not contain %q", query.Args, value)
+		}
+	}
+
+	if placeholders := strings.Count(query.SQL, "?"); placeholders != len(query.Args) {
+		t.Errorf("SQL has %d placeholders for %d args: %s", placeholders, len(query.Args), query.SQL)
+	}
+
+	wantColumns := []string{"sellerName", "createdAt:month", "sum:totalPrice", "count"}
+	if fmt.Sprint(query.Columns) != fmt.Sprint(wantColumns) {
+		t.Errorf("Columns = %v, want %v", query.Columns, wantColumns)
+	}
+	if !strings.Contains(query.SQL, "ORDER BY 3 DESC NULLS LAST") {
+		t.Errorf("SQL does not sort by the sum column: %s", query.SQL)
+	}
+}
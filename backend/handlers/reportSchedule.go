@@ -0,0 +1,589 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/tasks"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// scheduledExports — встроенные выгрузки, которые можно поставить в расписание.
+// Возвращают заголовки и строки в том же виде, что и /exports.
+var scheduledExports = map[string]func(db *gorm.DB) ([]string, [][]interface{}, error){
+	"products": func(db *gorm.DB) ([]string, [][]interface{}, error) {
+		var products []model.Product
+		if err := db.Preload(clause.Associations).Find(&products).Error; err != nil {
+			return nil, nil, err
+		}
+		rows, err := utils.StructRows(products, productExportFields)
+		return productExportHeaders, rows, err
+	},
+	"clients": func(db *gorm.DB) ([]string, [][]interface{}, error) {
+		var clients []model.Client
+		if err := db.Find(&clients).Error; err != nil {
+			return nil, nil, err
+		}
+		rows, err := utils.StructRows(clients, clientExportFields)
+		return clientExportHeaders, rows, err
+	},
+}
+
+// GenerateScheduledReport строит файл для расписания: выполняет сохранённый отчёт или встроенную
+// выгрузку. Если у расписания задан период, отчёт строится за последний завершённый такой период.
+func GenerateScheduledReport(schedule model.ReportSchedule, now time.Time) (tasks.ReportFile, error) {
+	db := database.DB
+	file := tasks.ReportFile{Extension: schedule.Format}
+
+	var headers []string
+	var rows [][]interface{}
+	switch {
+	case schedule.ReportID != nil:
+		var report model.SavedReport
+		if err := db.First(&report, "id = ?", *schedule.ReportID).Error; err != nil {
+			return file, fmt.Errorf("saved report: %w", err)
+		}
+
+		def := report.Definition
+		if schedule.Period != "" {
+			current, err := periodRange(schedule.Period, now, false)
+			if err != nil {
+				return file, err
+			}
+			r := current.previous()
+			def.Period, def.Rolling = "", false
+			def.From = r.From.Format("2006-01-02")
+			def.To = r.To.AddDate(0, 0, -1).Format("2006-01-02")
+			file.PeriodFrom, file.PeriodTo = &r.From, &r.To
+		}
+
+		result, err := runReport(db, def)
+		if err != nil {
+			return file, err
+		}
+		headers, rows = result.Columns, result.values
+	case schedule.Export != "":
+		export, ok := scheduledExports[schedule.Export]
+		if !ok {
+			return file, fmt.Errorf("unknown export %q", schedule.Export)
+		}
+		var err error
+		if headers, rows, err = export(db); err != nil {
+			return file, err
+		}
+	default:
+		return file, errors.New("schedule has neither a report nor an export")
+	}
+
+	var err error
+	if schedule.Format == "csv" {
+		file.ContentType = utils.CSVContentType
+		file.Content, err = utils.RowsToCSV(headers, rows)
+	} else {
+		file.ContentType = utils.ExcelContentType
+		file.Content, err = utils.RowsToExcel(headers, rows)
+	}
+	file.Rows = len(rows)
+	return file, err
+}
+
+// CreateReportSchedule Создать расписание отчёта
+//
+//	@Summary		Создать расписание отчёта
+//	@Description	Ставит сохранённый отчёт (reportId) или встроенную выгрузку (export: products, clients) в расписание. cron — пять полей в часовом поясе бизнеса, например "0 8 1 * *" — первого числа в 8:00. period — строить отчёт за последний завершённый день, неделю, месяц, квартал или год. Файл отправляется получателям, если настроен SMTP_HOST.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			schedule	body		model.ReportScheduleRequest	true	"Расписание"
+//	@Success		201			{object}	model.ReportSchedule		"Расписание создано"
+//	@Failure		400			{object}	APIError					"Некорректный запрос"
+//	@Failure		404			{object}	APIError					"Отчёт не найден"
+//	@Failure		422			{object}	APIError					"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError					"Ошибка сервера"
+//	@Router			/reports/schedules [post]
+func CreateReportSchedule(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.ReportScheduleRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	db := database.DB
+	schedule := model.ReportSchedule{
+		ID:          guuid.New(),
+		Active:      true,
+		CreatedByID: user.ID,
+	}
+	if err := applyReportScheduleRequest(db, &schedule, body); err != nil {
+		return reportScheduleErrorResponse(c, err)
+	}
+
+	if err := db.Create(&schedule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create report schedule",
+		})
+	}
+
+	recordAudit(c, db, model.AuditCreate, "report_schedule", schedule.ID, nil, schedule)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Report schedule created successfully",
+		"data":    schedule,
+	})
+}
+
+// GetReportSchedules Получить расписания отчётов
+//
+//	@Summary		Получить расписания отчётов
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{array}		model.ReportSchedule	"Список расписаний"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/reports/schedules [get]
+func GetReportSchedules(c *fiber.Ctx) error {
+	var schedules []model.ReportSchedule
+	if err := database.DB.Preload("Report").Order("name").Find(&schedules).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve report schedules",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    schedules,
+	})
+}
+
+// UpdateReportSchedule Обновить расписание отчёта
+//
+//	@Summary		Обновить расписание отчёта
+//	@Description	Заменяет параметры расписания и пересчитывает время следующего запуска
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string						true	"ID расписания"
+//	@Param			schedule	body		model.ReportScheduleRequest	true	"Расписание"
+//	@Success		200			{object}	model.ReportSchedule		"Расписание обновлено"
+//	@Failure		400			{object}	APIError					"Некорректный запрос"
+//	@Failure		404			{object}	APIError					"Расписание или отчёт не найдены"
+//	@Failure		422			{object}	APIError					"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError					"Ошибка сервера"
+//	@Router			/reports/schedules/{id} [put]
+func UpdateReportSchedule(c *fiber.Ctx) error {
+	db := database.DB
+
+	schedule, err := findReportSchedule(db, c.Params("id"))
+	if err != nil {
+		return reportScheduleErrorResponse(c, err)
+	}
+
+	body := new(model.ReportScheduleRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	before := schedule
+	if err := applyReportScheduleRequest(db, &schedule, body); err != nil {
+		return reportScheduleErrorResponse(c, err)
+	}
+
+	if err := db.Omit(clause.Associations).Save(&schedule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not update report schedule",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "report_schedule", schedule.ID, before, schedule)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Report schedule updated successfully",
+		"data":    schedule,
+	})
+}
+
+// DeleteReportSchedule Удалить расписание отчёта
+//
+//	@Summary		Удалить расписание отчёта
+//	@Description	Удаляет расписание; сформированные по нему файлы остаются
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID расписания"
+//	@Success		200	{object}	model.ReportSchedule	"Расписание удалено"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		404	{object}	APIError				"Расписание не найдено"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/reports/schedules/{id} [delete]
+func DeleteReportSchedule(c *fiber.Ctx) error {
+	db := database.DB
+
+	schedule, err := findReportSchedule(db, c.Params("id"))
+	if err != nil {
+		return reportScheduleErrorResponse(c, err)
+	}
+
+	if err := db.Delete(&schedule).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not delete report schedule",
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "report_schedule", schedule.ID, schedule, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Report schedule deleted successfully",
+		"data":    schedule,
+	})
+}
+
+// RunReportScheduleNow Запустить расписание сейчас
+//
+//	@Summary		Запустить расписание сейчас
+//	@Description	Формирует и отправляет отчёт немедленно, не сдвигая плановый запуск
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID расписания"
+//	@Success		201	{object}	model.GeneratedReport	"Отчёт сформирован; ошибки формирования и доставки — в полях error и deliveryError"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		404	{object}	APIError				"Расписание не найдено"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/reports/schedules/{id}/run [post]
+func RunReportScheduleNow(c *fiber.Ctx) error {
+	schedule, err := findReportSchedule(database.DB, c.Params("id"))
+	if err != nil {
+		return reportScheduleErrorResponse(c, err)
+	}
+
+	generated, err := tasks.RunReportSchedule(schedule)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to save generated report",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": generated.Status == model.GeneratedReportSuccess,
+		"message": "Report generated",
+		"data":    generated,
+	})
+}
+
+// GetGeneratedReports Сформированные отчёты
+//
+//	@Summary		Сформированные отчёты
+//	@Description	Список файлов, сформированных по расписаниям, новые сначала
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			scheduleId	query		string					false	"ID расписания"
+//	@Param			page		query		int						false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int						false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.GeneratedReport	"Файлы с информацией о пагинации"
+//	@Failure		400			{object}	APIError				"Неверный формат ID"
+//	@Failure		500			{object}	APIError				"Ошибка сервера"
+//	@Router			/reports/files [get]
+func GetGeneratedReports(c *fiber.Ctx) error {
+	reports := []model.GeneratedReport{}
+
+	db := database.DB.Order("created_at desc")
+	if raw := c.Query("scheduleId"); raw != "" {
+		id, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid scheduleId format",
+			})
+		}
+		db = db.Where("schedule_id = ?", id)
+	}
+
+	response, err := utils.Paginate(db, c, nil, &reports)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve generated reports",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// DownloadGeneratedReport Скачать сформированный отчёт
+//
+//	@Summary		Скачать сформированный отчёт
+//	@Tags			Reports
+//	@Produce		text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		BearerAuth
+//	@Param			id	path		string		true	"ID файла"
+//	@Success		200	{file}		file		"Файл отчёта"
+//	@Failure		400	{object}	APIError	"Неверный формат ID"
+//	@Failure		404	{object}	APIError	"Файл не найден"
+//	@Failure		500	{object}	APIError	"Ошибка сервера"
+//	@Router			/reports/files/{id}/download [get]
+func DownloadGeneratedReport(c *fiber.Ctx) error {
+	generated, err := findGeneratedReport(database.DB, c.Params("id"))
+	if err != nil {
+		return generatedReportErrorResponse(c, err)
+	}
+	if generated.Path == "" {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Report file was not generated",
+		})
+	}
+
+	content, err := os.ReadFile(generated.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Report file not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to read report file",
+		})
+	}
+
+	c.Set("Content-Type", generated.ContentType)
+	c.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", generated.FileName))
+	return c.Send(content)
+}
+
+// DeleteGeneratedReport Удалить сформированный отчёт
+//
+//	@Summary		Удалить сформированный отчёт
+//	@Description	Удаляет запись и файл отчёта
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"ID файла"
+//	@Success		200	{object}	model.GeneratedReport	"Файл удалён"
+//	@Failure		400	{object}	APIError				"Неверный формат ID"
+//	@Failure		404	{object}	APIError				"Файл не найден"
+//	@Failure		500	{object}	APIError				"Ошибка сервера"
+//	@Router			/reports/files/{id} [delete]
+func DeleteGeneratedReport(c *fiber.Ctx) error {
+	db := database.DB
+
+	generated, err := findGeneratedReport(db, c.Params("id"))
+	if err != nil {
+		return generatedReportErrorResponse(c, err)
+	}
+
+	if err := db.Delete(&generated).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not delete generated report",
+		})
+	}
+	if generated.Path != "" {
+		if err := os.Remove(generated.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Could not delete report file",
+			})
+		}
+	}
+
+	recordAudit(c, db, model.AuditDelete, "generated_report", generated.ID, generated, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Generated report deleted successfully",
+		"data":    generated,
+	})
+}
+
+var (
+	errInvalidReportScheduleID  = errors.New("invalid report schedule id")
+	errInvalidGeneratedReportID = errors.New("invalid generated report id")
+	errScheduleReportNotFound   = errors.New("saved report not found")
+)
+
+// reportScheduleValidationError — ошибка в параметрах расписания.
+type reportScheduleValidationError struct {
+	status  int
+	message string
+}
+
+func (e *reportScheduleValidationError) Error() string {
+	return e.message
+}
+
+// applyReportScheduleRequest проверяет запрос и переносит его в расписание вместе со временем
+// следующего запуска.
+func applyReportScheduleRequest(db *gorm.DB, schedule *model.ReportSchedule, body *model.ReportScheduleRequest) error {
+	if err := validator.New().Struct(body); err != nil {
+		return &reportScheduleValidationError{fiber.StatusUnprocessableEntity, err.Error()}
+	}
+	if (body.ReportID == nil) == (body.Export == "") {
+		return &reportScheduleValidationError{fiber.StatusUnprocessableEntity, "Specify either reportId or export"}
+	}
+	if body.Period != "" && body.ReportID == nil {
+		return &reportScheduleValidationError{fiber.StatusUnprocessableEntity, "period is only supported for saved reports"}
+	}
+
+	if body.ReportID != nil {
+		var report model.SavedReport
+		if err := db.First(&report, "id = ?", *body.ReportID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errScheduleReportNotFound
+			}
+			return err
+		}
+	}
+
+	next, err := tasks.NextScheduleRun(body.Cron, time.Now())
+	if err != nil {
+		return &reportScheduleValidationError{fiber.StatusBadRequest, "Invalid cron expression: " + err.Error()}
+	}
+
+	schedule.Name = body.Name
+	schedule.ReportID = body.ReportID
+	schedule.Report = nil
+	schedule.Export = body.Export
+	schedule.Format = body.Format
+	schedule.Cron = body.Cron
+	schedule.Period = body.Period
+	schedule.Recipients = body.Recipients
+	if body.Active != nil {
+		schedule.Active = *body.Active
+	}
+	schedule.NextRunAt = next
+	return nil
+}
+
+func findReportSchedule(db *gorm.DB, rawID string) (model.ReportSchedule, error) {
+	var schedule model.ReportSchedule
+
+	id, err := guuid.Parse(rawID)
+	if err != nil {
+		return schedule, errInvalidReportScheduleID
+	}
+
+	err = db.First(&schedule, "id = ?", id).Error
+	return schedule, err
+}
+
+func findGeneratedReport(db *gorm.DB, rawID string) (model.GeneratedReport, error) {
+	var generated model.GeneratedReport
+
+	id, err := guuid.Parse(rawID)
+	if err != nil {
+		return generated, errInvalidGeneratedReportID
+	}
+
+	err = db.First(&generated, "id = ?", id).Error
+	return generated, err
+}
+
+func reportScheduleErrorResponse(c *fiber.Ctx, err error) error {
+	var validationErr *reportScheduleValidationError
+	if errors.As(err, &validationErr) {
+		return c.Status(validationErr.status).JSON(fiber.Map{
+			"status":  validationErr.status,
+			"success": false,
+			"message": validationErr.message,
+		})
+	}
+	if errors.Is(err, errInvalidReportScheduleID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Report schedule ID",
+		})
+	}
+	if errors.Is(err, errScheduleReportNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Report not found",
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Report schedule not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
+
+func generatedReportErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidGeneratedReportID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Generated report ID",
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Generated report not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
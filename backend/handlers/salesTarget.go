@@ -0,0 +1,433 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TargetProgress — план и фактическая выручка за его месяц.
+// Expected — доля плана, которая должна быть выполнена к текущему дню при равномерных продажах.
+type TargetProgress struct {
+	model.SalesTarget
+	Actual    float64  `json:"actual"`
+	Progress  *float64 `json:"progress"`
+	Remaining float64  `json:"remaining"`
+	Expected  float64  `json:"expected"`
+}
+
+// CreateSalesTarget Создать план продаж
+//
+//	@Summary		Создать план продаж
+//	@Description	Создаёт план выручки на месяц для продавца или для категории (указывается что-то одно)
+//	@Tags			Targets
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			target	body		model.CreateSalesTargetRequest	true	"План"
+//	@Success		201		{object}	model.SalesTarget				"План создан"
+//	@Failure		400		{object}	APIError						"Некорректный запрос"
+//	@Failure		404		{object}	APIError						"Продавец или категория не найдены"
+//	@Failure		409		{object}	APIError						"План уже существует"
+//	@Failure		422		{object}	APIError						"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError						"Ошибка сервера"
+//	@Router			/targets [post]
+func CreateSalesTarget(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.CreateSalesTargetRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	if (body.SellerID == nil) == (body.CategoryID == nil) {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": "Specify either sellerId or categoryId",
+		})
+	}
+
+	db := database.DB
+	duplicate := db.Model(&model.SalesTarget{}).Where("month = ?", body.Month)
+	if body.SellerID != nil {
+		var seller model.User
+		if err := db.First(&seller, "id = ? AND role = ?", body.SellerID, model.Seller).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Seller not found",
+			})
+		}
+		duplicate = duplicate.Where("seller_id = ?", body.SellerID)
+	} else {
+		var category model.Category
+		if err := db.First(&category, "id = ?", body.CategoryID).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Category not found",
+			})
+		}
+		duplicate = duplicate.Where("category_id = ?", body.CategoryID)
+	}
+
+	var count int64
+	if err := duplicate.Count(&count).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create sales target",
+		})
+	}
+	if count > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status":  409,
+			"success": false,
+			"message": "Sales target for this month already exists",
+		})
+	}
+
+	target := model.SalesTarget{
+		ID:          guuid.New(),
+		Month:       body.Month,
+		SellerID:    body.SellerID,
+		CategoryID:  body.CategoryID,
+		Amount:      body.Amount,
+		CreatedByID: user.ID,
+	}
+
+	if err := db.Create(&target).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create sales target",
+		})
+	}
+
+	recordAudit(c, db, model.AuditCreate, "sales_target", target.ID, nil, target)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Sales target created successfully",
+		"data":    target,
+	})
+}
+
+// GetSalesTargets Получить планы продаж с выполнением
+//
+//	@Summary		Получить планы продаж с выполнением
+//	@Description	Возвращает планы за месяц (по умолчанию текущий) вместе с фактической выручкой (см. REVENUE_RECOGNITION)
+//	@Tags			Targets
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			month		query		string			false	"Месяц (YYYY-MM)"
+//	@Param			sellerId	query		string			false	"ID продавца"
+//	@Param			categoryId	query		string			false	"ID категории"
+//	@Success		200			{array}		TargetProgress	"Планы и их выполнение"
+//	@Failure		400			{object}	APIError		"Неверные параметры запроса"
+//	@Failure		500			{object}	APIError		"Ошибка сервера"
+//	@Router			/targets [get]
+func GetSalesTargets(c *fiber.Ctx) error {
+	month := c.Query("month", utils.BusinessNow().Format("2006-01"))
+	if _, _, err := monthRange(month); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	db := database.DB.Where("month = ?", month)
+	if raw := c.Query("sellerId"); raw != "" {
+		sellerID, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid sellerId format",
+			})
+		}
+		db = db.Where("seller_id = ?", sellerID)
+	}
+	if categoryID := c.Query("categoryId"); categoryID != "" {
+		if _, err := guuid.Parse(categoryID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid categoryId format",
+			})
+		}
+		db = db.Where("category_id = ?", categoryID)
+	}
+
+	progress, err := findTargetProgress(db)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve sales targets",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    progress,
+	})
+}
+
+// UpdateSalesTarget Изменить план продаж
+//
+//	@Summary		Изменить план продаж
+//	@Tags			Targets
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"ID плана"
+//	@Param			target	body		model.UpdateSalesTargetRequest	true	"Новая сумма плана"
+//	@Success		200		{object}	model.SalesTarget				"План обновлён"
+//	@Failure		400		{object}	APIError						"Некорректный запрос"
+//	@Failure		404		{object}	APIError						"План не найден"
+//	@Failure		422		{object}	APIError						"Ошибка валидации данных"
+//	@Failure		500		{object}	APIError						"Ошибка сервера"
+//	@Router			/targets/{id} [patch]
+func UpdateSalesTarget(c *fiber.Ctx) error {
+	body := new(model.UpdateSalesTargetRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	db := database.DB
+	target, err := findSalesTarget(db, c.Params("id"))
+	if err != nil {
+		return salesTargetErrorResponse(c, err)
+	}
+
+	before := target
+	target.Amount = body.Amount
+
+	if err := db.Save(&target).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update sales target",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "sales_target", target.ID, before, target)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Sales target updated successfully",
+		"data":    target,
+	})
+}
+
+// DeleteSalesTarget Удалить план продаж
+//
+//	@Summary		Удалить план продаж
+//	@Tags			Targets
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string				true	"ID плана"
+//	@Success		200	{object}	model.SalesTarget	"План удалён"
+//	@Failure		400	{object}	APIError			"Неверный формат ID"
+//	@Failure		404	{object}	APIError			"План не найден"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/targets/{id} [delete]
+func DeleteSalesTarget(c *fiber.Ctx) error {
+	db := database.DB
+	target, err := findSalesTarget(db, c.Params("id"))
+	if err != nil {
+		return salesTargetErrorResponse(c, err)
+	}
+
+	if err := db.Delete(&target).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to delete sales target",
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "sales_target", target.ID, target, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Sales target was removed",
+		"data":    target,
+	})
+}
+
+// sellerTargetProgress возвращает план продавца на месяц с выполнением или nil, если план не задан.
+func sellerTargetProgress(sellerID guuid.UUID, month string) (*TargetProgress, error) {
+	progress, err := findTargetProgress(database.DB.Where("month = ? AND seller_id = ?", month, sellerID))
+	if err != nil || len(progress) == 0 {
+		return nil, err
+	}
+	return &progress[0], nil
+}
+
+// findTargetProgress загружает планы по условиям query и считает фактическую выручку за месяц каждого плана.
+func findTargetProgress(query *gorm.DB) ([]TargetProgress, error) {
+	var targets []model.SalesTarget
+	err := query.Preload("Seller", unscoped).Preload("Category", unscoped).
+		Order("month asc, amount desc").
+		Find(&targets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	db := database.DB
+	now := time.Now()
+	actuals := map[string]map[string]float64{}
+	progress := make([]TargetProgress, 0, len(targets))
+
+	for _, target := range targets {
+		from, to, err := monthRange(target.Month)
+		if err != nil {
+			return nil, err
+		}
+
+		// Выручка за месяц считается один раз на все планы этого месяца
+		monthActuals, ok := actuals[target.Month]
+		if !ok {
+			monthActuals, err = monthlyActuals(db, from, to)
+			if err != nil {
+				return nil, err
+			}
+			actuals[target.Month] = monthActuals
+		}
+
+		row := TargetProgress{SalesTarget: target}
+		if target.SellerID != nil {
+			row.Actual = monthActuals["seller:"+target.SellerID.String()]
+		} else if target.CategoryID != nil {
+			row.Actual = monthActuals["category:"+*target.CategoryID]
+		}
+		if target.Amount > 0 {
+			value := row.Actual / target.Amount * 100
+			row.Progress = &value
+		}
+		if row.Actual < target.Amount {
+			row.Remaining = target.Amount - row.Actual
+		}
+
+		switch {
+		case !now.Before(to):
+			row.Expected = 100
+		case now.After(from):
+			row.Expected = now.Sub(from).Seconds() / to.Sub(from).Seconds() * 100
+		}
+
+		progress = append(progress, row)
+	}
+
+	return progress, nil
+}
+
+// monthlyActuals возвращает выручку за период по продавцам ("seller:<id>") и категориям ("category:<id>")
+// по дневным итогам продаж.
+func monthlyActuals(db *gorm.DB, from, to time.Time) (map[string]float64, error) {
+	var rows []struct {
+		Key     string
+		Revenue float64
+	}
+
+	fromDay, toDay := from.Format("2006-01-02"), to.Format("2006-01-02")
+	err := db.Raw(`
+		SELECT 'seller:' || salesperson_id::text AS key, COALESCE(SUM(revenue), 0) AS revenue
+		FROM daily_sales_rollups
+		WHERE day >= ? AND day < ?
+		GROUP BY salesperson_id
+		UNION ALL
+		SELECT 'category:' || products.category_id::text AS key, COALESCE(SUM(daily_sales_rollups.revenue), 0) AS revenue
+		FROM daily_sales_rollups
+		JOIN products ON products.id = daily_sales_rollups.product_id
+		WHERE daily_sales_rollups.day >= ? AND daily_sales_rollups.day < ?
+		GROUP BY products.category_id`,
+		fromDay, toDay, fromDay, toDay).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	actuals := make(map[string]float64, len(rows))
+	for _, row := range rows {
+		actuals[row.Key] = row.Revenue
+	}
+	return actuals, nil
+}
+
+var errInvalidSalesTargetID = errors.New("invalid sales target id")
+
+func findSalesTarget(db *gorm.DB, rawID string) (model.SalesTarget, error) {
+	var target model.SalesTarget
+
+	id, err := guuid.Parse(rawID)
+	if err != nil {
+		return target, errInvalidSalesTargetID
+	}
+
+	err = db.First(&target, "id = ?", id).Error
+	return target, err
+}
+
+func salesTargetErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidSalesTargetID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Sales target ID",
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Sales target not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
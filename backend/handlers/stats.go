@@ -4,16 +4,35 @@ import (
 	"backend/auth"
 	"backend/database"
 	"backend/model"
-	"fmt"
+	"backend/utils"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type DashboardResponse struct {
-	TopClients  []ClientSummary  `json:"top_clients"`
-	TopProducts []ProductSummary `json:"top_products"`
+	From        time.Time            `json:"from"`
+	To          time.Time            `json:"to"`
+	TopClients  []ClientSummary      `json:"top_clients"`
+	TopProducts []ProductSummary     `json:"top_products"`
+	Comparison  *DashboardComparison `json:"comparison,omitempty"`
+	// Планы продаж на месяцы, попадающие в период, с выполнением
+	Targets []TargetProgress `json:"targets"`
+}
+
+// DashboardComparison сравнивает выручку и число заказов с предыдущим периодом.
+// Изменение в процентах отсутствует, если в предыдущем периоде не было продаж.
+type DashboardComparison struct {
+	PreviousFrom       time.Time `json:"previous_from"`
+	PreviousTo         time.Time `json:"previous_to"`
+	Revenue            float64   `json:"revenue"`
+	PreviousRevenue    float64   `json:"previous_revenue"`
+	RevenueChange      *float64  `json:"revenue_change"`
+	OrderCount         int       `json:"order_count"`
+	PreviousOrderCount int       `json:"previous_order_count"`
+	OrderCountChange   *float64  `json:"order_count_change"`
 }
 
 type ClientSummary struct {
@@ -28,20 +47,35 @@ type ProductSummary struct {
 	ProductName string     `json:"product_name"`
 	TotalSold   float64    `json:"total_sold"`
 	UnitsSold   int        `json:"units_sold"`
+	TotalCost   float64    `json:"total_cost"`
+	Margin      float64    `json:"margin"`
 }
 
 type SalesData struct {
-	TotalAmount float64 `json:"total_amount"`
-	Date        string  `json:"date"`
+	TotalAmount    float64  `json:"total_amount"`
+	Date           string   `json:"date"`
+	PreviousDate   string   `json:"previous_date,omitempty"`
+	PreviousAmount *float64 `json:"previous_amount,omitempty"`
+}
+
+// SellerSalesChart — статистика продавца вместе с выполнением плана (при withTarget=true).
+// Target пуст, если план на месяц не задан.
+type SellerSalesChart struct {
+	Sales  []SalesData     `json:"sales"`
+	Target *TargetProgress `json:"target"`
 }
 
 // GetAllProductsStatistics Получить статистику всех продуктов
 //
 //	@Summary		Получить статистику всех продуктов
-//	@Description	Возвращает статистику по всем продуктам, включая количество произведенного и проданного за текущий месяц
+//	@Description	Возвращает статистику по всем продуктам, включая количество произведенного и проданного за период (по умолчанию — текущий месяц)
 //	@Tags			Statistics
+//	@Param			period	query	string	false	"Календарный период (day, week, month, quarter, year)"
+//	@Param			from	query	string	false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to		query	string	false	"Конец периода включительно (YYYY-MM-DD)"
 //	@Produce		json
 //	@Success		200	{array}		ProductStatistics	"Список со статистикой всех продуктов"
+//	@Failure		400	{object}	APIError			"Неверные параметры запроса"
 //	@Failure		500	{object}	APIError			"Ошибка сервера"
 //	@Router			/statistics/products [get]
 func GetProductStatistics(c *fiber.Ctx) error {
@@ -52,11 +86,20 @@ func GetProductStatistics(c *fiber.Ctx) error {
 		ProducedQuantity float64    `json:"produced_quantity"`
 	}
 
+	r, err := parseStatsRange(c, "month", false)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
 	var stats []ProductStats
-	currentMonth := time.Now().Month()
+	fromDay, toDay := r.days()
 
 	// Основной запрос с объединением данных
-	err := database.DB.Table("products").
+	err = database.DB.Table("products").
 		Select(`
             products.id as product_id,
             products.name as product_name,
@@ -66,24 +109,23 @@ func GetProductStatistics(c *fiber.Ctx) error {
 		Joins(`
             LEFT JOIN (
                 SELECT 
-                    oi.product_id, 
-                    SUM(oi.quantity) as sold_quantity 
-                FROM order_items oi
-                JOIN orders o ON o.id = oi.order_id 
-                WHERE EXTRACT(MONTH FROM o.created_at) = ?
-                GROUP BY oi.product_id
+                    product_id, 
+                    SUM(quantity) as sold_quantity 
+                FROM daily_sales_rollups
+                WHERE day >= ? AND day < ?
+                GROUP BY product_id
             ) sales ON products.id = sales.product_id
-        `, currentMonth).
+        `, fromDay, toDay).
 		Joins(`
             LEFT JOIN (
                 SELECT 
                     product_id, 
                     SUM(quantity) as produced_quantity 
                 FROM production_logs 
-                WHERE EXTRACT(MONTH FROM created_at) = ?
+                WHERE created_at >= ? AND created_at < ?
                 GROUP BY product_id
             ) production ON products.id = production.product_id
-        `, currentMonth).
+        `, r.From, r.To).
 		Scan(&stats).Error
 
 	if err != nil {
@@ -104,17 +146,18 @@ func GetProductStatistics(c *fiber.Ctx) error {
 // GetDashboard Получить топ клиентов и продуктов
 //
 //	@Summary		Получить топ клиентов и продуктов
-//	@Description	Возвращает топ 10 клиентов и продуктов по сумме потраченных денег и проданных единиц за текущий месяц
+//	@Description	Возвращает топ 10 клиентов и продуктов по сумме потраченных денег и проданных единиц за период (по умолчанию — текущий месяц). Учитываются только заказы, входящие в выручку: по умолчанию все принятые, при REVENUE_RECOGNITION=delivered — только доставленные. Для продуктов также считаются себестоимость проданного и маржа по себестоимости, зафиксированной в заказах. С compare=true добавляется сравнение выручки с предыдущим периодом. Также возвращаются планы продаж на месяцы периода и их выполнение.
 //	@Tags			Statistics
-//	@Param			period	query	string	false	"Период статистики (month, year)"
+//	@Param			period	query	string	false	"Календарный период (day, week, month, quarter, year)"
+//	@Param			from	query	string	false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to		query	string	false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Param			compare	query	bool	false	"Сравнить с предыдущим периодом"
 //	@Produce		json
 //	@Success		200	{array}		DashboardResponse	"Список со статистикой всех продуктов"
 //	@Failure		500	{object}	APIError			"Ошибка сервера"
 //	@Router			/statistics/dashboard [get]
 func GetDashboard(c *fiber.Ctx) error {
-	period := c.Query("period", "month")
-
-	startDate, endDate, err := getDateRange(period)
+	r, err := parseStatsRange(c, "month", false)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": err.Error(),
@@ -122,16 +165,18 @@ func GetDashboard(c *fiber.Ctx) error {
 	}
 	db := database.DB
 
+	fromDay, toDay := r.days()
+
 	var topClients []ClientSummary
-	err = db.Model(&model.Order{}).
+	err = db.Model(&model.DailySalesRollup{}).
 		Select(`
 				clients.id as client_id,
 				CONCAT(clients.name, ' ', clients.surname) as client_name,
-				SUM(orders.total_price) as total_spent,
-				COUNT(orders.id) as order_count
+				SUM(daily_sales_rollups.revenue) as total_spent,
+				SUM(daily_sales_rollups.order_count) as order_count
 			`).
-		Joins("JOIN clients ON clients.id = orders.client_id").
-		Where("orders.created_at BETWEEN ? AND ?", startDate, endDate).
+		Joins("JOIN clients ON clients.id = daily_sales_rollups.client_id").
+		Where("daily_sales_rollups.day >= ? AND daily_sales_rollups.day < ?", fromDay, toDay).
 		Group("clients.id, clients.name, clients.surname").
 		Order("total_spent DESC").
 		Limit(10).
@@ -144,16 +189,17 @@ func GetDashboard(c *fiber.Ctx) error {
 	}
 
 	var topProducts []ProductSummary
-	err = db.Model(&model.Order{}).
+	err = db.Model(&model.DailySalesRollup{}).
 		Select(`
 				products.id as product_id,
 				products.name as product_name,
-				SUM(order_items.quantity * products.price) as total_sold,
-				SUM(order_items.quantity) as units_sold
+				SUM(daily_sales_rollups.revenue) as total_sold,
+				SUM(daily_sales_rollups.quantity) as units_sold,
+				SUM(daily_sales_rollups.cost) as total_cost,
+				SUM(daily_sales_rollups.revenue - daily_sales_rollups.cost) as margin
 			`).
-		Joins("JOIN order_items ON order_items.order_id = orders.id").
-		Joins("JOIN products ON products.id = order_items.product_id").
-		Where("orders.created_at BETWEEN ? AND ?", startDate, endDate).
+		Joins("JOIN products ON products.id = daily_sales_rollups.product_id").
+		Where("daily_sales_rollups.day >= ? AND daily_sales_rollups.day < ?", fromDay, toDay).
 		Group("products.id, products.name").
 		Order("total_sold DESC").
 		Limit(10).
@@ -165,205 +211,235 @@ func GetDashboard(c *fiber.Ctx) error {
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(DashboardResponse{
+	response := DashboardResponse{
+		From:        r.From,
+		To:          r.To,
 		TopClients:  topClients,
 		TopProducts: topProducts,
-	})
+	}
+
+	lastMonth := r.To.Add(-time.Nanosecond).Format("2006-01")
+	response.Targets, err = findTargetProgress(db.Where("month >= ? AND month <= ?", r.From.Format("2006-01"), lastMonth))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to fetch sales targets",
+		})
+	}
+
+	if c.QueryBool("compare") {
+		prev := r.previous()
+		current, err := salesTotals(db, r)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to fetch sales totals",
+			})
+		}
+		previous, err := salesTotals(db, prev)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to fetch sales totals",
+			})
+		}
+
+		response.Comparison = &DashboardComparison{
+			PreviousFrom:       prev.From,
+			PreviousTo:         prev.To,
+			Revenue:            current.Revenue,
+			PreviousRevenue:    previous.Revenue,
+			RevenueChange:      percentChange(current.Revenue, previous.Revenue),
+			OrderCount:         current.OrderCount,
+			PreviousOrderCount: previous.OrderCount,
+			OrderCountChange:   percentChange(float64(current.OrderCount), float64(previous.OrderCount)),
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 // GetSalesChart Получить статистику продаж
 //
 //	@Summary		Получить статистику продаж
-//	@Description	Возвращает статистику продаж за последний месяц или год
+//	@Description	Возвращает выручку (см. REVENUE_RECOGNITION) по дням, неделям, месяцам или кварталам в часовом поясе бизнеса. По умолчанию — последние 30 дней. С compare=true к каждой точке добавляется значение за соответствующий интервал предыдущего периода.
 //	@Tags			Statistics
-//	@Param			period	query	string	false	"Период статистики (month, year)"
+//	@Param			period		query	string	false	"Скользящий период (week, month, quarter, year)"
+//	@Param			from		query	string	false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to			query	string	false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Param			granularity	query	string	false	"Шаг (day, week, month, quarter)"
+//	@Param			compare		query	bool	false	"Сравнить с предыдущим периодом"
 //	@Produce		json
 //	@Success		200	{array}		[]SalesData	"Список со статистикой всех продуктов"
+//	@Failure		400	{object}	APIError	"Неверные параметры запроса"
 //	@Failure		500	{object}	APIError	"Ошибка сервера"
 //	@Router			/statistics/chart [get]
 func GetSalesChart(c *fiber.Ctx) error {
-	period := c.Query("period", "month")
-	db := database.DB
-
-	now := time.Now()
-	var startDate, endDate time.Time
-
-	switch period {
-	case "year":
-		startDate = now.AddDate(-1, 0, 0) // Last 12 months
-		endDate = now
-	case "month":
-		startDate = now.AddDate(0, 0, -30) // Last 30 days
-		endDate = now
-	default:
+	r, err := parseStatsRange(c, "month", true)
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid period parameter. Use 'month' or 'year'",
+			"error": err.Error(),
 		})
 	}
 
-	// Get sales data from database
-	var results []SalesData
-	dateFormat := getPeriodFormat(period)
-	query := db.Model(&model.Order{}).
-		Select(
-			"COALESCE(SUM(total_price), 0) as total_amount",
-			fmt.Sprintf("to_char(created_at, '%s') as date", dateFormat),
-		).
-		Where("status NOT IN (?)", []string{"rejected", "pending"}).
-		Where("created_at BETWEEN ? AND ?", startDate, endDate).
-		Group("date").
-		Order("date ASC")
-
-	if err := query.Scan(&results).Error; err != nil {
+	generated, err := salesChart(database.DB, r, c.QueryBool("compare"))
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to fetch sales data",
 		})
 	}
 
-	// Create map for quick lookup
-	resultMap := make(map[string]float64)
-	for _, res := range results {
-		resultMap[res.Date] = res.TotalAmount
-	}
-
-	// Generate all possible dates/months in period
-	var generated []SalesData
-	current := startDate
-	layout := getGoLayout(period)
-
-	for {
-		// Stop condition
-		if (period == "year" && current.After(endDate)) ||
-			(period == "month" && current.After(endDate.AddDate(0, 0, 1))) {
-			break
-		}
-
-		// Format date according to period
-		dateStr := current.Format(layout)
-		amount := resultMap[dateStr]
-
-		generated = append(generated, SalesData{
-			Date:        dateStr,
-			TotalAmount: amount,
-		})
-
-		// Increment based on period
-		if period == "year" {
-			current = current.AddDate(0, 1, 0)
-		} else {
-			current = current.AddDate(0, 0, 1)
-		}
-	}
-
 	return c.Status(fiber.StatusOK).JSON(generated)
 }
 
+// GetSellerSalesChart Получить статистику продаж продавца
+//
+//	@Summary		Получить статистику продаж продавца
+//	@Description	Возвращает статистику продаж текущего продавца. Администратор и менеджер могут указать sellerId, в том числе деактивированного продавца. Параметры периода те же, что у /statistics/chart.
+//	@Description	С withTarget=true ответ — объект SellerSalesChart со статистикой и выполнением плана продавца на месяц month (по умолчанию текущий).
+//	@Tags			Statistics
+//	@Param			period		query	string	false	"Скользящий период (week, month, quarter, year)"
+//	@Param			from		query	string	false	"Начало периода (YYYY-MM-DD)"
+//	@Param			to			query	string	false	"Конец периода включительно (YYYY-MM-DD)"
+//	@Param			granularity	query	string	false	"Шаг (day, week, month, quarter)"
+//	@Param			compare		query	bool	false	"Сравнить с предыдущим периодом"
+//	@Param			sellerId	query	string	false	"ID продавца (только для admin и manager)"
+//	@Param			withTarget	query	bool	false	"Добавить выполнение плана продаж"
+//	@Param			month		query	string	false	"Месяц плана (YYYY-MM), по умолчанию текущий"
+//	@Produce		json
+//	@Success		200	{array}		[]SalesData	"Статистика продаж по датам (SellerSalesChart при withTarget=true)"
+//	@Failure		400	{object}	APIError	"Неверные параметры запроса"
+//	@Failure		500	{object}	APIError	"Ошибка сервера"
+//	@Router			/getstatsof/seller [get]
 func GetSellerSalesChart(c *fiber.Ctx) error {
-	period := c.Query("period", "month")
 	db := database.DB
 
 	user := c.Locals("user").(*auth.Claims)
 	sellerID := user.ID
 
-	now := time.Now()
-	var startDate, endDate time.Time
-
-	switch period {
-	case "year":
-		startDate = now.AddDate(-1, 0, 0)
-		endDate = now
-	case "month":
-		startDate = now.AddDate(0, 0, -30)
-		endDate = now
-	default:
+	// Продажи остаются за продавцом и после его деактивации, поэтому фильтруем только по salesperson_id
+	if raw := c.Query("sellerId"); raw != "" && user.Role != model.Seller {
+		parsed, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "invalid sellerId parameter",
+			})
+		}
+		sellerID = parsed
+	}
+
+	r, err := parseStatsRange(c, "month", true)
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "invalid period parameter. Use 'month' or 'year'",
+			"error": err.Error(),
 		})
 	}
 
-	dateFormat := getPeriodFormat(period)
-	var results []SalesData
-
-	query := db.Model(&model.Order{}).
-		Select(
-			"COALESCE(SUM(total_price), 0) as total_amount",
-			fmt.Sprintf("to_char(created_at, '%s') as date", dateFormat),
-		).
-		Where("status NOT IN (?)", []string{"rejected", "pending"}).
-		Where("created_at BETWEEN ? AND ?", startDate, endDate).
-		Where("salesperson_id = ?", sellerID). // Фильтрация по продавцу
-		Group("date").
-		Order("date ASC")
-
-	if err := query.Scan(&results).Error; err != nil {
+	withTarget := c.QueryBool("withTarget")
+	month := c.Query("month", utils.BusinessNow().Format("2006-01"))
+	if withTarget {
+		if _, _, err := monthRange(month); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	generated, err := salesChart(db.Where("salesperson_id = ?", sellerID), r, c.QueryBool("compare"))
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to fetch sales data",
 		})
 	}
 
-	resultMap := make(map[string]float64)
-	for _, res := range results {
-		resultMap[res.Date] = res.TotalAmount
+	// Без withTarget ответ остаётся массивом, как раньше
+	if !withTarget {
+		return c.Status(fiber.StatusOK).JSON(generated)
 	}
 
-	var generated []SalesData
-	current := startDate
-	layout := getGoLayout(period)
+	target, err := sellerTargetProgress(sellerID, month)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve sales target",
+		})
+	}
 
-	for {
-		if (period == "year" && current.After(endDate)) ||
-			(period == "month" && current.After(endDate.AddDate(0, 0, 1))) {
-			break
-		}
+	return c.Status(fiber.StatusOK).JSON(SellerSalesChart{Sales: generated, Target: target})
+}
 
-		dateStr := current.Format(layout)
-		amount := resultMap[dateStr]
+// salesChart строит ряд выручки по интервалам диапазона, заполняя пустые интервалы нулями.
+// Данные берутся из дневных итогов продаж; scope может содержать дополнительные условия на них.
+func salesChart(scope *gorm.DB, r statsRange, compare bool) ([]SalesData, error) {
+	amounts, err := salesByBucket(scope, r)
+	if err != nil {
+		return nil, err
+	}
 
+	buckets := r.buckets()
+	generated := make([]SalesData, 0, len(buckets))
+	for _, bucket := range buckets {
+		date := bucketLabel(bucket, r.Granularity)
 		generated = append(generated, SalesData{
-			Date:        dateStr,
-			TotalAmount: amount,
+			Date:        date,
+			TotalAmount: amounts[date],
 		})
+	}
+
+	if !compare {
+		return generated, nil
+	}
+
+	prev := r.previous()
+	previousAmounts, err := salesByBucket(scope, prev)
+	if err != nil {
+		return nil, err
+	}
 
-		if period == "year" {
-			current = current.AddDate(0, 1, 0)
-		} else {
-			current = current.AddDate(0, 0, 1)
+	// Интервалы сопоставляются по порядку: первый день месяца с первым днём предыдущего и т.д.
+	for i, bucket := range prev.buckets() {
+		if i >= len(generated) {
+			break
 		}
+		date := bucketLabel(bucket, prev.Granularity)
+		amount := previousAmounts[date]
+		generated[i].PreviousDate = date
+		generated[i].PreviousAmount = &amount
 	}
 
-	return c.Status(fiber.StatusOK).JSON(generated)
+	return generated, nil
 }
 
-// Helper to get PostgreSQL date format
-func getPeriodFormat(period string) string {
-	if period == "year" {
-		return "YYYY-MM"
+func salesByBucket(scope *gorm.DB, r statsRange) (map[string]float64, error) {
+	var results []struct {
+		TotalAmount float64
+		Bucket      time.Time
 	}
-	return "YYYY-MM-DD"
-}
 
-// Helper to get Go date layout
-func getGoLayout(period string) string {
-	if period == "year" {
-		return "2006-01"
+	fromDay, toDay := r.days()
+	err := scope.Session(&gorm.Session{}).Model(&model.DailySalesRollup{}).
+		Select("COALESCE(SUM(revenue), 0) as total_amount, "+r.bucketExpr("day")+" as bucket").
+		Where("day >= ? AND day < ?", fromDay, toDay).
+		Group("bucket").
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
 	}
-	return "2006-01-02"
-}
 
-func getDateRange(period string) (time.Time, time.Time, error) {
-	now := time.Now()
-	location := now.Location()
-
-	switch period {
-	case "month":
-		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, location)
-		end := start.AddDate(0, 1, -1).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-		return start, end, nil
-	case "year":
-		start := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, location)
-		end := start.AddDate(1, 0, -1).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
-		return start, end, nil
-	default:
-		return time.Time{}, time.Time{}, fmt.Errorf("invalid period. Use 'month' or 'year'")
+	amounts := make(map[string]float64, len(results))
+	for _, res := range results {
+		amounts[r.bucketKey(res.Bucket)] += res.TotalAmount
 	}
+	return amounts, nil
+}
+
+type salesTotal struct {
+	Revenue    float64
+	OrderCount int
+}
+
+func salesTotals(db *gorm.DB, r statsRange) (salesTotal, error) {
+	var total salesTotal
+	fromDay, toDay := r.days()
+	err := db.Model(&model.DailySalesRollup{}).
+		Select("COALESCE(SUM(revenue), 0) as revenue, COALESCE(SUM(order_count), 0) as order_count").
+		Where("day >= ? AND day < ?", fromDay, toDay).
+		Scan(&total).Error
+	return total, err
 }
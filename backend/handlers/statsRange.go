@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"backend/utils"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// statsRange — полуинтервал [From, To) в часовом поясе бизнеса и шаг группировки.
+type statsRange struct {
+	From        time.Time
+	To          time.Time
+	Granularity string
+}
+
+// Максимальное число интервалов в одном графике
+const maxStatsBuckets = 1000
+
+var statsGranularities = map[string]bool{
+	"day":     true,
+	"week":    true,
+	"month":   true,
+	"quarter": true,
+}
+
+// parseStatsRange читает параметры period, from, to и granularity.
+// from и to (YYYY-MM-DD, включительно) имеют приоритет над period. Если rolling,
+// period означает последние N дней или месяцев, иначе — текущий календарный период.
+func parseStatsRange(c *fiber.Ctx, defaultPeriod string, rolling bool) (statsRange, error) {
+	period := c.Query("period", defaultPeriod)
+	r, err := periodRange(period, utils.BusinessNow(), rolling)
+	if err != nil {
+		return r, err
+	}
+
+	location := utils.BusinessLocation()
+	explicit := false
+	if raw := c.Query("from"); raw != "" {
+		from, err := time.ParseInLocation("2006-01-02", raw, location)
+		if err != nil {
+			return r, errors.New("invalid from format, expected YYYY-MM-DD")
+		}
+		r.From = from
+		explicit = true
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, err := time.ParseInLocation("2006-01-02", raw, location)
+		if err != nil {
+			return r, errors.New("invalid to format, expected YYYY-MM-DD")
+		}
+		// Включаем весь указанный день
+		r.To = to.AddDate(0, 0, 1)
+		explicit = true
+	}
+	if !r.From.Before(r.To) {
+		return r, errors.New("from must not be after to")
+	}
+
+	if explicit {
+		r.Granularity = defaultGranularity(r.From, r.To)
+	}
+	if granularity := c.Query("granularity"); granularity != "" {
+		if !statsGranularities[granularity] {
+			return r, errors.New("invalid granularity. Use day, week, month or quarter")
+		}
+		r.Granularity = granularity
+	}
+
+	if len(r.buckets()) > maxStatsBuckets {
+		return r, fmt.Errorf("range is too large for %s granularity", r.Granularity)
+	}
+
+	return r, nil
+}
+
+// periodRange возвращает диапазон именованного периода относительно now.
+func periodRange(period string, now time.Time, rolling bool) (statsRange, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	tomorrow := today.AddDate(0, 0, 1)
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	if rolling {
+		switch period {
+		case "week":
+			return statsRange{From: today.AddDate(0, 0, -6), To: tomorrow, Granularity: "day"}, nil
+		case "month":
+			return statsRange{From: today.AddDate(0, 0, -29), To: tomorrow, Granularity: "day"}, nil
+		case "quarter":
+			return statsRange{From: today.AddDate(0, 0, -89), To: tomorrow, Granularity: "week"}, nil
+		case "year":
+			return statsRange{From: month.AddDate(0, -11, 0), To: month.AddDate(0, 1, 0), Granularity: "month"}, nil
+		}
+		return statsRange{}, errors.New("invalid period. Use week, month, quarter or year")
+	}
+
+	switch period {
+	case "day":
+		return statsRange{From: today, To: tomorrow, Granularity: "day"}, nil
+	case "week":
+		from := truncateToBucket(today, "week")
+		return statsRange{From: from, To: from.AddDate(0, 0, 7), Granularity: "day"}, nil
+	case "month":
+		return statsRange{From: month, To: month.AddDate(0, 1, 0), Granularity: "day"}, nil
+	case "quarter":
+		from := truncateToBucket(today, "quarter")
+		return statsRange{From: from, To: from.AddDate(0, 3, 0), Granularity: "week"}, nil
+	case "year":
+		from := time.Date(now.Year(), 1, 1, 0, 0, 0, 0, now.Location())
+		return statsRange{From: from, To: from.AddDate(1, 0, 0), Granularity: "month"}, nil
+	}
+	return statsRange{}, errors.New("invalid period. Use day, week, month, quarter or year")
+}
+
+// monthRange возвращает границы месяца YYYY-MM в часовом поясе бизнеса.
+func monthRange(month string) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation("2006-01", month, utils.BusinessLocation())
+	if err != nil {
+		return from, from, errors.New("invalid month format, expected YYYY-MM")
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+func defaultGranularity(from, to time.Time) string {
+	days := to.Sub(from).Hours() / 24
+	switch {
+	case days <= 31:
+		return "day"
+	case days <= 92:
+		return "week"
+	default:
+		return "month"
+	}
+}
+
+// previous возвращает предыдущий период той же длины. Диапазон из целых месяцев
+// сдвигается на то же число календарных месяцев.
+func (r statsRange) previous() statsRange {
+	prev := statsRange{To: r.From, Granularity: r.Granularity}
+
+	if r.From.Day() == 1 && r.To.Day() == 1 && r.From.Hour() == 0 && r.To.Hour() == 0 {
+		months := (r.To.Year()-r.From.Year())*12 + int(r.To.Month()-r.From.Month())
+		prev.From = r.From.AddDate(0, -months, 0)
+		return prev
+	}
+
+	days := int(r.To.Sub(r.From).Round(24*time.Hour).Hours() / 24)
+	prev.From = r.From.AddDate(0, 0, -days)
+	return prev
+}
+
+// buckets возвращает начала всех интервалов, пересекающих диапазон.
+func (r statsRange) buckets() []time.Time {
+	var result []time.Time
+	for current := truncateToBucket(r.From, r.Granularity); current.Before(r.To); current = nextBucket(current, r.Granularity) {
+		result = append(result, current)
+		if len(result) > maxStatsBuckets {
+			break
+		}
+	}
+	return result
+}
+
+// days возвращает границы диапазона датами для фильтра по дневным итогам продаж.
+func (r statsRange) days() (string, string) {
+	return r.From.Format("2006-01-02"), r.To.Format("2006-01-02")
+}
+
+// bucketExpr — SQL-выражение начала интервала для колонки типа date. Часовой пояс не применяется:
+// дни в дневных итогах уже посчитаны по часовому поясу бизнеса, для timestamptz выражение не подходит.
+func (r statsRange) bucketExpr(column string) string {
+	return fmt.Sprintf("date_trunc('%s', %s)", r.Granularity, column)
+}
+
+// bucketKey приводит начало интервала из базы (без часового пояса) к подписи интервала.
+func (r statsRange) bucketKey(bucket time.Time) string {
+	local := time.Date(bucket.Year(), bucket.Month(), bucket.Day(), 0, 0, 0, 0, utils.BusinessLocation())
+	return bucketLabel(local, r.Granularity)
+}
+
+func truncateToBucket(t time.Time, granularity string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch granularity {
+	case "week":
+		// Неделя начинается с понедельника, как в date_trunc('week')
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case "quarter":
+		month := time.Month((int(t.Month())-1)/3*3 + 1)
+		return time.Date(t.Year(), month, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+func nextBucket(t time.Time, granularity string) time.Time {
+	switch granularity {
+	case "week":
+		return t.AddDate(0, 0, 7)
+	case "month":
+		return t.AddDate(0, 1, 0)
+	case "quarter":
+		return t.AddDate(0, 3, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
+
+func bucketLabel(t time.Time, granularity string) string {
+	switch granularity {
+	case "month":
+		return t.Format("2006-01")
+	case "quarter":
+		return fmt.Sprintf("%d-Q%d", t.Year(), (int(t.Month())-1)/3+1)
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+func percentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous * 100
+	return &change
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/model"
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// insufficientStockError возвращается, когда на складе не хватает продукта для списания.
+type insufficientStockError struct {
+	ProductName string
+	Available   float64
+}
+
+func (e *insufficientStockError) Error() string {
+	return fmt.Sprintf("Product named '%s' is not available in sufficient quantity in the selected warehouse. Only %f left.", e.ProductName, e.Available)
+}
+
+// defaultWarehouseID возвращает склад по умолчанию.
+func defaultWarehouseID(db *gorm.DB) (guuid.UUID, error) {
+	var warehouse model.Warehouse
+	if err := db.Where("is_default = ?", true).First(&warehouse).Error; err != nil {
+		return guuid.Nil, err
+	}
+	return warehouse.ID, nil
+}
+
+// resolveWarehouseID возвращает указанный склад, проверив его существование, или склад по умолчанию.
+func resolveWarehouseID(db *gorm.DB, warehouseID *guuid.UUID) (guuid.UUID, error) {
+	if warehouseID == nil {
+		return defaultWarehouseID(db)
+	}
+
+	var warehouse model.Warehouse
+	if err := db.First(&warehouse, "id = ?", *warehouseID).Error; err != nil {
+		return guuid.Nil, err
+	}
+	return warehouse.ID, nil
+}
+
+// availableStock возвращает остаток продукта на складе.
+func availableStock(db *gorm.DB, warehouseID, productID guuid.UUID) (float64, error) {
+	var stock model.WarehouseStock
+	err := db.Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).First(&stock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, nil
+	}
+	return stock.Amount, err
+}
+
+// adjustStock изменяет остаток продукта на складе на delta и синхронно обновляет Product.Amount.
+// Должна вызываться внутри транзакции: строка остатка блокируется до её завершения.
+func adjustStock(tx *gorm.DB, warehouseID, productID guuid.UUID, delta float64) error {
+	lockStock := func(stock *model.WarehouseStock) error {
+		return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
+			First(stock).Error
+	}
+
+	var stock model.WarehouseStock
+	err := lockStock(&stock)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Параллельная транзакция могла создать строку одновременно с нами: ON CONFLICT не даёт
+		// упасть на уникальном индексе, после чего обе блокируют одну и ту же строку
+		empty := model.WarehouseStock{ID: guuid.New(), WarehouseID: warehouseID, ProductID: productID}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "warehouse_id"}, {Name: "product_id"}},
+			DoNothing: true,
+		}).Create(&empty).Error
+		if err == nil {
+			err = lockStock(&stock)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if stock.Amount+delta < 0 {
+		var product model.Product
+		if err := tx.Unscoped().First(&product, "id = ?", productID).Error; err != nil {
+			return err
+		}
+		return &insufficientStockError{ProductName: product.Name, Available: stock.Amount}
+	}
+
+	if err := tx.Model(&stock).UpdateColumn("amount", gorm.Expr("amount + ?", delta)).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&model.Product{}).
+		Where("id = ?", productID).
+		UpdateColumn("amount", gorm.Expr("amount + ?", delta)).Error
+}
+
+// warehouseAllowed проверяет, что менеджер, привязанный к складу, работает только со своим складом.
+// Администраторы и менеджеры без склада имеют доступ ко всем складам.
+func warehouseAllowed(c *fiber.Ctx, db *gorm.DB, warehouseID *guuid.UUID) (bool, error) {
+	claims := c.Locals("user").(*auth.Claims)
+	if claims.Role != model.Manager {
+		return true, nil
+	}
+
+	var user model.User
+	if err := db.Select("id", "warehouse_id").First(&user, "id = ?", claims.ID).Error; err != nil {
+		return false, err
+	}
+	if user.WarehouseID == nil {
+		return true, nil
+	}
+
+	target, err := resolveWarehouseID(db, warehouseID)
+	if err != nil {
+		return false, err
+	}
+	return target == *user.WarehouseID, nil
+}
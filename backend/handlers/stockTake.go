@@ -0,0 +1,629 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateStockTake Открыть инвентаризацию
+//
+//	@Summary		Открыть инвентаризацию
+//	@Description	Открывает инвентаризацию склада (по умолчанию — основного) целиком или по одной категории и фиксирует ожидаемые остатки
+//	@Tags			warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			stockTake	body		model.CreateStockTakeRequest	true	"Склад и категория"
+//	@Success		201			{object}	model.StockTake					"Инвентаризация открыта"
+//	@Failure		400			{object}	APIError						"Некорректный запрос"
+//	@Failure		403			{object}	APIError						"Склад недоступен менеджеру"
+//	@Failure		404			{object}	APIError						"Склад или категория не найдены"
+//	@Failure		409			{object}	APIError						"По складу уже идёт инвентаризация"
+//	@Failure		422			{object}	APIError						"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError						"Ошибка сервера"
+//	@Router			/warehouse/stocktakes [post]
+func CreateStockTake(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.CreateStockTakeRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	warehouseID, err := resolveWarehouseID(tx, body.WarehouseID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Warehouse not found",
+		})
+	}
+
+	allowed, err := warehouseAllowed(c, tx, &warehouseID)
+	if err != nil || !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "Stock-take can only be opened for your own warehouse",
+		})
+	}
+
+	if body.CategoryID != nil {
+		var category model.Category
+		if err := tx.First(&category, "id = ?", *body.CategoryID).Error; err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Category not found",
+			})
+		}
+	}
+
+	var openCount int64
+	if err := tx.Model(&model.StockTake{}).Where("warehouse_id = ? AND status = ?", warehouseID, model.StockTakeOpen).Count(&openCount).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+	if openCount > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status":  409,
+			"success": false,
+			"message": "Another stock-take is already open for this warehouse",
+		})
+	}
+
+	// Ожидаемые остатки на момент открытия
+	var expected []struct {
+		ID     guuid.UUID
+		Amount float64
+	}
+	query := tx.Model(&model.Product{}).
+		Select("products.id, COALESCE(warehouse_stocks.amount, 0) AS amount").
+		Joins("LEFT JOIN warehouse_stocks ON warehouse_stocks.product_id = products.id AND warehouse_stocks.warehouse_id = ?", warehouseID)
+	if body.CategoryID != nil {
+		query = query.Where("products.category_id = ?", *body.CategoryID)
+	}
+	if err := query.Scan(&expected).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to load expected stock",
+		})
+	}
+	if len(expected) == 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": "There are no products to count",
+		})
+	}
+
+	stockTake := model.StockTake{
+		ID:          guuid.New(),
+		WarehouseID: warehouseID,
+		CategoryID:  body.CategoryID,
+		Status:      model.StockTakeOpen,
+		Note:        body.Note,
+		CreatedByID: user.ID,
+	}
+	for _, row := range expected {
+		stockTake.Items = append(stockTake.Items, model.StockTakeItem{
+			ID:          guuid.New(),
+			StockTakeID: stockTake.ID,
+			ProductID:   row.ID,
+			Expected:    row.Amount,
+		})
+	}
+
+	if err := tx.Create(&stockTake).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to create stock-take",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditCreate, "stock_take", stockTake.ID, nil, stockTake)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Stock-take opened successfully",
+		"data":    stockTake,
+	})
+}
+
+// GetStockTakes Список инвентаризаций
+//
+//	@Summary		Список инвентаризаций
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string			false	"Статус (open, approved, cancelled)"
+//	@Param			warehouseId	query		string			false	"ID склада"
+//	@Param			page		query		int				false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int				false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.StockTake	"Инвентаризации с информацией о пагинации"
+//	@Failure		400			{object}	APIError		"Неверный формат ID склада"
+//	@Failure		500			{object}	APIError		"Ошибка сервера"
+//	@Router			/warehouse/stocktakes [get]
+func GetStockTakes(c *fiber.Ctx) error {
+	stockTakes := []model.StockTake{}
+	db := database.DB.Preload("Warehouse").Preload("Category").Order("created_at desc")
+
+	if status := c.Query("status"); status != "" {
+		db = db.Where("status = ?", status)
+	}
+	if warehouseID := c.Query("warehouseId"); warehouseID != "" {
+		id, err := guuid.Parse(warehouseID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid warehouseId format",
+			})
+		}
+		db = db.Where("warehouse_id = ?", id)
+	}
+
+	response, err := utils.Paginate(db, c, nil, &stockTakes)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve stock-takes",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetStockTakeByID Получить инвентаризацию
+//
+//	@Summary		Получить инвентаризацию
+//	@Description	Возвращает инвентаризацию со строками: ожидаемый и фактический остаток, расхождение
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID инвентаризации"
+//	@Success		200	{object}	model.StockTake	"Инвентаризация"
+//	@Failure		400	{object}	APIError		"Неверный формат ID"
+//	@Failure		404	{object}	APIError		"Инвентаризация не найдена"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/warehouse/stocktakes/{id} [get]
+func GetStockTakeByID(c *fiber.Ctx) error {
+	stockTake, err := loadStockTake(database.DB.Preload("Warehouse").Preload("Category"), c.Params("id"))
+	if err != nil {
+		return stockTakeErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    stockTake,
+	})
+}
+
+// SubmitStockTakeCounts Внести результаты пересчёта
+//
+//	@Summary		Внести результаты пересчёта
+//	@Description	Сохраняет фактическое количество по продуктам и рассчитывает расхождение с учётным остатком на момент пересчёта. Повторная отправка (пересчёт) перезаписывает значение и обновляет ожидаемый остаток.
+//	@Tags			warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"ID инвентаризации"
+//	@Param			counts	body		model.SubmitStockTakeCountsRequest	true	"Фактические остатки"
+//	@Success		200		{object}	model.StockTake						"Результаты сохранены"
+//	@Failure		400		{object}	APIError							"Инвентаризация уже закрыта"
+//	@Failure		403		{object}	APIError							"Склад недоступен менеджеру"
+//	@Failure		404		{object}	APIError							"Инвентаризация не найдена"
+//	@Failure		422		{object}	APIError							"Продукт не входит в инвентаризацию"
+//	@Failure		500		{object}	APIError							"Ошибка сервера"
+//	@Router			/warehouse/stocktakes/{id}/counts [post]
+func SubmitStockTakeCounts(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.SubmitStockTakeCountsRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	stockTake, err := loadStockTake(tx.Clauses(clause.Locking{Strength: "UPDATE"}), c.Params("id"))
+	if err != nil {
+		return stockTakeErrorResponse(c, err)
+	}
+
+	if stockTake.Status != model.StockTakeOpen {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Stock-take is already closed",
+		})
+	}
+
+	allowed, err := warehouseAllowed(c, tx, &stockTake.WarehouseID)
+	if err != nil || !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "This stock-take belongs to another warehouse",
+		})
+	}
+
+	items := make(map[guuid.UUID]*model.StockTakeItem, len(stockTake.Items))
+	for i := range stockTake.Items {
+		items[stockTake.Items[i].ProductID] = &stockTake.Items[i]
+	}
+
+	now := time.Now()
+	for _, count := range body.Items {
+		item, ok := items[count.ProductID]
+		if !ok {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"status":  422,
+				"success": false,
+				"message": fmt.Sprintf("Product %s is not part of this stock-take", count.ProductID),
+			})
+		}
+
+		// Ожидаемый остаток обновляется на момент пересчёта, чтобы повторный пересчёт учитывал
+		// продажи и приходы после открытия инвентаризации
+		var stock model.WarehouseStock
+		err := tx.Where("warehouse_id = ? AND product_id = ?", stockTake.WarehouseID, item.ProductID).First(&stock).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to load stock",
+			})
+		}
+
+		counted := count.Counted
+		item.Expected = stock.Amount
+		item.Counted = &counted
+		item.Variance = counted - item.Expected
+		item.CountedByID = &user.ID
+		item.CountedAt = &now
+
+		err = tx.Model(item).Select("expected", "counted", "variance", "counted_by_id", "counted_at").Updates(item).Error
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to save counts",
+			})
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Counts saved successfully",
+		"data":    stockTake,
+	})
+}
+
+// ApproveStockTake Утвердить инвентаризацию
+//
+//	@Summary		Утвердить инвентаризацию
+//	@Description	Проводит расхождения по пересчитанным продуктам как корректировки остатков склада. Непересчитанные продукты не изменяются. Если остаток продукта изменился после пересчёта, утверждение отклоняется и продукт нужно пересчитать.
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID инвентаризации"
+//	@Success		200	{object}	model.StockTake	"Инвентаризация утверждена"
+//	@Failure		400	{object}	APIError		"Инвентаризация уже закрыта"
+//	@Failure		404	{object}	APIError		"Инвентаризация не найдена"
+//	@Failure		409	{object}	APIError		"Остаток изменился после пересчёта, нужен пересчёт"
+//	@Failure		422	{object}	APIError		"Остаток ушёл бы в минус"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/warehouse/stocktakes/{id}/approve [post]
+func ApproveStockTake(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	stockTake, err := loadStockTake(tx.Clauses(clause.Locking{Strength: "UPDATE"}), c.Params("id"))
+	if err != nil {
+		return stockTakeErrorResponse(c, err)
+	}
+
+	if stockTake.Status != model.StockTakeOpen {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Stock-take is already closed",
+		})
+	}
+
+	before := stockTake
+	for _, item := range stockTake.Items {
+		if item.Counted == nil {
+			continue
+		}
+
+		// Проводится именно проверенное расхождение. Если после пересчёта были продажи или приходы,
+		// Expected устарел, и проводить его нельзя — продукт нужно пересчитать
+		var stock model.WarehouseStock
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("warehouse_id = ? AND product_id = ?", stockTake.WarehouseID, item.ProductID).
+			First(&stock).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to load stock",
+			})
+		}
+		if stock.Amount != item.Expected {
+			name := item.ProductID.String()
+			if item.Product != nil {
+				name = item.Product.Name
+			}
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"status":  409,
+				"success": false,
+				"message": fmt.Sprintf("Stock of %s changed since it was counted (expected %g, now %g), recount it", name, item.Expected, stock.Amount),
+			})
+		}
+
+		if item.Variance == 0 {
+			continue
+		}
+
+		if err := adjustStock(tx, stockTake.WarehouseID, item.ProductID, item.Variance); err != nil {
+			var stockErr *insufficientStockError
+			if errors.As(err, &stockErr) {
+				return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+					"status":  422,
+					"success": false,
+					"message": stockErr.Error(),
+				})
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to update stock",
+			})
+		}
+
+		adjustment := model.StockAdjustment{
+			ID:          guuid.New(),
+			WarehouseID: stockTake.WarehouseID,
+			ProductID:   item.ProductID,
+			Quantity:    item.Variance,
+			Reason:      "stock_take",
+			StockTakeID: &stockTake.ID,
+			CreatedByID: user.ID,
+		}
+		if err := tx.Create(&adjustment).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to post adjustment",
+			})
+		}
+	}
+
+	now := time.Now()
+	stockTake.Status = model.StockTakeApproved
+	stockTake.ApprovedByID = &user.ID
+	stockTake.ApprovedAt = &now
+	if err := tx.Model(&stockTake).Select("status", "approved_by_id", "approved_at").Updates(&stockTake).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to approve stock-take",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditUpdate, "stock_take", stockTake.ID, before, stockTake)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Stock-take approved successfully",
+		"data":    stockTake,
+	})
+}
+
+// CancelStockTake Отменить инвентаризацию
+//
+//	@Summary		Отменить инвентаризацию
+//	@Description	Закрывает инвентаризацию без изменения остатков
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID инвентаризации"
+//	@Success		200	{object}	model.StockTake	"Инвентаризация отменена"
+//	@Failure		400	{object}	APIError		"Инвентаризация уже закрыта"
+//	@Failure		404	{object}	APIError		"Инвентаризация не найдена"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/warehouse/stocktakes/{id}/cancel [post]
+func CancelStockTake(c *fiber.Ctx) error {
+	db := database.DB
+
+	stockTake, err := loadStockTake(db, c.Params("id"))
+	if err != nil {
+		return stockTakeErrorResponse(c, err)
+	}
+
+	if stockTake.Status != model.StockTakeOpen {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Stock-take is already closed",
+		})
+	}
+
+	before := stockTake
+	stockTake.Status = model.StockTakeCancelled
+	if err := db.Model(&stockTake).Update("status", stockTake.Status).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to cancel stock-take",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "stock_take", stockTake.ID, before, stockTake)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Stock-take cancelled",
+		"data":    stockTake,
+	})
+}
+
+type stockTakeVarianceRow struct {
+	Product  string
+	Unit     string
+	Expected float64
+	Counted  string
+	Variance float64
+	Value    float64
+}
+
+// ExportStockTakeVariance Отчёт о расхождениях инвентаризации
+//
+//	@Summary		Отчёт о расхождениях инвентаризации
+//	@Description	Выгружает строки инвентаризации в Excel: ожидаемый и фактический остаток, расхождение в количестве и в деньгах
+//	@Tags			Exports
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			id	path		string					true	"ID инвентаризации"
+//	@Success		200	{file}		file					"Excel-файл с расхождениями"
+//	@Failure		404	{object}	map[string]interface{}	"Инвентаризация не найдена"
+//	@Router			/exports/stocktakes/{id} [get]
+func ExportStockTakeVariance(ctx *fiber.Ctx) error {
+	stockTake, err := loadStockTake(database.DB, ctx.Params("id"))
+	if err != nil {
+		return stockTakeErrorResponse(ctx, err)
+	}
+
+	rows := make([]stockTakeVarianceRow, 0, len(stockTake.Items))
+	for _, item := range stockTake.Items {
+		row := stockTakeVarianceRow{Expected: item.Expected, Variance: item.Variance}
+		if item.Product != nil {
+			row.Product = item.Product.Name
+			row.Unit = item.Product.Unit
+			row.Value = item.Variance * item.Product.Price
+		}
+		if item.Counted != nil {
+			row.Counted = fmt.Sprintf("%g", *item.Counted)
+		}
+		rows = append(rows, row)
+	}
+
+	headers := []string{"Product", "Unit", "Expected", "Counted", "Variance", "Variance value"}
+	fields := []string{"Product", "Unit", "Expected", "Counted", "Variance", "Value"}
+	return utils.ExportDataToExcel(ctx, rows, headers, fields, fmt.Sprintf("stocktake-%s.xlsx", stockTake.ID))
+}
+
+var errInvalidStockTakeID = errors.New("invalid stock-take id")
+
+// loadStockTake загружает инвентаризацию со строками и продуктами.
+func loadStockTake(db *gorm.DB, rawID string) (model.StockTake, error) {
+	var stockTake model.StockTake
+
+	id, err := guuid.Parse(rawID)
+	if err != nil {
+		return stockTake, errInvalidStockTakeID
+	}
+
+	err = db.Preload("Items.Product", unscoped).First(&stockTake, "id = ?", id).Error
+	return stockTake, err
+}
+
+func stockTakeErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidStockTakeID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Stock-take ID",
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Stock-take not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
@@ -0,0 +1,325 @@
+package handlers
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UpdateSupplierRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=2,max=100"`
+	ContactInfo *string `json:"contactInfo" validate:"omitempty"`
+	Address     *string `json:"address" validate:"omitempty"`
+	Note        *string `json:"note" validate:"omitempty"`
+}
+
+// CreateSupplier Создать поставщика
+//
+//	@Summary		Создать поставщика
+//	@Tags			Suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			supplier	body		model.Supplier	true	"Данные поставщика"
+//	@Success		201			{object}	model.Supplier	"Поставщик создан"
+//	@Failure		400			{object}	APIError		"Некорректный запрос"
+//	@Failure		422			{object}	APIError		"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError		"Ошибка сервера"
+//	@Router			/suppliers [post]
+func CreateSupplier(c *fiber.Ctx) error {
+	supplier := new(model.Supplier)
+	if err := c.BodyParser(supplier); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(supplier); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	supplier.ID = guuid.New()
+
+	db := database.DB
+	if err := db.Create(supplier).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create supplier",
+		})
+	}
+
+	recordAudit(c, db, model.AuditCreate, "supplier", supplier.ID, nil, supplier)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Supplier created successfully",
+		"data":    supplier,
+	})
+}
+
+// GetAllSuppliers Получить список поставщиков
+//
+//	@Summary		Получить список поставщиков
+//	@Tags			Suppliers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int				false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int				false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.Supplier	"Поставщики с информацией о пагинации"
+//	@Failure		500			{object}	APIError		"Ошибка сервера"
+//	@Router			/suppliers [get]
+func GetAllSuppliers(c *fiber.Ctx) error {
+	suppliers := []model.Supplier{}
+
+	response, err := utils.Paginate(database.DB, c, nil, &suppliers)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve suppliers",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetSupplierById Получить поставщика
+//
+//	@Summary		Получить поставщика
+//	@Tags			Suppliers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID поставщика"
+//	@Success		200	{object}	model.Supplier	"Поставщик"
+//	@Failure		400	{object}	APIError		"Неверный формат ID"
+//	@Failure		404	{object}	APIError		"Поставщик не найден"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/suppliers/{id} [get]
+func GetSupplierById(c *fiber.Ctx) error {
+	supplier, err := findSupplier(database.DB, c.Params("id"))
+	if err != nil {
+		return supplierErrorResponse(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    supplier,
+	})
+}
+
+// UpdateSupplier Обновить поставщика
+//
+//	@Summary		Обновить поставщика
+//	@Tags			Suppliers
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string					true	"ID поставщика"
+//	@Param			supplier	body		UpdateSupplierRequest	true	"Новые данные поставщика"
+//	@Success		200			{object}	model.Supplier			"Поставщик обновлён"
+//	@Failure		400			{object}	APIError				"Некорректный запрос"
+//	@Failure		404			{object}	APIError				"Поставщик не найден"
+//	@Failure		422			{object}	APIError				"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError				"Ошибка сервера"
+//	@Router			/suppliers/{id} [patch]
+func UpdateSupplier(c *fiber.Ctx) error {
+	var body UpdateSupplierRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	db := database.DB
+	supplier, err := findSupplier(db, c.Params("id"))
+	if err != nil {
+		return supplierErrorResponse(c, err)
+	}
+
+	before := supplier
+
+	if body.Name != nil {
+		supplier.Name = *body.Name
+	}
+	if body.ContactInfo != nil {
+		supplier.ContactInfo = *body.ContactInfo
+	}
+	if body.Address != nil {
+		supplier.Address = *body.Address
+	}
+	if body.Note != nil {
+		supplier.Note = *body.Note
+	}
+
+	if err := db.Save(&supplier).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update supplier",
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "supplier", supplier.ID, before, supplier)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Supplier updated successfully",
+		"data":    supplier,
+	})
+}
+
+// DeleteSupplier Удалить поставщика
+//
+//	@Summary		Удалить поставщика
+//	@Description	Удаляет поставщика. Заказы поставщику и история цен сохраняются.
+//	@Tags			Suppliers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID поставщика"
+//	@Success		200	{object}	model.Supplier	"Поставщик удалён"
+//	@Failure		400	{object}	APIError		"Неверный формат ID"
+//	@Failure		404	{object}	APIError		"Поставщик не найден"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/suppliers/{id} [delete]
+func DeleteSupplier(c *fiber.Ctx) error {
+	db := database.DB
+	supplier, err := findSupplier(db, c.Params("id"))
+	if err != nil {
+		return supplierErrorResponse(c, err)
+	}
+
+	if err := db.Delete(&supplier).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to delete supplier",
+		})
+	}
+
+	recordAudit(c, db, model.AuditDelete, "supplier", supplier.ID, supplier, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Supplier was removed",
+		"data":    supplier,
+	})
+}
+
+// GetSupplierPrices Цены поставщика
+//
+//	@Summary		Цены поставщика
+//	@Description	Без productId возвращает текущую закупочную цену каждого продукта поставщика, с productId — историю цен на продукт
+//	@Tags			Suppliers
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string					true	"ID поставщика"
+//	@Param			productId	query		string					false	"ID продукта для истории цен"
+//	@Success		200			{array}		model.SupplierPrice		"Цены поставщика"
+//	@Failure		400			{object}	APIError				"Неверный формат ID"
+//	@Failure		404			{object}	APIError				"Поставщик не найден"
+//	@Failure		500			{object}	APIError				"Ошибка сервера"
+//	@Router			/suppliers/{id}/prices [get]
+func GetSupplierPrices(c *fiber.Ctx) error {
+	db := database.DB
+	supplier, err := findSupplier(db, c.Params("id"))
+	if err != nil {
+		return supplierErrorResponse(c, err)
+	}
+
+	prices := []model.SupplierPrice{}
+	query := db.Preload("Product", unscoped).Where("supplier_id = ?", supplier.ID)
+
+	if productID := c.Query("productId"); productID != "" {
+		id, err := guuid.Parse(productID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"success": false,
+				"message": "Invalid productId format",
+			})
+		}
+		query = query.Where("product_id = ?", id).Order("created_at desc")
+	} else {
+		// Последняя цена по каждому продукту
+		query = query.Select("DISTINCT ON (product_id) *").Order("product_id, created_at desc")
+	}
+
+	if err := query.Find(&prices).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve supplier prices",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "success",
+		"data":    prices,
+	})
+}
+
+var errInvalidSupplierID = errors.New("invalid supplier id")
+
+func findSupplier(db *gorm.DB, rawID string) (model.Supplier, error) {
+	var supplier model.Supplier
+
+	id, err := guuid.Parse(rawID)
+	if err != nil {
+		return supplier, errInvalidSupplierID
+	}
+
+	err = db.First(&supplier, "id = ?", id).Error
+	return supplier, err
+}
+
+func supplierErrorResponse(c *fiber.Ctx, err error) error {
+	if errors.Is(err, errInvalidSupplierID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Supplier ID",
+		})
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"success": false,
+			"message": "Supplier not found",
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Internal Server Error",
+	})
+}
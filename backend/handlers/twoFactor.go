@@ -0,0 +1,500 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+const (
+	// Количество резервных кодов, выдаваемых при подключении 2FA
+	recoveryCodesCount = 10
+	// Попыток ввода кода по одному промежуточному токену; дальше нужен новый вход с паролем
+	maxTwoFactorAttempts = 5
+)
+
+type TwoFactorLoginRequest struct {
+	PreAuthToken string `json:"preAuthToken" validate:"required"`
+	Code         string `json:"code" example:"123456"`
+	RecoveryCode string `json:"recoveryCode" example:"a1b2c-3d4e5"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" example:"123456"`
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	ProvisioningURI string `json:"provisioningUri" example:"otpauth://totp/CRM:admin?secret=JBSWY3DPEHPK3PXP&issuer=CRM"`
+}
+
+type TwoFactorVerifyResponse struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+	Token         string   `json:"token,omitempty" example:"JWTOKEN"`
+}
+
+// LoginTwoFactor Второй шаг авторизации
+//
+//	@Summary		Второй шаг авторизации
+//	@Description	Принимает промежуточный токен из /login и код из приложения-аутентификатора (или резервный код) и возвращает JWT-токен.
+//	@Description	По одному промежуточному токену даётся 5 попыток; после них нужно снова войти с паролем через /login.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		TwoFactorLoginRequest	true	"Промежуточный токен и код"
+//	@Success		200		{object}	LoginResponse			"Успешная авторизация, возвращается JWT-токен"
+//	@Failure		400		{object}	APIError				"Некорректный формат JSON"
+//	@Failure		401		{object}	APIError				"Неверный код или истёкший токен"
+//	@Failure		429		{object}	APIError				"Превышено число попыток, нужен новый вход с паролем"
+//	@Failure		500		{object}	APIError				"Ошибка при генерации токена"
+//	@Router			/login/2fa [post]
+func LoginTwoFactor(c *fiber.Ctx) error {
+	db := database.DB
+	json := new(TwoFactorLoginRequest)
+
+	if err := c.BodyParser(json); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid JSON",
+			"success": false,
+		})
+	}
+
+	claims, err := utils.ParseJWT(json.PreAuthToken)
+	if err != nil || claims.Scope != auth.ScopeTwoFactorLogin {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  401,
+			"message": "Invalid or expired pre-auth token",
+			"success": false,
+		})
+	}
+
+	found := model.User{}
+	err = db.First(&found, "id = ?", claims.ID).Error
+	if err != nil || !found.TOTPEnabled || !found.Active || claims.Id == "" || claims.Id != found.TwoFactorTokenID {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  401,
+			"message": "Invalid or expired pre-auth token",
+			"success": false,
+		})
+	}
+
+	// Попытка занимается одним UPDATE, поэтому параллельные запросы не обходят лимит
+	attempt := db.Model(&model.User{}).
+		Where("id = ? AND two_factor_token_id = ? AND two_factor_attempts < ?", found.ID, claims.Id, maxTwoFactorAttempts).
+		UpdateColumn("two_factor_attempts", gorm.Expr("two_factor_attempts + 1"))
+	if attempt.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to update user",
+			"success": false,
+		})
+	}
+	if attempt.RowsAffected == 0 {
+		return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+			"status":  429,
+			"message": "Too many two-factor attempts, log in with your password again",
+			"success": false,
+		})
+	}
+
+	switch {
+	case json.Code != "":
+		counter, ok := utils.ValidateTOTP(found.TOTPSecret, json.Code, found.TOTPLastCounter, time.Now())
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":  401,
+				"message": "Invalid two-factor code",
+				"success": false,
+			})
+		}
+		found.TOTPLastCounter = counter
+	case json.RecoveryCode != "":
+		rest, ok := utils.UseRecoveryCode(found.RecoveryCodes, json.RecoveryCode)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"status":  401,
+				"message": "Invalid recovery code",
+				"success": false,
+			})
+		}
+		found.RecoveryCodes = rest
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Either code or recoveryCode is required",
+			"success": false,
+		})
+	}
+
+	// Промежуточный токен одноразовый: после успешного входа он больше не принимается. Условие на
+	// токен не даёт двум параллельным запросам с одним кодом получить по JWT
+	found.TwoFactorTokenID = ""
+	found.TwoFactorAttempts = 0
+	consume := db.Model(&found).
+		Where("two_factor_token_id = ?", claims.Id).
+		Select("totp_last_counter", "recovery_codes", "two_factor_token_id", "two_factor_attempts").
+		Updates(&found)
+	if consume.Error != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to update user",
+			"success": false,
+		})
+	}
+	if consume.RowsAffected != 1 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  401,
+			"message": "Invalid or expired pre-auth token",
+			"success": false,
+		})
+	}
+
+	tokenString, err := utils.GenerateJWT(found)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not generate token",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":            200,
+		"message":           "success",
+		"success":           true,
+		"token":             tokenString,
+		"recoveryCodesLeft": len(found.RecoveryCodes),
+	})
+}
+
+// EnrollTwoFactor Начать подключение 2FA
+//
+//	@Summary		Начать подключение 2FA
+//	@Description	Генерирует новый секрет TOTP и ссылку otpauth:// для QR-кода. 2FA включается только после подтверждения кодом через /2fa/verify.
+//	@Tags			Auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	TwoFactorEnrollResponse	"Секрет и ссылка для приложения-аутентификатора"
+//	@Failure		404	{object}	APIError				"Пользователь не найден"
+//	@Failure		409	{object}	APIError				"2FA уже подключена"
+//	@Failure		500	{object}	APIError				"Ошибка на сервере"
+//	@Router			/2fa/enroll [post]
+func EnrollTwoFactor(c *fiber.Ctx) error {
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"message": "User not found",
+			"success": false,
+		})
+	}
+
+	if user.TOTPEnabled {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status":  409,
+			"message": "Two-factor authentication is already enabled",
+			"success": false,
+		})
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to generate secret",
+			"success": false,
+		})
+	}
+
+	if err := database.DB.Model(&user).Updates(map[string]interface{}{
+		"totp_secret":       secret,
+		"totp_last_counter": 0,
+	}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to save secret",
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"message": "success",
+		"success": true,
+		"data": TwoFactorEnrollResponse{
+			Secret:          secret,
+			ProvisioningURI: utils.TOTPProvisioningURI(secret, user.Username),
+		},
+	})
+}
+
+// VerifyTwoFactor Подтвердить подключение 2FA
+//
+//	@Summary		Подтвердить подключение 2FA
+//	@Description	Проверяет первый код из приложения, включает 2FA и возвращает резервные коды (показываются один раз). Если запрос сделан с промежуточным токеном подключения, дополнительно возвращается JWT-токен.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		TwoFactorCodeRequest	true	"Код из приложения-аутентификатора"
+//	@Success		200		{object}	TwoFactorVerifyResponse	"2FA включена"
+//	@Failure		400		{object}	APIError				"Подключение не начато"
+//	@Failure		401		{object}	APIError				"Неверный код"
+//	@Failure		500		{object}	APIError				"Ошибка на сервере"
+//	@Router			/2fa/verify [post]
+func VerifyTwoFactor(c *fiber.Ctx) error {
+	claims := c.Locals("user").(*auth.Claims)
+
+	json := new(TwoFactorCodeRequest)
+	if err := c.BodyParser(json); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid JSON",
+			"success": false,
+		})
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"message": "User not found",
+			"success": false,
+		})
+	}
+
+	if user.TOTPEnabled || user.TOTPSecret == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Two-factor enrollment has not been started",
+			"success": false,
+		})
+	}
+
+	counter, ok := utils.ValidateTOTP(user.TOTPSecret, json.Code, user.TOTPLastCounter, time.Now())
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  401,
+			"message": "Invalid two-factor code",
+			"success": false,
+		})
+	}
+
+	plain, hashed, err := utils.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to generate recovery codes",
+			"success": false,
+		})
+	}
+
+	before := user
+	user.TOTPEnabled = true
+	user.TOTPLastCounter = counter
+	user.RecoveryCodes = hashed
+	if err := database.DB.Model(&user).Select("totp_enabled", "totp_last_counter", "recovery_codes").Updates(&user).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to enable two-factor authentication",
+			"success": false,
+		})
+	}
+
+	recordAudit(c, database.DB, model.AuditUpdate, "user", user.ID, before, user)
+
+	response := TwoFactorVerifyResponse{RecoveryCodes: plain}
+
+	// Подключение во время входа: сразу выдаём полноценный токен
+	if claims.Scope == auth.ScopeTwoFactorEnroll {
+		response.Token, err = utils.GenerateJWT(user)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Could not generate token",
+			})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"message": "Two-factor authentication enabled",
+		"success": true,
+		"data":    response,
+	})
+}
+
+// DisableTwoFactor Отключить 2FA
+//
+//	@Summary		Отключить 2FA
+//	@Description	Отключает 2FA после проверки текущего кода. Недоступно, если 2FA обязательна для роли пользователя.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		TwoFactorCodeRequest	true	"Код из приложения-аутентификатора"
+//	@Success		200		{object}	ResponseSuccess			"2FA отключена"
+//	@Failure		400		{object}	APIError				"2FA не подключена"
+//	@Failure		401		{object}	APIError				"Неверный код"
+//	@Failure		403		{object}	APIError				"2FA обязательна для роли"
+//	@Failure		500		{object}	APIError				"Ошибка на сервере"
+//	@Router			/2fa/disable [post]
+func DisableTwoFactor(c *fiber.Ctx) error {
+	json := new(TwoFactorCodeRequest)
+	if err := c.BodyParser(json); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid JSON",
+			"success": false,
+		})
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"message": "User not found",
+			"success": false,
+		})
+	}
+
+	if utils.TwoFactorRequired(user.Role) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"message": "Two-factor authentication is mandatory for this role",
+			"success": false,
+		})
+	}
+
+	if !user.TOTPEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Two-factor authentication is not enabled",
+			"success": false,
+		})
+	}
+
+	if _, ok := utils.ValidateTOTP(user.TOTPSecret, json.Code, user.TOTPLastCounter, time.Now()); !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  401,
+			"message": "Invalid two-factor code",
+			"success": false,
+		})
+	}
+
+	if err := database.DB.Model(&user).Updates(map[string]interface{}{
+		"totp_enabled":      false,
+		"totp_secret":       "",
+		"totp_last_counter": 0,
+		"recovery_codes":    nil,
+	}).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to disable two-factor authentication",
+			"success": false,
+		})
+	}
+
+	after := user
+	after.TOTPEnabled = false
+	recordAudit(c, database.DB, model.AuditUpdate, "user", user.ID, user, after)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"message": "Two-factor authentication disabled",
+		"success": true,
+	})
+}
+
+// RegenerateRecoveryCodes Перевыпустить резервные коды
+//
+//	@Summary		Перевыпустить резервные коды
+//	@Description	Заменяет все резервные коды новыми после проверки текущего кода из приложения
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		TwoFactorCodeRequest	true	"Код из приложения-аутентификатора"
+//	@Success		200		{object}	TwoFactorVerifyResponse	"Новые резервные коды"
+//	@Failure		400		{object}	APIError				"2FA не подключена"
+//	@Failure		401		{object}	APIError				"Неверный код"
+//	@Failure		500		{object}	APIError				"Ошибка на сервере"
+//	@Router			/2fa/recovery-codes [post]
+func RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	json := new(TwoFactorCodeRequest)
+	if err := c.BodyParser(json); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid JSON",
+			"success": false,
+		})
+	}
+
+	user, err := currentUser(c)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"status":  404,
+			"message": "User not found",
+			"success": false,
+		})
+	}
+
+	if !user.TOTPEnabled {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Two-factor authentication is not enabled",
+			"success": false,
+		})
+	}
+
+	counter, ok := utils.ValidateTOTP(user.TOTPSecret, json.Code, user.TOTPLastCounter, time.Now())
+	if !ok {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+			"status":  401,
+			"message": "Invalid two-factor code",
+			"success": false,
+		})
+	}
+
+	plain, hashed, err := utils.GenerateRecoveryCodes(recoveryCodesCount)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to generate recovery codes",
+			"success": false,
+		})
+	}
+
+	user.TOTPLastCounter = counter
+	user.RecoveryCodes = hashed
+	if err := database.DB.Model(&user).Select("totp_last_counter", "recovery_codes").Updates(&user).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to save recovery codes",
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"message": "success",
+		"success": true,
+		"data":    TwoFactorVerifyResponse{RecoveryCodes: plain},
+	})
+}
+
+// currentUser загружает из БД пользователя, которому принадлежит токен запроса.
+func currentUser(c *fiber.Ctx) (model.User, error) {
+	claims := c.Locals("user").(*auth.Claims)
+
+	user := model.User{}
+	err := database.DB.First(&user, "id = ?", claims.ID).Error
+	return user, err
+}
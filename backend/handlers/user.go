@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"backend/auth"
 	"backend/database"
 	"backend/model"
 	"backend/utils"
+	"errors"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
@@ -17,6 +20,8 @@ type CreateUserRequest struct {
 	Password string     `json:"password" validate:"required,min=4,max=100"`
 	Image    string     `json:"image" validate:"omitempty,min=5"`
 	Role     model.Role `json:"role" validate:"required,oneof=admin manager seller"`
+	// Склад, к которому привязан менеджер
+	WarehouseID *guuid.UUID `json:"warehouseId" validate:"omitempty"`
 }
 
 // UpdateUserRequest структура для обновления пользователя
@@ -25,6 +30,8 @@ type UpdateUserRequest struct {
 	Password *string     `json:"password" validate:"required,min=4,max=100"`
 	Image    *string     `json:"image" validate:"omitempty"`
 	Role     *model.Role `json:"role" validate:"required,oneof=admin manager seller"`
+	// Склад, к которому привязан менеджер
+	WarehouseID *guuid.UUID `json:"warehouseId" validate:"omitempty"`
 }
 
 type APIError struct {
@@ -72,6 +79,10 @@ func CreateUser(c *fiber.Ctx) error {
 		Password: utils.HashAndSalt([]byte(json.Password)),
 		ID:       guuid.New(),
 		Role:     json.Role,
+		Active:   true,
+	}
+	if json.Role == model.Manager {
+		user.WarehouseID = json.WarehouseID
 	}
 
 	err = db.Create(&user).Error
@@ -80,6 +91,8 @@ func CreateUser(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusInternalServerError)
 	}
 
+	recordAudit(c, db, model.AuditCreate, "user", user.ID, nil, user)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"success": true,
 		"status":  200,
@@ -138,13 +151,22 @@ func GetUserById(c *fiber.Ctx) error {
 // @Produce		json
 // @Param			page	query		int			false	"Номер страницы (по умолчанию 1)"
 // @Param			size	query		int			false	"Размер страницы (по умолчанию 10)"
+// @Param			active	query		bool		false	"Фильтр по активности (true, false)"
 // @Success		200		{array}		model.User	"Список пользователей"
 // @Failure		500		{object}	APIError	"Ошибка на сервере"
 // @Router			/users [get]
 func GetUsers(c *fiber.Ctx) error {
 	Users := []model.User{}
 
-	respons, err := utils.Paginate(database.DB, c, map[string]interface{}{}, &Users)
+	filter := map[string]interface{}{}
+	switch c.Query("active") {
+	case "true":
+		filter["active"] = true
+	case "false":
+		filter["active"] = false
+	}
+
+	respons, err := utils.Paginate(database.DB, c, filter, &Users)
 
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -162,6 +184,7 @@ func GetUsers(c *fiber.Ctx) error {
 // @Tags			Users
 // @Accept			json
 // @Produce		json
+// @Security		BearerAuth
 // @Param			id		path		string				true	"ID пользователя"
 // @Param			data	body		UpdateUserRequest	true	"Обновляемые данные пользователя"
 // @Success		200		{object}	model.User			"Пользователь успешно обновлён"
@@ -169,7 +192,7 @@ func GetUsers(c *fiber.Ctx) error {
 // @Failure		404		{object}	APIError			"Пользователь не найден"
 // @Failure		422		{object}	APIError			"Ошибка валидации данных"
 // @Failure		500		{object}	APIError			"Ошибка на сервере"
-// @Router			/users/{id} [put]
+// @Router			/users/{id} [patch]
 func UpdateUser(c *fiber.Ctx) error {
 	db := database.DB
 	param := c.Params("id")
@@ -216,12 +239,17 @@ func UpdateUser(c *fiber.Ctx) error {
 		})
 	}
 
+	before := found
+
 	if json.Username != nil {
 		found.Username = *json.Username
 	}
 	if json.Image != nil {
 		found.Image = *json.Image
 	}
+	if json.WarehouseID != nil {
+		found.WarehouseID = json.WarehouseID
+	}
 	if json.Password != nil {
 		hashedPassword := utils.HashAndSalt([]byte(*json.Password))
 		found.Password = hashedPassword
@@ -238,6 +266,8 @@ func UpdateUser(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, db, model.AuditUpdate, "user", found.ID, before, found)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  200,
 		"message": "User updated successfully",
@@ -247,15 +277,19 @@ func UpdateUser(c *fiber.Ctx) error {
 }
 
 // @Summary		Удалить пользователя
-// @Description	Удаляет пользователя из системы по его ID
+// @Description	Мягко удаляет пользователя: он деактивируется и скрывается из списков, но его заказы и клиенты сохраняют ссылку на него.
+// @Description	Клиентов удаляемого пользователя можно передать другому продавцу параметром reassignTo.
 // @Tags			Users
 // @Accept			json
 // @Produce		json
-// @Param			id	path		string		true	"ID пользователя"
-// @Success		200	{object}	model.User	"Пользователь успешно удалён"
-// @Failure		400	{object}	APIError	"Неверный формат ID"
-// @Failure		404	{object}	APIError	"Пользователь не найден"
-// @Failure		500	{object}	APIError	"Ошибка на сервере"
+// @Security		BearerAuth
+// @Param			id			path		string		true	"ID пользователя"
+// @Param			reassignTo	query		string		false	"ID продавца, которому передаются клиенты"
+// @Success		200			{object}	model.User	"Пользователь успешно удалён"
+// @Failure		400			{object}	APIError	"Неверный формат ID"
+// @Failure		404			{object}	APIError	"Пользователь не найден"
+// @Failure		422			{object}	APIError	"Недопустимый получатель клиентов"
+// @Failure		500			{object}	APIError	"Ошибка на сервере"
 // @Router			/users/{id} [delete]
 func DeleteUser(c *fiber.Ctx) error {
 	db := database.DB
@@ -270,6 +304,19 @@ func DeleteUser(c *fiber.Ctx) error {
 		})
 	}
 
+	var reassignTo *guuid.UUID
+	if raw := c.Query("reassignTo"); raw != "" {
+		target, err := guuid.Parse(raw)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"message": "Invalid reassignTo format",
+				"success": false,
+			})
+		}
+		reassignTo = &target
+	}
+
 	var found model.User
 	err = db.First(&found, "id = ?", id).Error
 	if err == gorm.ErrRecordNotFound {
@@ -280,9 +327,19 @@ func DeleteUser(c *fiber.Ctx) error {
 		})
 	}
 
-	err = db.Delete(&model.User{}, "id = ?", id).Error
+	tx := db.Begin()
+	defer tx.Rollback()
 
-	if err != nil {
+	before := found
+	if status, err := deactivateUser(tx, &found, reassignTo); err != nil {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  status,
+			"message": err.Error(),
+			"success": false,
+		})
+	}
+
+	if err := tx.Delete(&found).Error; err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"status":  500,
 			"message": "Failed to delete user",
@@ -290,6 +347,16 @@ func DeleteUser(c *fiber.Ctx) error {
 		})
 	}
 
+	recordAudit(c, tx, model.AuditDelete, "user", found.ID, before, nil)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to commit transaction",
+			"success": false,
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  200,
 		"message": "User was removed",
@@ -298,6 +365,198 @@ func DeleteUser(c *fiber.Ctx) error {
 	})
 }
 
+// DeactivateUserRequest параметры деактивации пользователя
+type DeactivateUserRequest struct {
+	ReassignTo *guuid.UUID `json:"reassignTo" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// @Summary		Деактивировать пользователя
+// @Description	Запрещает пользователю вход в систему. История заказов и статистика продаж продолжают ссылаться на него.
+// @Description	Если указан reassignTo, все клиенты пользователя передаются этому продавцу.
+// @Tags			Users
+// @Accept			json
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id		path		string					true	"ID пользователя"
+// @Param			data	body		DeactivateUserRequest	false	"Получатель клиентов"
+// @Success		200		{object}	model.User				"Пользователь деактивирован"
+// @Failure		400		{object}	APIError				"Неверный формат ID или JSON"
+// @Failure		404		{object}	APIError				"Пользователь не найден"
+// @Failure		422		{object}	APIError				"Недопустимый получатель клиентов"
+// @Failure		500		{object}	APIError				"Ошибка на сервере"
+// @Router			/users/{id}/deactivate [post]
+func DeactivateUser(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid ID format",
+			"success": false,
+		})
+	}
+
+	var json DeactivateUserRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&json); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"status":  400,
+				"message": "Invalid JSON",
+				"success": false,
+			})
+		}
+	}
+
+	claims := c.Locals("user").(*auth.Claims)
+	if claims.ID == id {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"message": "You cannot deactivate yourself",
+			"success": false,
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var found model.User
+	if err := tx.First(&found, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"message": "User not found",
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Database error",
+			"success": false,
+		})
+	}
+
+	before := found
+	if status, err := deactivateUser(tx, &found, json.ReassignTo); err != nil {
+		return c.Status(status).JSON(fiber.Map{
+			"status":  status,
+			"message": err.Error(),
+			"success": false,
+		})
+	}
+
+	recordAudit(c, tx, model.AuditUpdate, "user", found.ID, before, found)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to commit transaction",
+			"success": false,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"message": "User deactivated",
+		"success": true,
+		"data":    found,
+	})
+}
+
+// @Summary		Активировать пользователя
+// @Description	Снова разрешает деактивированному пользователю вход в систему
+// @Tags			Users
+// @Produce		json
+// @Security		BearerAuth
+// @Param			id	path		string		true	"ID пользователя"
+// @Success		200	{object}	model.User	"Пользователь активирован"
+// @Failure		400	{object}	APIError	"Неверный формат ID"
+// @Failure		404	{object}	APIError	"Пользователь не найден"
+// @Failure		500	{object}	APIError	"Ошибка на сервере"
+// @Router			/users/{id}/activate [post]
+func ActivateUser(c *fiber.Ctx) error {
+	db := database.DB
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"message": "Invalid ID format",
+			"success": false,
+		})
+	}
+
+	var found model.User
+	if err := db.First(&found, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"message": "User not found",
+				"success": false,
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Database error",
+			"success": false,
+		})
+	}
+
+	before := found
+	found.Active = true
+	found.DeactivatedAt = nil
+	if err := db.Model(&found).Select("active", "deactivated_at").Updates(&found).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"message": "Failed to activate user",
+			"success": false,
+		})
+	}
+
+	recordAudit(c, db, model.AuditUpdate, "user", found.ID, before, found)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"message": "User activated",
+		"success": true,
+		"data":    found,
+	})
+}
+
+// deactivateUser помечает пользователя неактивным и при необходимости передаёт его клиентов
+// другому активному продавцу. Возвращает HTTP-статус для ответа в случае ошибки.
+func deactivateUser(tx *gorm.DB, user *model.User, reassignTo *guuid.UUID) (int, error) {
+	if reassignTo != nil {
+		if *reassignTo == user.ID {
+			return fiber.StatusUnprocessableEntity, errors.New("Clients cannot be reassigned to the same user")
+		}
+
+		var target model.User
+		if err := tx.First(&target, "id = ?", *reassignTo).Error; err != nil {
+			return fiber.StatusUnprocessableEntity, errors.New("User to reassign clients to not found")
+		}
+		if !target.Active || target.Role != model.Seller && target.Role != model.AdminRole {
+			return fiber.StatusUnprocessableEntity, errors.New("Clients can only be reassigned to an active seller or admin")
+		}
+
+		if err := tx.Model(&model.Client{}).
+			Where("salesperson_id = ?", user.ID).
+			Update("salesperson_id", target.ID).Error; err != nil {
+			return fiber.StatusInternalServerError, errors.New("Failed to reassign clients")
+		}
+	}
+
+	now := time.Now()
+	user.Active = false
+	user.DeactivatedAt = &now
+	if err := tx.Model(user).Select("active", "deactivated_at").Updates(user).Error; err != nil {
+		return fiber.StatusInternalServerError, errors.New("Failed to deactivate user")
+	}
+	return 0, nil
+}
+
+// unscoped используется в Preload, чтобы подтягивать и удалённых пользователей (например, автора старого заказа).
+func unscoped(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
+
 // @Summary		Поиск пользователей
 // @Description	Ищет пользователей по заданному запросу
 // @Tags			Users
@@ -319,11 +578,8 @@ func SearchUsers(c *fiber.Ctx) error {
 
 	var users []model.User
 
-	err := database.DB.Raw(`
-        SELECT *
-        FROM users
-        WHERE search_vector @@ plainto_tsquery('pg_catalog.russian', ?)
-    `, query).Scan(&users).Error
+	// Find, а не Raw: так применяется soft delete и удалённые пользователи не попадают в выдачу
+	err := database.DB.Where("search_vector @@ plainto_tsquery('pg_catalog.russian', ?)", query).Find(&users).Error
 
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -0,0 +1,729 @@
+package handlers
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type UpdateWarehouseRequest struct {
+	Name      *string `json:"name" validate:"omitempty,min=2,max=100"`
+	Address   *string `json:"address" validate:"omitempty"`
+	IsDefault *bool   `json:"isDefault" validate:"omitempty"`
+}
+
+// CreateWarehouse Создать склад
+//
+//	@Summary		Создать склад
+//	@Description	Создаёт новый склад или магазин
+//	@Tags			Warehouses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			warehouse	body		model.Warehouse	true	"Данные склада"
+//	@Success		201			{object}	model.Warehouse	"Склад создан"
+//	@Failure		400			{object}	APIError		"Некорректный запрос"
+//	@Failure		422			{object}	APIError		"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError		"Ошибка сервера"
+//	@Router			/warehouses [post]
+func CreateWarehouse(c *fiber.Ctx) error {
+	warehouse := new(model.Warehouse)
+	if err := c.BodyParser(warehouse); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(warehouse); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	warehouse.ID = guuid.New()
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	if warehouse.IsDefault {
+		if err := tx.Model(&model.Warehouse{}).Where("is_default = ?", true).Update("is_default", false).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Could not create warehouse",
+			})
+		}
+	}
+
+	if err := tx.Create(warehouse).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Could not create warehouse",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditCreate, "warehouse", warehouse.ID, nil, warehouse)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Warehouse created successfully",
+		"data":    warehouse,
+	})
+}
+
+// GetAllWarehouses Получить список складов
+//
+//	@Summary		Получить список складов
+//	@Tags			Warehouses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page		query		int				false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int				false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.Warehouse	"Список складов с информацией о пагинации"
+//	@Failure		500			{object}	APIError		"Ошибка сервера"
+//	@Router			/warehouses [get]
+func GetAllWarehouses(c *fiber.Ctx) error {
+	warehouses := []model.Warehouse{}
+
+	response, err := utils.Paginate(database.DB, c, nil, &warehouses)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve warehouses",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetWarehouseStock Остатки на складе
+//
+//	@Summary		Остатки на складе
+//	@Description	Возвращает остатки всех продуктов на складе
+//	@Tags			Warehouses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string					true	"ID склада"
+//	@Param			page		query		int						false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int						false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.WarehouseStock	"Остатки с информацией о пагинации"
+//	@Failure		400			{object}	APIError				"Неверный формат ID"
+//	@Failure		500			{object}	APIError				"Ошибка сервера"
+//	@Router			/warehouses/{id}/stock [get]
+func GetWarehouseStock(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Warehouse ID",
+		})
+	}
+
+	stocks := []model.WarehouseStock{}
+	response, err := utils.Paginate(database.DB.Preload("Product"), c, map[string]interface{}{"warehouse_id": id}, &stocks)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve warehouse stock",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// UpdateWarehouse Обновить склад
+//
+//	@Summary		Обновить склад
+//	@Description	Обновляет название, адрес или делает склад основным
+//	@Tags			Warehouses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string					true	"ID склада"
+//	@Param			warehouse	body		UpdateWarehouseRequest	true	"Новые данные склада"
+//	@Success		200			{object}	model.Warehouse			"Склад обновлён"
+//	@Failure		400			{object}	APIError				"Некорректный запрос"
+//	@Failure		404			{object}	APIError				"Склад не найден"
+//	@Failure		422			{object}	APIError				"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError				"Ошибка сервера"
+//	@Router			/warehouses/{id} [patch]
+func UpdateWarehouse(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Warehouse ID",
+		})
+	}
+
+	var body UpdateWarehouseRequest
+	if err := c.BodyParser(&body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request body",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var warehouse model.Warehouse
+	if err := tx.First(&warehouse, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Warehouse not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
+	before := warehouse
+
+	if body.Name != nil {
+		warehouse.Name = *body.Name
+	}
+	if body.Address != nil {
+		warehouse.Address = *body.Address
+	}
+	if body.IsDefault != nil {
+		// Снять признак основного склада можно только назначив основным другой склад
+		if !*body.IsDefault && warehouse.IsDefault {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"status":  422,
+				"success": false,
+				"message": "Mark another warehouse as default instead",
+			})
+		}
+		if *body.IsDefault && !warehouse.IsDefault {
+			if err := tx.Model(&model.Warehouse{}).Where("is_default = ?", true).Update("is_default", false).Error; err != nil {
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"status":  500,
+					"success": false,
+					"message": "Failed to update warehouse",
+				})
+			}
+		}
+		warehouse.IsDefault = *body.IsDefault
+	}
+
+	if err := tx.Save(&warehouse).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update warehouse",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditUpdate, "warehouse", warehouse.ID, before, warehouse)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Warehouse updated successfully",
+		"data":    warehouse,
+	})
+}
+
+// DeleteWarehouse Удалить склад
+//
+//	@Summary		Удалить склад
+//	@Description	Удаляет пустой склад. Нельзя удалить основной склад, склад с остатками, перемещениями в пути, открытыми заказами поставщикам или инвентаризацией и склад, к которому привязаны менеджеры.
+//	@Tags			Warehouses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string			true	"ID склада"
+//	@Success		200	{object}	model.Warehouse	"Склад удалён"
+//	@Failure		400	{object}	APIError		"Неверный формат ID"
+//	@Failure		404	{object}	APIError		"Склад не найден"
+//	@Failure		409	{object}	APIError		"Склад основной, на нём есть остатки или незакрытые документы"
+//	@Failure		500	{object}	APIError		"Ошибка сервера"
+//	@Router			/warehouses/{id} [delete]
+func DeleteWarehouse(c *fiber.Ctx) error {
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid ID format",
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	// Склад блокируется, чтобы до удаления на него не успели оформить перемещение или заказ
+	var warehouse model.Warehouse
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&warehouse, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Warehouse not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
+	if warehouse.IsDefault {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"status":  409,
+			"success": false,
+			"message": "Default warehouse cannot be deleted",
+		})
+	}
+
+	// Всё, что ещё ссылается на склад и изменит его остатки или требует доступа к нему
+	conflicts := []struct {
+		query   *gorm.DB
+		message string
+	}{
+		{
+			tx.Model(&model.WarehouseStock{}).Where("warehouse_id = ? AND amount > 0", id),
+			"Warehouse still has stock. Transfer it to another warehouse first",
+		},
+		{
+			tx.Model(&model.StockTransfer{}).Where("(from_warehouse_id = ? OR to_warehouse_id = ?) AND status = ?", id, id, model.TransferInTransit),
+			"Warehouse has transfers in transit. Receive or cancel them first",
+		},
+		{
+			tx.Model(&model.PurchaseOrder{}).Where("warehouse_id = ? AND status IN ?", id, []string{model.PurchaseOrdered, model.PurchasePartiallyReceived}),
+			"Warehouse has open purchase orders. Receive or cancel them first",
+		},
+		{
+			tx.Model(&model.StockTake{}).Where("warehouse_id = ? AND status = ?", id, model.StockTakeOpen),
+			"Warehouse has an open stock-take. Approve or cancel it first",
+		},
+		{
+			tx.Model(&model.User{}).Where("warehouse_id = ?", id),
+			"Warehouse has assigned managers. Reassign them first",
+		},
+	}
+	for _, conflict := range conflicts {
+		var count int64
+		if err := conflict.query.Count(&count).Error; err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Internal Server Error",
+			})
+		}
+		if count > 0 {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"status":  409,
+				"success": false,
+				"message": conflict.message,
+			})
+		}
+	}
+
+	if err := tx.Delete(&warehouse).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to delete warehouse",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditDelete, "warehouse", warehouse.ID, warehouse, nil)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Warehouse was removed",
+		"data":    warehouse,
+	})
+}
+
+// CreateStockTransfer Создать перемещение между складами
+//
+//	@Summary		Создать перемещение между складами
+//	@Description	Списывает товар с исходного склада и переводит документ в статус "in_transit". Товар поступает на склад назначения после подтверждения получения.
+//	@Tags			warehouse
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			transfer	body		model.CreateStockTransferRequest	true	"Данные перемещения"
+//	@Success		201			{object}	model.StockTransfer					"Перемещение создано"
+//	@Failure		400			{object}	APIError							"Недостаточно товара на складе"
+//	@Failure		403			{object}	APIError							"Склад недоступен менеджеру"
+//	@Failure		404			{object}	APIError							"Склад или продукт не найден"
+//	@Failure		422			{object}	APIError							"Ошибка валидации данных"
+//	@Failure		500			{object}	APIError							"Ошибка сервера"
+//	@Router			/warehouse/transfers [post]
+func CreateStockTransfer(c *fiber.Ctx) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	body := new(model.CreateStockTransferRequest)
+	if err := c.BodyParser(body); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid request",
+		})
+	}
+
+	if err := validator.New().Struct(body); err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  422,
+			"success": false,
+			"message": err.Error(),
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	for _, warehouseID := range []guuid.UUID{body.FromWarehouseID, body.ToWarehouseID} {
+		if _, err := resolveWarehouseID(tx, &warehouseID); err != nil {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Warehouse not found",
+			})
+		}
+	}
+
+	allowed, err := warehouseAllowed(c, tx, &body.FromWarehouseID)
+	if err != nil || !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "Transfers can only be sent from your own warehouse",
+		})
+	}
+
+	transfer := model.StockTransfer{
+		ID:              guuid.New(),
+		FromWarehouseID: body.FromWarehouseID,
+		ToWarehouseID:   body.ToWarehouseID,
+		Status:          model.TransferInTransit,
+		Note:            body.Note,
+		CreatedByID:     user.ID,
+	}
+
+	for _, item := range body.Items {
+		if err := adjustStock(tx, body.FromWarehouseID, item.ProductID, -item.Quantity); err != nil {
+			return stockErrorResponse(c, err)
+		}
+		transfer.Items = append(transfer.Items, model.StockTransferItem{
+			ID:         guuid.New(),
+			TransferID: transfer.ID,
+			ProductID:  item.ProductID,
+			Quantity:   item.Quantity,
+		})
+	}
+
+	if err := tx.Create(&transfer).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to create transfer",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditCreate, "stock_transfer", transfer.ID, nil, transfer)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  201,
+		"success": true,
+		"message": "Transfer created successfully",
+		"data":    transfer,
+	})
+}
+
+// GetStockTransfers Список перемещений
+//
+//	@Summary		Список перемещений
+//	@Description	Возвращает перемещения между складами. Менеджер, привязанный к складу, видит только перемещения своего склада.
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string				false	"Статус (in_transit, received, cancelled)"
+//	@Param			page		query		int					false	"Номер страницы"	default(1)
+//	@Param			pageSize	query		int					false	"Размер страницы"	default(10)
+//	@Success		200			{array}		model.StockTransfer	"Перемещения с информацией о пагинации"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/warehouse/transfers [get]
+func GetStockTransfers(c *fiber.Ctx) error {
+	transfers := []model.StockTransfer{}
+	db := database.DB.Preload("Items.Product").Preload("FromWarehouse").Preload("ToWarehouse").Order("created_at desc")
+
+	if status := c.Query("status"); status != "" {
+		db = db.Where("status = ?", status)
+	}
+
+	user := c.Locals("user").(*auth.Claims)
+	if user.Role == model.Manager {
+		manager, err := currentUser(c)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"status":  500,
+				"success": false,
+				"message": "Failed to retrieve transfers",
+			})
+		}
+		if manager.WarehouseID != nil {
+			db = db.Where("from_warehouse_id = ? OR to_warehouse_id = ?", *manager.WarehouseID, *manager.WarehouseID)
+		}
+	}
+
+	response, err := utils.Paginate(db, c, nil, &transfers)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve transfers",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// ReceiveStockTransfer Принять перемещение
+//
+//	@Summary		Принять перемещение
+//	@Description	Оприходует товар на складе назначения и переводит документ в статус "received"
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string				true	"ID перемещения"
+//	@Success		200	{object}	model.StockTransfer	"Перемещение принято"
+//	@Failure		400	{object}	APIError			"Неверный формат UUID или статус"
+//	@Failure		403	{object}	APIError			"Склад недоступен менеджеру"
+//	@Failure		404	{object}	APIError			"Перемещение не найдено"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/warehouse/transfers/{id}/receive [post]
+func ReceiveStockTransfer(c *fiber.Ctx) error {
+	return finishStockTransfer(c, model.TransferReceived)
+}
+
+// CancelStockTransfer Отменить перемещение
+//
+//	@Summary		Отменить перемещение
+//	@Description	Возвращает товар на исходный склад и переводит документ в статус "cancelled"
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string				true	"ID перемещения"
+//	@Success		200	{object}	model.StockTransfer	"Перемещение отменено"
+//	@Failure		400	{object}	APIError			"Неверный формат UUID или статус"
+//	@Failure		403	{object}	APIError			"Склад недоступен менеджеру"
+//	@Failure		404	{object}	APIError			"Перемещение не найдено"
+//	@Failure		500	{object}	APIError			"Ошибка сервера"
+//	@Router			/warehouse/transfers/{id}/cancel [post]
+func CancelStockTransfer(c *fiber.Ctx) error {
+	return finishStockTransfer(c, model.TransferCancelled)
+}
+
+// finishStockTransfer закрывает перемещение в пути: при получении товар приходует склад назначения,
+// при отмене — возвращается на исходный склад.
+func finishStockTransfer(c *fiber.Ctx, status string) error {
+	user := c.Locals("user").(*auth.Claims)
+
+	id, err := guuid.Parse(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Invalid UUID format for Transfer ID",
+		})
+	}
+
+	tx := database.DB.Begin()
+	defer tx.Rollback()
+
+	var transfer model.StockTransfer
+	err = tx.Preload("Items").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&transfer, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"status":  404,
+				"success": false,
+				"message": "Transfer not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Internal Server Error",
+		})
+	}
+
+	if transfer.Status != model.TransferInTransit {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": "Only transfers in transit can be received or cancelled",
+		})
+	}
+
+	target := transfer.ToWarehouseID
+	if status == model.TransferCancelled {
+		target = transfer.FromWarehouseID
+	}
+
+	allowed, err := warehouseAllowed(c, tx, &target)
+	if err != nil || !allowed {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"status":  403,
+			"success": false,
+			"message": "This transfer belongs to another warehouse",
+		})
+	}
+
+	for _, item := range transfer.Items {
+		if err := adjustStock(tx, target, item.ProductID, item.Quantity); err != nil {
+			return stockErrorResponse(c, err)
+		}
+	}
+
+	before := transfer
+	now := time.Now()
+	transfer.Status = status
+	transfer.ReceivedByID = &user.ID
+	transfer.ReceivedAt = &now
+
+	if err := tx.Model(&transfer).Select("status", "received_by_id", "received_at").Updates(&transfer).Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to update transfer",
+		})
+	}
+
+	recordAudit(c, tx, model.AuditUpdate, "stock_transfer", transfer.ID, before, transfer)
+
+	if err := tx.Commit().Error; err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to commit transaction",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  200,
+		"success": true,
+		"message": "Transfer " + status,
+		"data":    transfer,
+	})
+}
+
+// stockErrorResponse превращает ошибку изменения остатков в ответ API.
+func stockErrorResponse(c *fiber.Ctx, err error) error {
+	var stockErr *insufficientStockError
+	if errors.As(err, &stockErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"status":  400,
+			"success": false,
+			"message": stockErr.Error(),
+		})
+	}
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"status":  500,
+		"success": false,
+		"message": "Failed to update stock",
+	})
+}
+
+// GetStockAlerts Сигналы о низком остатке
+//
+//	@Summary		Сигналы о низком остатке
+//	@Description	Возвращает продукты, доступный остаток которых (с учётом резерва под непринятые заказы) ниже минимального
+//	@Tags			warehouse
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string				false	"Статус (open, resolved)"	default(open)
+//	@Param			page		query		int					false	"Номер страницы"			default(1)
+//	@Param			pageSize	query		int					false	"Размер страницы"			default(10)
+//	@Success		200			{array}		model.StockAlert	"Сигналы с информацией о пагинации"
+//	@Failure		500			{object}	APIError			"Ошибка сервера"
+//	@Router			/warehouse/alerts [get]
+func GetStockAlerts(c *fiber.Ctx) error {
+	alerts := []model.StockAlert{}
+	status := c.Query("status", model.AlertOpen)
+
+	db := database.DB.Preload("Product").Order("created_at desc")
+	response, err := utils.Paginate(db, c, map[string]interface{}{"status": status}, &alerts)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"status":  500,
+			"success": false,
+			"message": "Failed to retrieve stock alerts",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
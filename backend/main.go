@@ -2,10 +2,14 @@ package main
 
 import (
 	"backend/database"
+	"backend/handlers"
 	"backend/middleware"
 	"backend/router"
+	"backend/tasks"
 	"backend/utils"
 	"log"
+	"os"
+	"strings"
 
 	_ "backend/docs"
 
@@ -34,21 +38,53 @@ import (
 
 func main() {
 	godotenv.Load()
-	app := fiber.New()
+	// Адрес клиента берётся из PROXY_HEADER (например, X-Real-IP, который выставляет nginx) только
+	// для запросов от прокси из TRUSTED_PROXIES (IP или CIDR через запятую), иначе — адрес соединения
+	app := fiber.New(fiber.Config{
+		ProxyHeader:             utils.Getenv("PROXY_HEADER", ""),
+		EnableTrustedProxyCheck: true,
+		TrustedProxies:          trustedProxies(),
+		EnableIPValidation:      true,
+	})
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
 		AllowMethods: "GET,POST,HEAD,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders: "Authorization, Content-Type",
+		AllowHeaders: "Authorization, Content-Type, X-API-Key",
 	}))
 
 	app.Use(middleware.Security)
 
 	database.ConnectDB()
 
+	// go run . backfill-rollups — пересобрать дневные итоги продаж и выйти
+	if len(os.Args) > 1 && os.Args[1] == "backfill-rollups" {
+		if err := tasks.BackfillSalesRollups(database.DB); err != nil {
+			log.Fatal(err)
+		}
+		log.Println("Sales rollups rebuilt")
+		return
+	}
+
+	tasks.EnsureSalesRollups()
+	tasks.StartAuditRetention()
+	tasks.StartStockAlerts()
+	tasks.StartReportSchedules(handlers.GenerateScheduledReport)
+	tasks.StartJobWorkers(handlers.JobHandlers())
+
 	app.Static("/uploads", "./uploads")
 
 	router.Initalize(app)
 	log.Fatal(app.Listen(":" + utils.Getenv("PORT", "8080")))
 }
+
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"backend/auth"
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Как часто обновлять last_used_at, чтобы не писать в БД на каждый запрос
+const apiKeyTouchInterval = time.Minute
+
+// extractAPIKey ищет ключ в заголовке X-API-Key или в Authorization: ApiKey <ключ>.
+func extractAPIKey(c *fiber.Ctx) string {
+	if key := c.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	headerParts := strings.Split(c.Get("Authorization"), " ")
+	if len(headerParts) == 2 && headerParts[0] == "ApiKey" {
+		return headerParts[1]
+	}
+	return ""
+}
+
+// authenticateAPIKey проверяет ключ и возвращает claims пользователя, от имени которого он действует.
+func authenticateAPIKey(c *fiber.Ctx, rawKey string) (*auth.Claims, error) {
+	prefix, ok := utils.ParseAPIKeyPrefix(rawKey)
+	if !ok {
+		return nil, errors.New("Invalid API key")
+	}
+
+	var key model.APIKey
+	if err := database.DB.Preload("User").First(&key, "prefix = ?", prefix).Error; err != nil || key.User == nil {
+		return nil, errors.New("Invalid API key")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(utils.HashAPIKey(rawKey)), []byte(key.KeyHash)) != 1 {
+		return nil, errors.New("Invalid API key")
+	}
+
+	now := time.Now()
+	if key.ExpiresAt != nil && key.ExpiresAt.Before(now) {
+		return nil, errors.New("API key has expired")
+	}
+
+	if !utils.IPAllowed(c.IP(), key.AllowedIPs) {
+		return nil, errors.New("API key is not allowed from this IP address")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		database.DB.Model(&key).UpdateColumn("last_used_at", now)
+	}
+
+	keyID := key.ID
+	return &auth.Claims{
+		ID:          key.User.ID,
+		Username:    key.User.Username,
+		Role:        key.User.Role,
+		APIKeyID:    &keyID,
+		Permissions: key.Permissions,
+	}, nil
+}
+
+// apiKeyPermits проверяет право "<ресурс>:read|write" для текущего маршрута.
+func apiKeyPermits(c *fiber.Ctx, permissions []string) bool {
+	resource := strings.SplitN(strings.TrimPrefix(c.Path(), "/"), "/", 2)[0]
+
+	action := "write"
+	if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+		action = "read"
+	}
+
+	return slices.Contains(permissions, resource+":"+action)
+}
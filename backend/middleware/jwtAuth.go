@@ -2,65 +2,108 @@ package middleware
 
 import (
 	"backend/auth"
+	"backend/database"
 	"backend/model"
+	"backend/utils"
 	"crypto/subtle"
-	"errors"
 	"net/http"
-	"os"
 	"strings"
-	"time"
 
-	"github.com/dgrijalva/jwt-go"
 	"github.com/gofiber/fiber/v2"
 )
 
-// JWT Secret key
-var jwtSecretKey = []byte(os.Getenv("jwt_secret"))
-
-// ProtectRoute middleware with support for multiple roles
+// ProtectRoute middleware with support for multiple roles.
+// Accepts either a Bearer JWT or an API key (X-API-Key or "Authorization: ApiKey <key>").
 func ProtectRoute(allowedRoles ...model.Role) fiber.Handler {
+	return protect([]string{""}, allowedRoles, true)
+}
+
+// ProtectUserRoute — ProtectRoute только для входа пользователя по JWT: API-ключи отклоняются.
+// Нужен для действий, которые касаются самого пользователя, например управления его 2FA.
+func ProtectUserRoute(allowedRoles ...model.Role) fiber.Handler {
+	return protect([]string{""}, allowedRoles, false)
+}
+
+// ProtectTwoFactorSetup пропускает как обычные токены, так и промежуточные токены подключения 2FA,
+// чтобы администратор с обязательной 2FA мог завершить настройку до получения полного доступа.
+// API-ключи не принимаются.
+func ProtectTwoFactorSetup() fiber.Handler {
+	return protect([]string{"", auth.ScopeTwoFactorEnroll}, []model.Role{model.AdminRole, model.Manager, model.Seller}, false)
+}
+
+func protect(allowedScopes []string, allowedRoles []model.Role, allowAPIKeys bool) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Check Authorization header
-		authHeader := c.Get("Authorization")
-		if authHeader == "" {
-			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
-				"status":  401,
-				"message": "Unauthorized",
-			})
-		}
+		var claims *auth.Claims
 
-		// Split and validate header format
-		headerParts := strings.Split(authHeader, " ")
-		if len(headerParts) != 2 || headerParts[0] != "Bearer" {
-			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
-				"status":  401,
-				"message": "Invalid Authorization header format",
-			})
-		}
+		if apiKey := extractAPIKey(c); apiKey != "" {
+			if !allowAPIKeys {
+				return c.Status(http.StatusForbidden).JSON(fiber.Map{
+					"status":  403,
+					"message": "API keys cannot be used for this resource",
+				})
+			}
 
-		// Token string with size check
-		tokenString := headerParts[1]
-		if len(tokenString) > 1024 {
-			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
-				"status":  401,
-				"message": "Token too large",
-			})
+			// Machine-to-machine request authenticated by an API key
+			keyClaims, err := authenticateAPIKey(c, apiKey)
+			if err != nil {
+				return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+					"status":  401,
+					"message": err.Error(),
+				})
+			}
+			claims = keyClaims
+		} else {
+			// Check Authorization header
+			authHeader := c.Get("Authorization")
+			if authHeader == "" {
+				return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+					"status":  401,
+					"message": "Unauthorized",
+				})
+			}
+
+			// Split and validate header format
+			headerParts := strings.Split(authHeader, " ")
+			if len(headerParts) != 2 || headerParts[0] != "Bearer" {
+				return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+					"status":  401,
+					"message": "Invalid Authorization header format",
+				})
+			}
+
+			// Token string with size check
+			tokenString := headerParts[1]
+			if len(tokenString) > 1024 {
+				return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+					"status":  401,
+					"message": "Token too large",
+				})
+			}
+
+			// Parse token, check validity and expiration
+			tokenClaims, err := utils.ParseJWT(tokenString)
+			if err != nil {
+				return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+					"status":  401,
+					"message": "Unauthorized",
+				})
+			}
+			claims = tokenClaims
 		}
 
-		// Parse token
-		claims := &auth.Claims{}
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, errors.New("unexpected signing method")
+		// Pre-auth tokens are only accepted where their scope is explicitly allowed
+		scopeAllowed := false
+		for _, scope := range allowedScopes {
+			if claims.Scope == scope {
+				scopeAllowed = true
+				break
 			}
-			return jwtSecretKey, nil
-		})
+		}
 
-		// Token validity and expiration check
-		if err != nil || !token.Valid || claims.ExpiresAt < time.Now().Unix() {
+		if !scopeAllowed {
 			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
 				"status":  401,
-				"message": "Unauthorized",
+				"message": "Two-factor authentication is not completed",
 			})
 		}
 
@@ -80,6 +123,23 @@ func ProtectRoute(allowedRoles ...model.Role) fiber.Handler {
 			})
 		}
 
+		// API keys are limited to the resources they were granted
+		if claims.APIKeyID != nil && !apiKeyPermits(c, claims.Permissions) {
+			return c.Status(http.StatusForbidden).JSON(fiber.Map{
+				"status":  403,
+				"message": "API key has no permission for this resource",
+			})
+		}
+
+		// Deactivated and deleted users lose access immediately, even with an unexpired token
+		var user model.User
+		if err := database.DB.Select("id", "active").First(&user, "id = ?", claims.ID).Error; err != nil || !user.Active {
+			return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+				"status":  401,
+				"message": "User is deactivated",
+			})
+		}
+
 		// Store user claims in context
 		c.Locals("user", claims)
 		return c.Next()
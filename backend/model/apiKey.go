@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// APIKeyResources — группы маршрутов, к которым может быть выдан доступ по API-ключу.
+// Право записывается как "<ресурс>:read" (GET) или "<ресурс>:write" (остальные методы).
+var APIKeyResources = []string{
+	"clients", "categories", "products", "orders", "warehouse", "warehouses",
+	"statistics", "getstatsof", "exports", "imports", "jobs", "upload", "uploadmany",
+	"suppliers", "purchase-orders", "commissions", "targets", "reports", "settings",
+}
+
+// APIKey — ключ для межсервисных интеграций. Ключ действует от имени пользователя UserID
+// с его ролью, но только в пределах перечисленных прав.
+type APIKey struct {
+	ID          guuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string         `gorm:"not null" json:"name"`
+	Prefix      string         `gorm:"not null;uniqueIndex" json:"prefix"`
+	KeyHash     string         `gorm:"not null" json:"-"`
+	UserID      guuid.UUID     `gorm:"type:uuid;not null;index" json:"userId"`
+	User        *User          `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Permissions pq.StringArray `gorm:"type:text[]" json:"permissions" swaggertype:"array,string"`
+	AllowedIPs  pq.StringArray `gorm:"type:text[]" json:"allowedIps" swaggertype:"array,string"`
+	ExpiresAt   *time.Time     `json:"expiresAt"`
+	LastUsedAt  *time.Time     `json:"lastUsedAt"`
+	CreatedByID guuid.UUID     `gorm:"type:uuid" json:"createdById"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const (
+	AuditCreate = "create"
+	AuditUpdate = "update"
+	AuditDelete = "delete"
+)
+
+type AuditEntry struct {
+	ID            guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ActorID       *guuid.UUID `gorm:"type:uuid;index" json:"actorId"`
+	ActorUsername string      `json:"actorUsername"`
+	ActorRole     Role        `json:"actorRole"`
+	APIKeyID      *guuid.UUID `gorm:"type:uuid" json:"apiKeyId"`
+	Action        string      `gorm:"not null;index" json:"action"`
+	EntityType    string      `gorm:"not null;index:idx_audit_entity" json:"entityType"`
+	EntityID      string      `gorm:"index:idx_audit_entity" json:"entityId"`
+	Before        JSON        `gorm:"type:jsonb" json:"before" swaggertype:"object"`
+	After         JSON        `gorm:"type:jsonb" json:"after" swaggertype:"object"`
+	Diff          JSON        `gorm:"type:jsonb" json:"diff" swaggertype:"object"`
+	IP            string      `json:"ip"`
+	UserAgent     string      `json:"userAgent"`
+	CreatedAt     time.Time   `gorm:"index" json:"createdAt"`
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	guuid "github.com/google/uuid"
+)
+
+// BOMLine — строка спецификации: сколько единиц компонента расходуется на одну единицу продукта.
+type BOMLine struct {
+	ID          guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ProductID   guuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_bom_product_component" json:"productId"`
+	ComponentID guuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_bom_product_component" json:"componentId"`
+	Component   *Product   `gorm:"foreignKey:ComponentID" json:"component,omitempty"`
+	Quantity    float64    `gorm:"not null" json:"quantity"`
+}
+
+type SetBOMRequest struct {
+	Components []BOMLineRequest `json:"components" validate:"dive"`
+}
+
+type BOMLineRequest struct {
+	ComponentID guuid.UUID `json:"componentId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174003"`
+	Quantity    float64    `json:"quantity" validate:"required,gt=0" example:"2.5"`
+}
+
+type LogProductionRequest struct {
+	ProductID   guuid.UUID  `json:"productId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Quantity    float64     `json:"quantity" validate:"required,gt=0" example:"10"`
+	WarehouseID *guuid.UUID `json:"warehouseId" example:"123e4567-e89b-12d3-a456-426614174001"`
+}
@@ -13,7 +13,7 @@ type Client struct {
 	Image           string     `json:"image" validate:"omitempty,min=5"`
 	ContactInfo     string     `json:"contactInfo" validate:"omitempty"`
 	Address         string     `json:"address"`
-	Balance         int64      `json:"balance"`
+	Balance         int64      `json:"balance"` // Начальное сальдо: долг клиента до начала учёта, входит в выписку
 	Note            string     `json:"note" validate:"omitempty"`
 	SalespersonID   guuid.UUID `json:"salespersonId"`
 	PurchaseHistory []Order    `gorm:"foreignKey:ClientID" json:"purchaseHistory"`
@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+// ClientPayment — оплата, полученная от клиента. Вместе с заказами клиента образует
+// расчёты, по которым строится выписка /clients/{id}/statement.pdf.
+type ClientPayment struct {
+	ID          guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ClientID    guuid.UUID  `gorm:"type:uuid;not null;index" json:"clientId"`
+	Client      *Client     `gorm:"foreignKey:ClientID;constraint:OnDelete:CASCADE" json:"-"`
+	OrderID     *guuid.UUID `gorm:"type:uuid;index" json:"orderId"`
+	Amount      float64     `gorm:"not null" json:"amount" example:"1500000"`
+	Method      string      `gorm:"not null" json:"method" example:"cash"`
+	PaidAt      time.Time   `gorm:"not null;index" json:"paidAt"`
+	Note        string      `json:"note" example:"Предоплата"`
+	CreatedByID guuid.UUID  `gorm:"type:uuid" json:"createdById"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+type CreateClientPaymentRequest struct {
+	Amount float64 `json:"amount" validate:"required,gt=0" example:"1500000"`
+	Method string  `json:"method" validate:"required,oneof=cash transfer card" example:"cash"`
+	// Дата оплаты; по умолчанию — текущий момент
+	PaidAt  *time.Time  `json:"paidAt" validate:"omitempty" example:"2026-03-15T10:00:00+05:00"`
+	OrderID *guuid.UUID `json:"orderId" validate:"omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Note    string      `json:"note" validate:"max=500" example:"Предоплата"`
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const (
+	CommissionDraft    = "draft"
+	CommissionApproved = "approved"
+)
+
+// CommissionRule — правило комиссии продавца в процентах от выручки по доставленным заказам.
+// Правило без SellerID действует для продавцов, у которых нет своего. Если месячная выручка
+// достигла порога уровня, ко всей выручке применяется ставка этого уровня.
+type CommissionRule struct {
+	ID        guuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	SellerID  *guuid.UUID      `gorm:"type:uuid;uniqueIndex" json:"sellerId"`
+	Seller    *User            `gorm:"foreignKey:SellerID" json:"seller,omitempty"`
+	Rate      float64          `gorm:"not null" json:"rate"`
+	Tiers     []CommissionTier `gorm:"foreignKey:RuleID;constraint:OnDelete:CASCADE" json:"tiers"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+type CommissionTier struct {
+	ID        guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	RuleID    guuid.UUID `gorm:"type:uuid;not null;index" json:"ruleId"`
+	Threshold float64    `gorm:"not null" json:"threshold"`
+	Rate      float64    `gorm:"not null" json:"rate"`
+}
+
+// CommissionStatement — начисление комиссии продавцу за месяц. Черновик пересчитывается
+// при каждом формировании ведомости, утверждённое начисление больше не меняется.
+type CommissionStatement struct {
+	ID           guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	SellerID     guuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_commission_seller_month" json:"sellerId"`
+	Seller       *User       `gorm:"foreignKey:SellerID" json:"seller,omitempty"`
+	Month        string      `gorm:"not null;uniqueIndex:idx_commission_seller_month;index" json:"month"`
+	Revenue      float64     `json:"revenue"`
+	OrderCount   int         `json:"orderCount"`
+	Rate         float64     `json:"rate"`
+	Amount       float64     `json:"amount"`
+	RuleID       *guuid.UUID `gorm:"type:uuid" json:"ruleId"`
+	Status       string      `gorm:"not null;index" json:"status"`
+	ApprovedByID *guuid.UUID `gorm:"type:uuid" json:"approvedById"`
+	ApprovedAt   *time.Time  `json:"approvedAt"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
+
+type CommissionRuleRequest struct {
+	SellerID *guuid.UUID             `json:"sellerId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Rate     float64                 `json:"rate" validate:"gte=0,lte=100" example:"3"`
+	Tiers    []CommissionTierRequest `json:"tiers" validate:"omitempty,dive"`
+}
+
+type CommissionTierRequest struct {
+	Threshold float64 `json:"threshold" validate:"gt=0" example:"50000000"`
+	Rate      float64 `json:"rate" validate:"gte=0,lte=100" example:"5"`
+}
+
+type GenerateCommissionsRequest struct {
+	Month string `json:"month" validate:"required,datetime=2006-01" example:"2025-01"`
+}
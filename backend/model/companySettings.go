@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// CompanySettings — реквизиты компании для документов. Хранится одна запись с ID = 1.
+type CompanySettings struct {
+	ID             uint   `gorm:"primaryKey" json:"-"`
+	Name           string `json:"name" example:"ООО «Sharif Mebel»"`
+	Address        string `json:"address" example:"г. Ташкент, ул. Навои, 1"`
+	Phone          string `json:"phone" example:"+998 90 123 45 67"`
+	Email          string `json:"email" example:"info@example.uz"`
+	TaxID          string `json:"taxId" example:"123456789"`
+	BankName       string `json:"bankName" example:"АКБ «Капиталбанк»"`
+	BankAccount    string `json:"bankAccount" example:"20208000900123456001"`
+	BankCode       string `json:"bankCode" example:"00974"`
+	DirectorName   string `json:"directorName" example:"Иванов И. И."`
+	AccountantName string `json:"accountantName" example:"Петрова А. А."`
+	// Путь к загруженному логотипу (uploads/...); если не задан, документы без логотипа
+	LogoPath  string    `json:"logoPath" example:"uploads/logo.png"`
+	Currency  string    `gorm:"not null;default:UZS" json:"currency" example:"UZS"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type UpdateCompanySettingsRequest struct {
+	Name           string `json:"name" validate:"required,max=200" example:"ООО «Sharif Mebel»"`
+	Address        string `json:"address" validate:"max=300" example:"г. Ташкент, ул. Навои, 1"`
+	Phone          string `json:"phone" validate:"max=50" example:"+998 90 123 45 67"`
+	Email          string `json:"email" validate:"omitempty,email" example:"info@example.uz"`
+	TaxID          string `json:"taxId" validate:"max=20" example:"123456789"`
+	BankName       string `json:"bankName" validate:"max=200" example:"АКБ «Капиталбанк»"`
+	BankAccount    string `json:"bankAccount" validate:"max=34" example:"20208000900123456001"`
+	BankCode       string `json:"bankCode" validate:"max=20" example:"00974"`
+	DirectorName   string `json:"directorName" validate:"max=100" example:"Иванов И. И."`
+	AccountantName string `json:"accountantName" validate:"max=100" example:"Петрова А. А."`
+	LogoPath       string `json:"logoPath" validate:"omitempty,startswith=uploads/,excludes=.." example:"uploads/logo.png"`
+	Currency       string `json:"currency" validate:"required,oneof=UZS USD RUB" example:"UZS"`
+}
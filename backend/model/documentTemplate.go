@@ -0,0 +1,130 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+// DocumentLayout — макет документа по заказу: шрифты и блоки, которые выводятся сверху вниз.
+// В тексте блоков можно подставлять поля заказа: {{client.name}}, {{order.total}}, {{label.total}}.
+type DocumentLayout struct {
+	// Файлы шрифтов из DOCUMENT_FONTS_DIR; по умолчанию Roboto-Regular.ttf и Roboto-Bold.ttf
+	Font     string `json:"font,omitempty" validate:"omitempty,max=100" example:"Roboto-Regular.ttf"`
+	BoldFont string `json:"boldFont,omitempty" validate:"omitempty,max=100" example:"Roboto-Bold.ttf"`
+	// Размер и цвет текста по умолчанию
+	FontSize    float64         `json:"fontSize,omitempty" validate:"omitempty,gte=6,lte=24" example:"10"`
+	Color       string          `json:"color,omitempty" validate:"omitempty,hexcolor,len=7" example:"#2d3436"`
+	PageNumbers bool            `json:"pageNumbers" example:"true"`
+	Blocks      []DocumentBlock `json:"blocks" validate:"required,min=1,max=100,dive"`
+}
+
+// DocumentBlock — блок макета. Виды блоков:
+//   - text — текст с подстановкой полей;
+//   - field — «подпись: значение» поля Field, пустое значение не выводится;
+//   - logo — логотип компании размером Width×Height;
+//   - items — таблица позиций заказа с колонками Columns;
+//   - totals — итог и сумма прописью;
+//   - signatures — строки для подписей;
+//   - line — горизонтальная линия;
+//   - spacer — отступ высотой Height.
+//
+// X и Y отсчитываются от левого и верхнего полей, ширина по умолчанию — до правого поля.
+// Если задан Y, блок выводится на этой высоте текущей страницы, иначе — под предыдущим.
+type DocumentBlock struct {
+	Type       string              `json:"type" validate:"required,oneof=text field logo items totals signatures line spacer" example:"text"`
+	Text       string              `json:"text,omitempty" validate:"max=2000" example:"{{label.buyer}}: {{client.name}}"`
+	Label      string              `json:"label,omitempty" validate:"max=200" example:"{{label.warehouse}}"`
+	Field      string              `json:"field,omitempty" validate:"max=50" example:"warehouse.name"`
+	X          float64             `json:"x,omitempty" validate:"gte=0,lte=500"`
+	Y          float64             `json:"y,omitempty" validate:"gte=0,lte=790"`
+	Width      float64             `json:"width,omitempty" validate:"gte=0,lte=520"`
+	Height     float64             `json:"height,omitempty" validate:"gte=0,lte=400"`
+	Size       float64             `json:"size,omitempty" validate:"omitempty,gte=6,lte=40"`
+	Bold       bool                `json:"bold,omitempty"`
+	Color      string              `json:"color,omitempty" validate:"omitempty,hexcolor,len=7" example:"#0984e3"`
+	Align      string              `json:"align,omitempty" validate:"omitempty,oneof=left center right" example:"center"`
+	Columns    []DocumentColumn    `json:"columns,omitempty" validate:"required_if=Type items,max=10,dive"`
+	SortBy     string              `json:"sortBy,omitempty" validate:"omitempty,oneof=name category" example:"category"`
+	Signatures []DocumentSignature `json:"signatures,omitempty" validate:"required_if=Type signatures,max=6,dive"`
+}
+
+// DocumentColumn — колонка таблицы позиций. Field — поле позиции (item.name, item.quantity, ...).
+// Колонки без ширины делят оставшееся место поровну.
+type DocumentColumn struct {
+	Title string  `json:"title" validate:"max=100" example:"{{label.col.name}}"`
+	Field string  `json:"field" validate:"required,max=50" example:"item.name"`
+	Width float64 `json:"width,omitempty" validate:"gte=0,lte=520" example:"200"`
+	Align string  `json:"align,omitempty" validate:"omitempty,oneof=left center right" example:"left"`
+}
+
+// DocumentSignature — строка для подписи: должность и расшифровка.
+type DocumentSignature struct {
+	Title string `json:"title" validate:"required,max=100" example:"{{label.sign.director}}"`
+	Name  string `json:"name,omitempty" validate:"max=100" example:"{{company.director}}"`
+}
+
+func (l DocumentLayout) Value() (driver.Value, error) {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (l *DocumentLayout) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, l)
+	case string:
+		return json.Unmarshal([]byte(v), l)
+	default:
+		return errors.New("unsupported type for document layout")
+	}
+}
+
+// DocumentTemplate — шаблон документа по заказу. Каждое изменение макета сохраняется новой версией,
+// печатается опубликованная версия PublishedVersion. Для вида документа опубликован не больше
+// одного шаблона; если опубликованного нет, печатается встроенный макет.
+type DocumentTemplate struct {
+	ID               guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	DocumentType     string     `gorm:"not null;index" json:"documentType" example:"invoice"`
+	Name             string     `gorm:"not null" json:"name" example:"Счёт с логотипом"`
+	LatestVersion    int        `gorm:"not null" json:"latestVersion" example:"3"`
+	PublishedVersion int        `gorm:"not null;default:0" json:"publishedVersion" example:"2"`
+	CreatedByID      guuid.UUID `gorm:"type:uuid" json:"createdById"`
+	CreatedAt        time.Time  `json:"createdAt"`
+	UpdatedAt        time.Time  `json:"updatedAt"`
+}
+
+// DocumentTemplateVersion — неизменяемая версия макета шаблона.
+type DocumentTemplateVersion struct {
+	ID          guuid.UUID        `gorm:"type:uuid;primaryKey" json:"id"`
+	TemplateID  guuid.UUID        `gorm:"type:uuid;not null;uniqueIndex:idx_template_version" json:"templateId"`
+	Template    *DocumentTemplate `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"-"`
+	Version     int               `gorm:"not null;uniqueIndex:idx_template_version" json:"version" example:"3"`
+	Layout      DocumentLayout    `gorm:"type:jsonb;not null" json:"layout"`
+	Comment     string            `json:"comment" example:"Добавлен логотип"`
+	CreatedByID guuid.UUID        `gorm:"type:uuid" json:"createdById"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+type CreateDocumentTemplateRequest struct {
+	DocumentType string         `json:"documentType" validate:"required,oneof=invoice delivery_note pick_list receipt" example:"invoice"`
+	Name         string         `json:"name" validate:"required,min=2,max=100" example:"Счёт с логотипом"`
+	Layout       DocumentLayout `json:"layout" validate:"required"`
+	Comment      string         `json:"comment" validate:"max=500" example:"Первая версия"`
+}
+
+type UpdateDocumentTemplateRequest struct {
+	Name    string         `json:"name" validate:"required,min=2,max=100" example:"Счёт с логотипом"`
+	Layout  DocumentLayout `json:"layout" validate:"required"`
+	Comment string         `json:"comment" validate:"max=500" example:"Добавлен логотип"`
+}
+
+type PublishDocumentTemplateRequest struct {
+	Version int `json:"version" validate:"required,gte=1" example:"2"`
+}
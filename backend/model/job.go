@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job — фоновая задача в очереди. Воркеры забирают задачи из таблицы с SKIP LOCKED, поэтому
+// очередь работает без внешнего брокера и при нескольких экземплярах backend.
+type Job struct {
+	ID     guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	Type   string     `gorm:"not null;index" json:"type" example:"export.orders"`
+	Status string     `gorm:"not null;index:idx_jobs_status_run_at" json:"status" example:"queued"`
+	// Параметры задачи; могут содержать пути к файлам на сервере, поэтому не отдаются в API
+	Payload         JSON       `gorm:"type:jsonb" json:"-"`
+	Progress        int        `gorm:"not null;default:0" json:"progress" example:"40"`
+	ProgressMessage string     `json:"progressMessage" example:"Обработано 2000 строк из 5000"`
+	Attempts        int        `gorm:"not null;default:0" json:"attempts"`
+	MaxAttempts     int        `gorm:"not null" json:"maxAttempts"`
+	RunAt           time.Time  `gorm:"not null;index:idx_jobs_status_run_at" json:"runAt"`
+	LockedUntil     *time.Time `json:"-"`
+	StartedAt       *time.Time `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt"`
+	Error           string     `json:"error"`
+	// Данные результата (например, итог импорта) и файл результата, если задача его формирует
+	Result            JSON       `gorm:"type:jsonb" json:"result" swaggertype:"object"`
+	ResultPath        string     `json:"-"`
+	ResultFileName    string     `json:"resultFileName"`
+	ResultContentType string     `json:"resultContentType"`
+	ResultSize        int64      `json:"resultSize"`
+	InputPath         string     `json:"-"`
+	CreatedByID       guuid.UUID `gorm:"type:uuid;index" json:"createdById"`
+	CreatedAt         time.Time  `json:"createdAt"`
+	UpdatedAt         time.Time  `json:"updatedAt"`
+}
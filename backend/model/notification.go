@@ -0,0 +1,20 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const NotificationStockDigest = "stock_digest"
+
+// Notification — сообщение пользователю внутри системы (например, ежедневная сводка по остаткам).
+type Notification struct {
+	ID        guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	UserID    guuid.UUID `gorm:"type:uuid;not null;index" json:"userId"`
+	Type      string     `gorm:"not null" json:"type"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
@@ -13,13 +13,21 @@ type Order struct {
 	Salesperson   *User          `gorm:"foreignKey:SalespersonID" json:"salesperson"`
 	ClientID      guuid.UUID     `gorm:"type:uuid;not null;index" validate:"required,uuid" json:"clientId"`
 	Client        *Client        `gorm:"foreignKey:ClientID" validate:"-" json:"client"`
+	WarehouseID   *guuid.UUID    `gorm:"type:uuid;index" json:"warehouseId"`
+	Warehouse     *Warehouse     `gorm:"foreignKey:WarehouseID" validate:"-" json:"warehouse"`
 	Products      []OrderItem    `gorm:"foreignKey:OrderID;" json:"products"`
 	Status        string         `json:"status" gorm:"not null" validate:"required,oneof=pending accepted rejected in_production ready delivered"`
 	Attachments   pq.StringArray `json:"attachments" gorm:"type:text[]" validate:"omitempty"`
 	PaymentMethod string         `json:"paymentMethod" gorm:"not null" validate:"required,oneof=cash transfer credit"`
 	TotalPrice    float64        `json:"totalPrice"`
-	CreatedAt     time.Time      `json:"createdAt"`
-	UpdatedAt     time.Time      `json:"updatedAt"`
+	// Время переходов между статусами, по ним считаются сроки исполнения заказов
+	AcceptedAt     *time.Time `json:"acceptedAt"`
+	RejectedAt     *time.Time `json:"rejectedAt"`
+	InProductionAt *time.Time `json:"inProductionAt"`
+	ReadyAt        *time.Time `json:"readyAt"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
 }
 
 type CreateOrderRequest struct {
@@ -28,4 +36,5 @@ type CreateOrderRequest struct {
 	Products      []CreateOrderItemRequest `json:"products" validate:"required,dive"`
 	PaymentMethod string                   `json:"paymentMethod" validate:"required,oneof=cash transfer credit" example:"cash"`
 	Attachments   []string                 `json:"attachments" validate:"omitempty"`
+	WarehouseID   *guuid.UUID              `json:"warehouseId" validate:"omitempty" example:"123e4567-e89b-12d3-a456-426614174002"`
 }
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+// Виды документов по заказу
+const (
+	DocumentInvoice      = "invoice"
+	DocumentDeliveryNote = "delivery_note"
+	DocumentPickList     = "pick_list"
+	DocumentReceipt      = "receipt"
+)
+
+// DocumentPrefixes — префиксы номеров документов; номер имеет вид INV-2026-000123.
+var DocumentPrefixes = map[string]string{
+	DocumentInvoice:      "INV",
+	DocumentDeliveryNote: "DN",
+	DocumentPickList:     "PL",
+	DocumentReceipt:      "RC",
+}
+
+// OrderDocument — выданный документ по заказу. Номер присваивается при первой печати
+// и сохраняется, поэтому повторная печать даёт тот же номер.
+type OrderDocument struct {
+	ID         guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	OrderID    guuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_order_document_type" json:"orderId"`
+	Order      *Order     `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE" json:"-"`
+	Type       string     `gorm:"not null;uniqueIndex:idx_order_document_type" json:"type" example:"invoice"`
+	Number     string     `gorm:"not null;uniqueIndex" json:"number" example:"INV-2026-000123"`
+	IssuedAt   time.Time  `json:"issuedAt"`
+	IssuedByID guuid.UUID `gorm:"type:uuid" json:"issuedById"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// DocumentSequence — последний выданный номер документа данного вида за год.
+type DocumentSequence struct {
+	Type       string `gorm:"primaryKey"`
+	Year       int    `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int    `gorm:"not null"`
+}
@@ -13,6 +13,9 @@ type OrderItem struct {
 	// Unit      string     `json:"unit" validate:"required, oneof=piece meter"`
 	// PricePerUnit float64    `json:"pricePerUnit"`
 	TotalPrice float64 `json:"totalPrice"`
+	// Себестоимость на момент оформления заказа
+	UnitCost  float64 `gorm:"not null;default:0" json:"unitCost"`
+	TotalCost float64 `gorm:"not null;default:0" json:"totalCost"`
 }
 
 type CreateOrderItemRequest struct {
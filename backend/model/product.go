@@ -8,20 +8,24 @@ import (
 )
 
 type Product struct {
-	ID           guuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
-	Name         string         `json:"name" validate:"required,min=3,max=100"`
-	CategoryID   string         `gorm:"type:uuid;not null" json:"categoryId" validate:"required,uuid"`
-	Category     *Category      `gorm:"foreignKey:CategoryID" json:"category"`
-	Width        string         `json:"width" validate:"omitempty"`
-	Height       string         `json:"height" validate:"omitempty"`
-	Price        float64        `json:"price,omitempty" validate:"gte=0"`
-	Unit         string         `json:"unit" validate:"required,oneof=piece meter"`
-	Amount       float64        `json:"amount" validate:"required,gte=0"`
-	Image        string         `json:"image" validate:"omitempty,min=5"`
-	SearchVector string         `gorm:"type:tsvector" json:"-"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
-	CreatedAt    time.Time      `json:"createdAt"`
-	UpdatedAt    time.Time      `json:"updatedAt"`
+	ID              guuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	Name            string           `json:"name" validate:"required,min=3,max=100"`
+	CategoryID      string           `gorm:"type:uuid;not null" json:"categoryId" validate:"required,uuid"`
+	Category        *Category        `gorm:"foreignKey:CategoryID" json:"category"`
+	Width           string           `json:"width" validate:"omitempty"`
+	Height          string           `json:"height" validate:"omitempty"`
+	Price           float64          `json:"price,omitempty" validate:"gte=0"`
+	CostPrice       float64          `gorm:"not null;default:0" json:"costPrice" validate:"gte=0"`
+	Unit            string           `json:"unit" validate:"required,oneof=piece meter"`
+	Amount          float64          `json:"amount" validate:"required,gte=0"`
+	MinStock        float64          `gorm:"not null;default:0" json:"minStock" validate:"gte=0"`
+	ReorderQuantity float64          `gorm:"not null;default:0" json:"reorderQuantity" validate:"gte=0"`
+	Image           string           `json:"image" validate:"omitempty,min=5"`
+	Stocks          []WarehouseStock `gorm:"foreignKey:ProductID" json:"stocks" validate:"-"`
+	SearchVector    string           `gorm:"type:tsvector" json:"-"`
+	DeletedAt       gorm.DeletedAt   `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+	CreatedAt       time.Time        `json:"createdAt"`
+	UpdatedAt       time.Time        `json:"updatedAt"`
 }
 
 type CreateProductRequest struct {
@@ -33,4 +37,9 @@ type CreateProductRequest struct {
 	Unit       string  `json:"unit" validate:"required,oneof=piece meter" example:"piece"`
 	Amount     float64 `json:"amount" validate:"required,gte=0" example:"10"`
 	Image      string  `json:"image" validate:"omitempty,min=5" example:"uploads/image.jpg"`
+	// Склад для начального остатка; по умолчанию — основной склад
+	WarehouseID *string `json:"warehouseId" validate:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174002"`
+	// Минимальный доступный остаток, ниже которого создаётся сигнал, и рекомендуемый объём дозаказа
+	MinStock        float64 `json:"minStock" validate:"gte=0" example:"5"`
+	ReorderQuantity float64 `json:"reorderQuantity" validate:"gte=0" example:"20"`
 }
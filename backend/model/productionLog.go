@@ -7,9 +7,11 @@ import (
 )
 
 type ProductionLog struct {
-	ID        guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
-	ProductID guuid.UUID `gorm:"type:uuid;not null" json:"productId"`
-	Product   *Product   `gorm:"foreignKey:ProductID" json:"product"`
-	Quantity  float64    `json:"quantity"`
-	CreatedAt time.Time  `json:"createdAt"`
+	ID          guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ProductID   guuid.UUID  `gorm:"type:uuid;not null" json:"productId"`
+	Product     *Product    `gorm:"foreignKey:ProductID" json:"product"`
+	Quantity    float64     `json:"quantity"`
+	WarehouseID *guuid.UUID `gorm:"type:uuid" json:"warehouseId"`
+	CreatedByID *guuid.UUID `gorm:"type:uuid" json:"createdById"`
+	CreatedAt   time.Time   `json:"createdAt"`
 }
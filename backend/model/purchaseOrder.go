@@ -0,0 +1,87 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const (
+	PurchaseOrdered           = "ordered"
+	PurchasePartiallyReceived = "partially_received"
+	PurchaseReceived          = "received"
+	PurchaseCancelled         = "cancelled"
+)
+
+// PurchaseOrder — заказ поставщику. Товар приходуется на склад WarehouseID одной или
+// несколькими приёмками; заказ закрывается, когда получено всё заказанное количество.
+type PurchaseOrder struct {
+	ID          guuid.UUID          `gorm:"type:uuid;primaryKey" json:"id"`
+	SupplierID  guuid.UUID          `gorm:"type:uuid;not null;index" json:"supplierId"`
+	Supplier    *Supplier           `gorm:"foreignKey:SupplierID" json:"supplier,omitempty"`
+	WarehouseID guuid.UUID          `gorm:"type:uuid;not null;index" json:"warehouseId"`
+	Warehouse   *Warehouse          `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
+	Items       []PurchaseOrderItem `gorm:"foreignKey:PurchaseOrderID" json:"items"`
+	Receipts    []PurchaseReceipt   `gorm:"foreignKey:PurchaseOrderID" json:"receipts,omitempty"`
+	Status      string              `gorm:"not null;index" json:"status"`
+	TotalCost   float64             `json:"totalCost"`
+	ExpectedAt  *time.Time          `json:"expectedAt"`
+	Note        string              `json:"note"`
+	CreatedByID guuid.UUID          `gorm:"type:uuid" json:"createdById"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+type PurchaseOrderItem struct {
+	ID               guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	PurchaseOrderID  guuid.UUID `gorm:"type:uuid;not null;index" json:"purchaseOrderId"`
+	ProductID        guuid.UUID `gorm:"type:uuid;not null" json:"productId"`
+	Product          *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Quantity         float64    `json:"quantity"`
+	ReceivedQuantity float64    `gorm:"not null;default:0" json:"receivedQuantity"`
+	UnitCost         float64    `json:"unitCost"`
+	TotalCost        float64    `json:"totalCost"`
+}
+
+// PurchaseReceipt — приёмка товара по заказу поставщику.
+type PurchaseReceipt struct {
+	ID              guuid.UUID            `gorm:"type:uuid;primaryKey" json:"id"`
+	PurchaseOrderID guuid.UUID            `gorm:"type:uuid;not null;index" json:"purchaseOrderId"`
+	Items           []PurchaseReceiptItem `gorm:"foreignKey:ReceiptID" json:"items"`
+	Note            string                `json:"note"`
+	ReceivedByID    guuid.UUID            `gorm:"type:uuid" json:"receivedById"`
+	CreatedAt       time.Time             `json:"createdAt"`
+}
+
+type PurchaseReceiptItem struct {
+	ID                  guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ReceiptID           guuid.UUID `gorm:"type:uuid;not null;index" json:"receiptId"`
+	PurchaseOrderItemID guuid.UUID `gorm:"type:uuid;not null" json:"purchaseOrderItemId"`
+	ProductID           guuid.UUID `gorm:"type:uuid;not null" json:"productId"`
+	Quantity            float64    `json:"quantity"`
+	UnitCost            float64    `json:"unitCost"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	SupplierID  guuid.UUID                       `json:"supplierId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	WarehouseID *guuid.UUID                      `json:"warehouseId" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Items       []CreatePurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	ExpectedAt  *time.Time                       `json:"expectedAt" example:"2025-01-31T00:00:00Z"`
+	Note        string                           `json:"note" validate:"omitempty,max=500"`
+}
+
+type CreatePurchaseOrderItemRequest struct {
+	ProductID guuid.UUID `json:"productId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174003"`
+	Quantity  float64    `json:"quantity" validate:"required,gt=0" example:"100"`
+	UnitCost  float64    `json:"unitCost" validate:"gte=0" example:"2.5"`
+}
+
+type ReceivePurchaseOrderRequest struct {
+	Items []ReceivePurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	Note  string                            `json:"note" validate:"omitempty,max=500"`
+}
+
+type ReceivePurchaseOrderItemRequest struct {
+	ProductID guuid.UUID `json:"productId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174003"`
+	Quantity  float64    `json:"quantity" validate:"required,gt=0" example:"40"`
+}
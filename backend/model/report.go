@@ -0,0 +1,82 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+// ReportDefinition описывает отчёт конструктора: набор данных, группировки, показатели,
+// фильтры и период. Поля задаются именами из белого списка набора данных.
+type ReportDefinition struct {
+	Dataset string `json:"dataset" validate:"required,oneof=orders order_items clients products" example:"orders"`
+	// Группировки: поле или поле с датой и шагом, например "createdAt:month"
+	Dimensions []string        `json:"dimensions" validate:"max=5" example:"sellerName,createdAt:month"`
+	Measures   []ReportMeasure `json:"measures" validate:"required,min=1,max=10,dive"`
+	Filters    []ReportFilter  `json:"filters" validate:"max=20,dive"`
+	// Календарный период (day, week, month, quarter, year) или from/to (YYYY-MM-DD, включительно).
+	// Если Rolling, period означает последние 7, 30 или 90 дней либо 12 месяцев.
+	Period  string `json:"period,omitempty" validate:"omitempty,oneof=day week month quarter year" example:"month"`
+	Rolling bool   `json:"rolling,omitempty"`
+	From    string `json:"from,omitempty" validate:"omitempty,datetime=2006-01-02" example:"2025-01-01"`
+	To      string `json:"to,omitempty" validate:"omitempty,datetime=2006-01-02" example:"2025-01-31"`
+	// Ключ группировки или показателя для сортировки
+	SortBy   string `json:"sortBy,omitempty" example:"sum:totalPrice"`
+	SortDesc bool   `json:"sortDesc,omitempty"`
+	Limit    int    `json:"limit,omitempty" validate:"gte=0,lte=10000" example:"100"`
+}
+
+// ReportMeasure — агрегат по полю. Для count поле можно не указывать.
+type ReportMeasure struct {
+	Field     string `json:"field,omitempty" example:"totalPrice"`
+	Aggregate string `json:"aggregate" validate:"required,oneof=count count_distinct sum avg min max" example:"sum"`
+}
+
+// ReportFilter — условие на поле. Для in значение — массив.
+type ReportFilter struct {
+	Field    string      `json:"field" validate:"required" example:"status"`
+	Operator string      `json:"operator" validate:"required,oneof=eq ne gt gte lt lte in contains" example:"in"`
+	Value    interface{} `json:"value" swaggertype:"object"`
+}
+
+func (d ReportDefinition) Value() (driver.Value, error) {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (d *ReportDefinition) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return errors.New("unsupported type for report definition")
+	}
+}
+
+// SavedReport — сохранённый отчёт пользователя. Общие отчёты видны всем, кому доступен конструктор.
+type SavedReport struct {
+	ID          guuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string           `gorm:"not null" json:"name"`
+	Description string           `json:"description"`
+	OwnerID     guuid.UUID       `gorm:"type:uuid;not null;index" json:"ownerId"`
+	Owner       *User            `gorm:"foreignKey:OwnerID" json:"owner,omitempty"`
+	Shared      bool             `gorm:"not null;default:false" json:"shared"`
+	Definition  ReportDefinition `gorm:"type:jsonb;not null" json:"definition"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+type SaveReportRequest struct {
+	Name        string           `json:"name" validate:"required,min=2,max=100" example:"Выручка по продавцам"`
+	Description string           `json:"description" validate:"max=500" example:"Помесячная выручка каждого продавца"`
+	Shared      bool             `json:"shared" example:"false"`
+	Definition  ReportDefinition `json:"definition" validate:"required"`
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+const (
+	GeneratedReportSuccess = "success"
+	GeneratedReportFailed  = "failed"
+)
+
+// ReportSchedule — расписание формирования отчёта. Источник — сохранённый отчёт (ReportID)
+// или встроенная выгрузка (Export); задаётся ровно одно из них.
+type ReportSchedule struct {
+	ID       guuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
+	Name     string       `gorm:"not null" json:"name"`
+	ReportID *guuid.UUID  `gorm:"type:uuid;index" json:"reportId"`
+	Report   *SavedReport `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"report,omitempty"`
+	Export   string       `json:"export"`
+	Format   string       `gorm:"not null" json:"format"`
+	// Cron-выражение в часовом поясе бизнеса
+	Cron string `gorm:"not null" json:"cron"`
+	// Если задан, отчёт строится за последний завершённый период (day, week, month, quarter, year)
+	// вместо периода из определения отчёта
+	Period      string         `json:"period"`
+	Recipients  pq.StringArray `gorm:"type:text[]" json:"recipients" swaggertype:"array,string"`
+	Active      bool           `gorm:"not null" json:"active"`
+	NextRunAt   *time.Time     `gorm:"index" json:"nextRunAt"`
+	LastRunAt   *time.Time     `json:"lastRunAt"`
+	CreatedByID guuid.UUID     `gorm:"type:uuid" json:"createdById"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// GeneratedReport — файл, сформированный по расписанию или вручную. Сам файл хранится в REPORTS_DIR.
+type GeneratedReport struct {
+	ID            guuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	ScheduleID    *guuid.UUID     `gorm:"type:uuid;index" json:"scheduleId"`
+	Schedule      *ReportSchedule `gorm:"foreignKey:ScheduleID;constraint:OnDelete:SET NULL" json:"-"`
+	Name          string          `gorm:"not null" json:"name"`
+	FileName      string          `json:"fileName"`
+	Path          string          `json:"-"`
+	ContentType   string          `json:"contentType"`
+	Size          int64           `json:"size"`
+	Rows          int             `json:"rows"`
+	PeriodFrom    *time.Time      `json:"periodFrom"`
+	PeriodTo      *time.Time      `json:"periodTo"`
+	Status        string          `gorm:"not null" json:"status"`
+	Error         string          `json:"error"`
+	Recipients    pq.StringArray  `gorm:"type:text[]" json:"recipients" swaggertype:"array,string"`
+	DeliveredAt   *time.Time      `json:"deliveredAt"`
+	DeliveryError string          `json:"deliveryError"`
+	CreatedAt     time.Time       `json:"createdAt"`
+}
+
+type ReportScheduleRequest struct {
+	Name       string      `json:"name" validate:"required,min=2,max=100" example:"Продажи за месяц"`
+	ReportID   *guuid.UUID `json:"reportId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Export     string      `json:"export" validate:"omitempty,oneof=products clients" example:""`
+	Format     string      `json:"format" validate:"required,oneof=csv xlsx" example:"xlsx"`
+	Cron       string      `json:"cron" validate:"required" example:"0 8 1 * *"`
+	Period     string      `json:"period" validate:"omitempty,oneof=day week month quarter year" example:"month"`
+	Recipients []string    `json:"recipients" validate:"max=20,dive,email" example:"director@example.com"`
+	Active     *bool       `json:"active" example:"true"`
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+// DailySalesRollup — продажи за день (по часовому поясу бизнеса) в разрезе продукта, продавца и клиента.
+// Содержит только заказы, входящие в выручку. Каждый заказ учитывается в OrderCount один раз —
+// в строке своего первого продукта, поэтому число заказов точно по дням, продавцам и клиентам,
+// но не по продуктам.
+type DailySalesRollup struct {
+	Day           time.Time  `gorm:"type:date;primaryKey" json:"day"`
+	ProductID     guuid.UUID `gorm:"type:uuid;primaryKey;index" json:"productId"`
+	SalespersonID guuid.UUID `gorm:"type:uuid;primaryKey;index" json:"salespersonId"`
+	ClientID      guuid.UUID `gorm:"type:uuid;primaryKey;index" json:"clientId"`
+	Quantity      float64    `json:"quantity"`
+	Revenue       float64    `json:"revenue"`
+	Cost          float64    `json:"cost"`
+	OrderCount    int        `json:"orderCount"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+// SalesTarget — план выручки на месяц для продавца или для категории продуктов.
+// Задаётся ровно одно из полей SellerID и CategoryID.
+type SalesTarget struct {
+	ID          guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	Month       string      `gorm:"not null;index" json:"month"`
+	SellerID    *guuid.UUID `gorm:"type:uuid;index" json:"sellerId"`
+	Seller      *User       `gorm:"foreignKey:SellerID" json:"seller,omitempty"`
+	CategoryID  *string     `gorm:"type:uuid;index" json:"categoryId"`
+	Category    *Category   `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Amount      float64     `gorm:"not null" json:"amount"`
+	CreatedByID guuid.UUID  `gorm:"type:uuid" json:"createdById"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+type CreateSalesTargetRequest struct {
+	Month      string      `json:"month" validate:"required,datetime=2006-01" example:"2025-01"`
+	SellerID   *guuid.UUID `json:"sellerId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CategoryID *string     `json:"categoryId" validate:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Amount     float64     `json:"amount" validate:"gt=0" example:"150000000"`
+}
+
+type UpdateSalesTargetRequest struct {
+	Amount float64 `json:"amount" validate:"gt=0" example:"180000000"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const (
+	AlertOpen     = "open"
+	AlertResolved = "resolved"
+)
+
+// StockAlert — сигнал о том, что доступный остаток продукта опустился ниже минимального.
+// Для продукта одновременно существует не более одного открытого сигнала; он закрывается,
+// когда остаток восстанавливается. Available = Amount - Reserved (товар в ещё не принятых заказах).
+type StockAlert struct {
+	ID              guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ProductID       guuid.UUID `gorm:"type:uuid;not null;index" json:"productId"`
+	Product         *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Amount          float64    `json:"amount"`
+	Reserved        float64    `json:"reserved"`
+	Available       float64    `json:"available"`
+	MinStock        float64    `json:"minStock"`
+	ReorderQuantity float64    `json:"reorderQuantity"`
+	Status          string     `gorm:"not null;index" json:"status"`
+	ResolvedAt      *time.Time `json:"resolvedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const (
+	StockTakeOpen      = "open"
+	StockTakeApproved  = "approved"
+	StockTakeCancelled = "cancelled"
+)
+
+// StockTake — инвентаризация склада (целиком или по одной категории). При открытии фиксируется
+// ожидаемый остаток каждого продукта; после утверждения расхождения проводятся как корректировки.
+type StockTake struct {
+	ID           guuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	WarehouseID  guuid.UUID      `gorm:"type:uuid;not null;index" json:"warehouseId"`
+	Warehouse    *Warehouse      `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
+	CategoryID   *string         `gorm:"type:uuid" json:"categoryId"`
+	Category     *Category       `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Items        []StockTakeItem `gorm:"foreignKey:StockTakeID" json:"items"`
+	Status       string          `gorm:"not null;index" json:"status"`
+	Note         string          `json:"note"`
+	CreatedByID  guuid.UUID      `gorm:"type:uuid" json:"createdById"`
+	ApprovedByID *guuid.UUID     `gorm:"type:uuid" json:"approvedById"`
+	ApprovedAt   *time.Time      `json:"approvedAt"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+}
+
+// StockTakeItem — строка инвентаризации. Counted пуст, пока продукт не пересчитан;
+// Expected — учётный остаток на момент открытия, а после пересчёта — на момент пересчёта;
+// Variance = Counted - Expected.
+type StockTakeItem struct {
+	ID          guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	StockTakeID guuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_stock_take_product" json:"stockTakeId"`
+	ProductID   guuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_stock_take_product" json:"productId"`
+	Product     *Product    `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Expected    float64     `json:"expected"`
+	Counted     *float64    `json:"counted"`
+	Variance    float64     `json:"variance"`
+	CountedByID *guuid.UUID `gorm:"type:uuid" json:"countedById"`
+	CountedAt   *time.Time  `json:"countedAt"`
+}
+
+// StockAdjustment — проводка, изменившая остаток вне заказов и перемещений (например, по итогам инвентаризации).
+type StockAdjustment struct {
+	ID          guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	WarehouseID guuid.UUID  `gorm:"type:uuid;not null;index" json:"warehouseId"`
+	ProductID   guuid.UUID  `gorm:"type:uuid;not null;index" json:"productId"`
+	Quantity    float64     `json:"quantity"`
+	Reason      string      `json:"reason"`
+	StockTakeID *guuid.UUID `gorm:"type:uuid;index" json:"stockTakeId"`
+	CreatedByID guuid.UUID  `gorm:"type:uuid" json:"createdById"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+type CreateStockTakeRequest struct {
+	WarehouseID *guuid.UUID `json:"warehouseId" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CategoryID  *string     `json:"categoryId" validate:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Note        string      `json:"note" validate:"omitempty,max=500"`
+}
+
+type SubmitStockTakeCountsRequest struct {
+	Items []StockTakeCountRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type StockTakeCountRequest struct {
+	ProductID guuid.UUID `json:"productId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174003"`
+	Counted   float64    `json:"counted" validate:"gte=0" example:"8"`
+}
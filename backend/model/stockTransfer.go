@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+const (
+	TransferInTransit = "in_transit"
+	TransferReceived  = "received"
+	TransferCancelled = "cancelled"
+)
+
+// StockTransfer — документ перемещения между складами. Пока документ в пути,
+// товар списан с исходного склада и ещё не оприходован на складе назначения.
+type StockTransfer struct {
+	ID              guuid.UUID          `gorm:"type:uuid;primaryKey" json:"id"`
+	FromWarehouseID guuid.UUID          `gorm:"type:uuid;not null;index" json:"fromWarehouseId"`
+	FromWarehouse   *Warehouse          `gorm:"foreignKey:FromWarehouseID" json:"fromWarehouse,omitempty"`
+	ToWarehouseID   guuid.UUID          `gorm:"type:uuid;not null;index" json:"toWarehouseId"`
+	ToWarehouse     *Warehouse          `gorm:"foreignKey:ToWarehouseID" json:"toWarehouse,omitempty"`
+	Items           []StockTransferItem `gorm:"foreignKey:TransferID" json:"items"`
+	Status          string              `gorm:"not null;index" json:"status"`
+	Note            string              `json:"note"`
+	CreatedByID     guuid.UUID          `gorm:"type:uuid" json:"createdById"`
+	ReceivedByID    *guuid.UUID         `gorm:"type:uuid" json:"receivedById"`
+	ReceivedAt      *time.Time          `json:"receivedAt"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	UpdatedAt       time.Time           `json:"updatedAt"`
+}
+
+type StockTransferItem struct {
+	ID         guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	TransferID guuid.UUID `gorm:"type:uuid;not null;index" json:"transferId"`
+	ProductID  guuid.UUID `gorm:"type:uuid;not null" json:"productId"`
+	Product    *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Quantity   float64    `json:"quantity"`
+}
+
+type CreateStockTransferRequest struct {
+	FromWarehouseID guuid.UUID                       `json:"fromWarehouseId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ToWarehouseID   guuid.UUID                       `json:"toWarehouseId" validate:"required,nefield=FromWarehouseID" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Items           []CreateStockTransferItemRequest `json:"items" validate:"required,min=1,dive"`
+	Note            string                           `json:"note" validate:"omitempty,max=500"`
+}
+
+type CreateStockTransferItemRequest struct {
+	ProductID guuid.UUID `json:"productId" validate:"required" example:"123e4567-e89b-12d3-a456-426614174003"`
+	Quantity  float64    `json:"quantity" validate:"required,gt=0" example:"10"`
+}
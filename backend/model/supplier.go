@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Supplier struct {
+	ID          guuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	Name        string         `gorm:"not null" json:"name" validate:"required,min=2,max=100"`
+	ContactInfo string         `json:"contactInfo" validate:"omitempty"`
+	Address     string         `json:"address" validate:"omitempty"`
+	Note        string         `json:"note" validate:"omitempty"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
+
+// SupplierPrice — цена закупки продукта у поставщика. Новая запись добавляется при каждом
+// изменении цены, поэтому таблица хранит и текущую цену, и её историю.
+type SupplierPrice struct {
+	ID              guuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	SupplierID      guuid.UUID  `gorm:"type:uuid;not null;index:idx_supplier_product_price" json:"supplierId"`
+	ProductID       guuid.UUID  `gorm:"type:uuid;not null;index:idx_supplier_product_price" json:"productId"`
+	Product         *Product    `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Price           float64     `gorm:"not null" json:"price"`
+	PurchaseOrderID *guuid.UUID `gorm:"type:uuid" json:"purchaseOrderId"`
+	CreatedAt       time.Time   `gorm:"index:idx_supplier_product_price" json:"createdAt"`
+}
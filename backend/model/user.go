@@ -4,6 +4,8 @@ import (
 	"time"
 
 	guuid "github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 type Role string
@@ -24,4 +26,21 @@ type User struct {
 	UpdatedAt time.Time  `json:"updatedAt"`
 	Clients   []Client   `gorm:"foreignKey:SalespersonID" json:"clients"`
 	Orders    []Order    `gorm:"foreignKey:SalespersonID" json:"orders"`
+
+	// Деактивированный пользователь не может войти, но остаётся привязанным к своим заказам и клиентам
+	Active        bool           `gorm:"not null;default:true" json:"active"`
+	DeactivatedAt *time.Time     `json:"deactivatedAt"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+
+	// Склад менеджера: маршруты /warehouse работают только с его заказами и перемещениями
+	WarehouseID *guuid.UUID `gorm:"type:uuid" json:"warehouseId"`
+
+	TOTPEnabled     bool           `gorm:"column:totp_enabled;default:false" json:"totpEnabled"`
+	TOTPSecret      string         `gorm:"column:totp_secret" json:"-"`
+	TOTPLastCounter int64          `gorm:"column:totp_last_counter" json:"-"`
+	RecoveryCodes   pq.StringArray `gorm:"type:text[]" json:"-"`
+
+	// Действующий промежуточный токен второго шага входа и число попыток ввода кода по нему
+	TwoFactorTokenID  string `gorm:"column:two_factor_token_id" json:"-"`
+	TwoFactorAttempts int    `gorm:"column:two_factor_attempts;not null;default:0" json:"-"`
 }
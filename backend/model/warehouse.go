@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Warehouse — магазин или склад хранения. Склад с IsDefault используется, когда склад не указан явно
+// (старые заказы, создание продукта с начальным остатком).
+type Warehouse struct {
+	ID        guuid.UUID     `gorm:"type:uuid;primaryKey" json:"id"`
+	Name      string         `gorm:"not null" json:"name" validate:"required,min=2,max=100"`
+	Address   string         `json:"address" validate:"omitempty"`
+	IsDefault bool           `gorm:"not null;default:false" json:"isDefault"`
+	DeletedAt gorm.DeletedAt `gorm:"index" swaggerignore:"true" json:"deleted_at"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// WarehouseStock — остаток продукта на конкретном складе. Product.Amount равен сумме остатков по всем складам.
+type WarehouseStock struct {
+	ID          guuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	WarehouseID guuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_warehouse_product" json:"warehouseId"`
+	Warehouse   *Warehouse `gorm:"foreignKey:WarehouseID" json:"warehouse,omitempty"`
+	ProductID   guuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_warehouse_product" json:"productId"`
+	Product     *Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	Amount      float64    `gorm:"not null;default:0" json:"amount"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
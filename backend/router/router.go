@@ -19,8 +19,12 @@ func Initalize(router *fiber.App) {
 	users.Get("/", handlers.GetUsers)
 	users.Get("/search", handlers.SearchUsers)
 	users.Get("/:id", handlers.GetUserById)
-	users.Patch("/:id", handlers.UpdateUser)
-	users.Delete("/:id", handlers.DeleteUser)
+
+	usersAdmin := router.Group("/users", middleware.ProtectRoute("admin"))
+	usersAdmin.Patch("/:id", handlers.UpdateUser)
+	usersAdmin.Delete("/:id", handlers.DeleteUser)
+	usersAdmin.Post("/:id/deactivate", handlers.DeactivateUser)
+	usersAdmin.Post("/:id/activate", handlers.ActivateUser)
 
 	clients := router.Group("/clients", middleware.ProtectRoute("admin", "seller"))
 	clients.Post("/", handlers.CreateClient)
@@ -29,6 +33,12 @@ func Initalize(router *fiber.App) {
 	clients.Get("/:id", handlers.GetClientById)
 	clients.Patch("/:id", handlers.UpdateClient)
 	clients.Delete("/:id", handlers.DeleteClient)
+	clients.Get("/:id/statement.pdf", handlers.GetClientStatement)
+	clients.Post("/:id/payments", handlers.CreateClientPayment)
+	clients.Get("/:id/payments", handlers.GetClientPayments)
+
+	clientsAdmin := router.Group("/clients", middleware.ProtectRoute("admin"))
+	clientsAdmin.Delete("/:id/payments/:paymentId", handlers.DeleteClientPayment)
 
 	categoriesAdmin := router.Group("/categories", middleware.ProtectRoute("admin"))
 	categoriesAdmin.Post("/", handlers.CreateCategory)
@@ -47,18 +57,41 @@ func Initalize(router *fiber.App) {
 	products.Patch("/:id", handlers.UpdateProduct)
 	products.Get("/:id", handlers.GetProductById)
 	products.Delete("/:id", handlers.DeleteProduct)
+	products.Get("/:id/bom", handlers.GetProductBOM)
+	products.Put("/:id/bom", handlers.SetProductBOM)
+	products.Post("/:id/cost/from-bom", handlers.RecalculateCostFromBOM)
 
 	exports := router.Group("/exports", middleware.ProtectRoute("admin"))
 	exports.Get("/products", handlers.ExportProductsHandler)
 	exports.Get("/clients", handlers.ExportClientsHandler)
+	exports.Get("/stocktakes/:id", handlers.ExportStockTakeVariance)
+	exports.Get("/commissions", handlers.ExportCommissionStatements)
+	exports.Get("/orders", handlers.ExportOrders)
+	exports.Get("/order-items", handlers.ExportOrderItems)
+
+	imports := router.Group("/imports", middleware.ProtectRoute("admin"))
+	imports.Post("/products", handlers.ImportProducts)
+	imports.Post("/clients", handlers.ImportClients)
+
+	jobs := router.Group("/jobs", middleware.ProtectRoute("admin", "seller", "manager"))
+	jobs.Get("/", handlers.GetJobs)
+	jobs.Get("/:id", handlers.GetJobByID)
+	jobs.Get("/:id/download", handlers.DownloadJobResult)
+	jobs.Delete("/:id", handlers.DeleteJob)
 
 	stats := router.Group("/statistics", middleware.ProtectRoute("admin"))
 	stats.Get("/products", handlers.GetProductStatistics)
+	stats.Get("/margins", handlers.GetMarginReport)
+	stats.Get("/funnel", handlers.GetStatusFunnel)
+	stats.Get("/lead-times", handlers.GetLeadTimes)
+	stats.Get("/sellers", handlers.GetSellerLeaderboard)
+	stats.Get("/clients", handlers.GetClientRFM)
 	stats.Get("/dashboard", handlers.GetDashboard)
 	stats.Get("/chart", handlers.GetSalesChart)
 
 	individualStats := router.Group("/getstatsof", middleware.ProtectRoute("admin", "seller", "manager"))
 	individualStats.Get("/seller", handlers.GetSellerSalesChart)
+	individualStats.Get("/seller/at-risk", handlers.GetSellerAtRiskClients)
 
 	upload := router.Group("/upload", middleware.ProtectRoute("admin", "seller", "manager"))
 	upload.Post("/", handlers.UploadImage)
@@ -71,6 +104,8 @@ func Initalize(router *fiber.App) {
 	orders.Get("/", handlers.GetAllOrders)
 	orders.Get("/:id", handlers.GetOrderByID)
 	orders.Get("/:id/pdf", handlers.GetOrderPDF)
+	orders.Get("/:id/documents", handlers.GetOrderDocuments)
+	orders.Get("/:id/documents/:type", handlers.GetOrderDocument)
 	// orders.Patch("/:id", handlers.UpdateOrder)
 	// orders.Delete("/:id", handlers.DeleteOrder)
 
@@ -82,6 +117,121 @@ func Initalize(router *fiber.App) {
 	warehouseOrderFlow.Post("/:id/in_production", handlers.InProduction)
 	warehouseOrderFlow.Post("/:id/ready", handlers.OrderReady)
 	warehouseOrderFlow.Post("/:id/delivered", handlers.Delivered)
+	warehouseOrderFlow.Post("/transfers", handlers.CreateStockTransfer)
+	warehouseOrderFlow.Get("/transfers", handlers.GetStockTransfers)
+	warehouseOrderFlow.Post("/transfers/:id/receive", handlers.ReceiveStockTransfer)
+	warehouseOrderFlow.Post("/transfers/:id/cancel", handlers.CancelStockTransfer)
+	warehouseOrderFlow.Get("/alerts", handlers.GetStockAlerts)
+	warehouseOrderFlow.Post("/production", handlers.LogProduction)
+	warehouseOrderFlow.Get("/material-requirements", handlers.GetMaterialRequirements)
+	warehouseOrderFlow.Get("/forecast", handlers.GetDemandForecast)
+	warehouseOrderFlow.Post("/stocktakes", handlers.CreateStockTake)
+	warehouseOrderFlow.Get("/stocktakes", handlers.GetStockTakes)
+	warehouseOrderFlow.Get("/stocktakes/:id", handlers.GetStockTakeByID)
+	warehouseOrderFlow.Post("/stocktakes/:id/counts", handlers.SubmitStockTakeCounts)
+
+	stockTakesAdmin := router.Group("/warehouse/stocktakes", middleware.ProtectRoute("admin"))
+	stockTakesAdmin.Post("/:id/approve", handlers.ApproveStockTake)
+	stockTakesAdmin.Post("/:id/cancel", handlers.CancelStockTake)
+
+	// Общая группа регистрируется первой: middleware группы /warehouses срабатывает для всех её маршрутов
+	warehousesForAll := router.Group("/warehouses", middleware.ProtectRoute("admin", "manager", "seller"))
+	warehousesForAll.Get("/", handlers.GetAllWarehouses)
+	warehousesForAll.Get("/:id/stock", handlers.GetWarehouseStock)
+
+	warehousesAdmin := router.Group("/warehouses", middleware.ProtectRoute("admin"))
+	warehousesAdmin.Post("/", handlers.CreateWarehouse)
+	warehousesAdmin.Patch("/:id", handlers.UpdateWarehouse)
+	warehousesAdmin.Delete("/:id", handlers.DeleteWarehouse)
+
+	suppliers := router.Group("/suppliers", middleware.ProtectRoute("admin", "manager"))
+	suppliers.Post("/", handlers.CreateSupplier)
+	suppliers.Get("/", handlers.GetAllSuppliers)
+	suppliers.Get("/:id", handlers.GetSupplierById)
+	suppliers.Get("/:id/prices", handlers.GetSupplierPrices)
+	suppliers.Patch("/:id", handlers.UpdateSupplier)
+	suppliers.Delete("/:id", handlers.DeleteSupplier)
+
+	purchaseOrders := router.Group("/purchase-orders", middleware.ProtectRoute("admin", "manager"))
+	purchaseOrders.Post("/", handlers.CreatePurchaseOrder)
+	purchaseOrders.Get("/", handlers.GetPurchaseOrders)
+	purchaseOrders.Get("/:id", handlers.GetPurchaseOrderByID)
+	purchaseOrders.Post("/:id/receive", handlers.ReceivePurchaseOrder)
+	purchaseOrders.Post("/:id/cancel", handlers.CancelPurchaseOrder)
+
+	targets := router.Group("/targets", middleware.ProtectRoute("admin", "manager"))
+	targets.Post("/", handlers.CreateSalesTarget)
+	targets.Get("/", handlers.GetSalesTargets)
+	targets.Patch("/:id", handlers.UpdateSalesTarget)
+	targets.Delete("/:id", handlers.DeleteSalesTarget)
+
+	// Регистрируется до группы /reports, иначе /reports/:id перехватит /reports/schedules и /reports/files
+	reportSchedules := router.Group("/reports/schedules", middleware.ProtectRoute("admin"))
+	reportSchedules.Post("/", handlers.CreateReportSchedule)
+	reportSchedules.Get("/", handlers.GetReportSchedules)
+	reportSchedules.Put("/:id", handlers.UpdateReportSchedule)
+	reportSchedules.Delete("/:id", handlers.DeleteReportSchedule)
+	reportSchedules.Post("/:id/run", handlers.RunReportScheduleNow)
+
+	reportFiles := router.Group("/reports/files", middleware.ProtectRoute("admin"))
+	reportFiles.Get("/", handlers.GetGeneratedReports)
+	reportFiles.Get("/:id/download", handlers.DownloadGeneratedReport)
+	reportFiles.Delete("/:id", handlers.DeleteGeneratedReport)
+
+	reports := router.Group("/reports", middleware.ProtectRoute("admin", "manager"))
+	reports.Post("/run", handlers.RunReport)
+	reports.Post("/", handlers.CreateSavedReport)
+	reports.Get("/", handlers.GetSavedReports)
+	reports.Get("/:id", handlers.GetSavedReportByID)
+	reports.Put("/:id", handlers.UpdateSavedReport)
+	reports.Delete("/:id", handlers.DeleteSavedReport)
+	reports.Get("/:id/run", handlers.RunSavedReport)
+
+	// Продавец видит свои начисления, остальные маршруты /commissions доступны только администратору
+	commissionStatements := router.Group("/commissions/statements", middleware.ProtectRoute("admin", "manager", "seller"))
+	commissionStatements.Get("/", handlers.GetCommissionStatements)
+
+	commissions := router.Group("/commissions", middleware.ProtectRoute("admin"))
+	commissions.Post("/rules", handlers.CreateCommissionRule)
+	commissions.Get("/rules", handlers.GetCommissionRules)
+	commissions.Put("/rules/:id", handlers.UpdateCommissionRule)
+	commissions.Delete("/rules/:id", handlers.DeleteCommissionRule)
+	commissions.Post("/statements", handlers.GenerateCommissionStatements)
+	commissions.Post("/statements/:id/approve", handlers.ApproveCommissionStatement)
+
+	apiKeys := router.Group("/api-keys", middleware.ProtectRoute("admin"))
+	apiKeys.Post("/", handlers.CreateAPIKey)
+	apiKeys.Get("/", handlers.GetAPIKeys)
+	apiKeys.Delete("/:id", handlers.RevokeAPIKey)
+
+	notifications := router.Group("/notifications", middleware.ProtectRoute("admin", "seller", "manager"))
+	notifications.Get("/", handlers.GetNotifications)
+	notifications.Post("/:id/read", handlers.MarkNotificationRead)
+
+	audit := router.Group("/audit", middleware.ProtectRoute("admin"))
+	audit.Get("/", handlers.GetAuditLog)
+
+	settings := router.Group("/settings", middleware.ProtectRoute("admin"))
+	settings.Get("/company", handlers.GetCompanySettings)
+	settings.Put("/company", handlers.UpdateCompanySettings)
+	settings.Get("/templates", handlers.GetDocumentTemplates)
+	settings.Post("/templates", handlers.CreateDocumentTemplate)
+	settings.Get("/templates/:id", handlers.GetDocumentTemplate)
+	settings.Put("/templates/:id", handlers.UpdateDocumentTemplate)
+	settings.Delete("/templates/:id", handlers.DeleteDocumentTemplate)
+	settings.Get("/templates/:id/versions", handlers.GetDocumentTemplateVersions)
+	settings.Get("/templates/:id/preview", handlers.PreviewDocumentTemplate)
+	settings.Post("/templates/:id/publish", handlers.PublishDocumentTemplate)
+	settings.Post("/templates/:id/unpublish", handlers.UnpublishDocumentTemplate)
 
 	router.Post("/login", handlers.Login)
+	router.Post("/login/2fa", handlers.LoginTwoFactor)
+
+	twoFactor := router.Group("/2fa", middleware.ProtectTwoFactorSetup())
+	twoFactor.Post("/enroll", handlers.EnrollTwoFactor)
+	twoFactor.Post("/verify", handlers.VerifyTwoFactor)
+
+	twoFactorManage := router.Group("/2fa", middleware.ProtectUserRoute("admin", "seller", "manager"))
+	twoFactorManage.Post("/disable", handlers.DisableTwoFactor)
+	twoFactorManage.Post("/recovery-codes", handlers.RegenerateRecoveryCodes)
 }
@@ -0,0 +1,43 @@
+package tasks
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"log"
+	"strconv"
+	"time"
+)
+
+// StartAuditRetention периодически удаляет записи журнала изменений старше AUDIT_RETENTION_DAYS дней.
+// Значение 0 отключает очистку.
+func StartAuditRetention() {
+	days, err := strconv.Atoi(utils.Getenv("AUDIT_RETENTION_DAYS", "365"))
+	if err != nil || days <= 0 {
+		log.Println("Audit log retention is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(24 * time.Hour)
+		defer ticker.Stop()
+
+		for {
+			purgeAuditLog(days)
+			<-ticker.C
+		}
+	}()
+}
+
+func purgeAuditLog(days int) {
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	result := database.DB.Where("created_at < ?", cutoff).Delete(&model.AuditEntry{})
+	if result.Error != nil {
+		log.Printf("Failed to purge audit log: %v", result.Error)
+		return
+	}
+	if result.RowsAffected > 0 {
+		log.Printf("Purged %d audit entries older than %d days", result.RowsAffected, days)
+	}
+}
@@ -0,0 +1,335 @@
+package tasks
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobResult — итог задачи. WriteFile, если задан, записывает файл результата, который затем
+// можно скачать через API; Data сохраняется в задаче как JSON.
+type JobResult struct {
+	FileName    string
+	ContentType string
+	WriteFile   func(w io.Writer) error
+	Data        interface{}
+}
+
+// JobHandler выполняет задачу одного типа. Ошибка приводит к повторной попытке,
+// если это не PermanentJobError и попытки ещё остались.
+type JobHandler func(job *JobContext) (JobResult, error)
+
+// JobContext передаётся обработчику задачи.
+type JobContext struct {
+	Job model.Job
+}
+
+// DecodePayload разбирает параметры задачи; ошибка разбора повторять задачу смысла не имеет.
+func (j *JobContext) DecodePayload(v interface{}) error {
+	if err := json.Unmarshal(j.Job.Payload, v); err != nil {
+		return PermanentJobError(fmt.Errorf("invalid job payload: %w", err))
+	}
+	return nil
+}
+
+// SetProgress сохраняет прогресс задачи (0–100) и продлевает её блокировку, поэтому долгие
+// задачи должны сообщать о прогрессе чаще, чем раз в JOB_TIMEOUT_MINUTES.
+func (j *JobContext) SetProgress(percent int, message string) {
+	if percent < 0 {
+		percent = 0
+	} else if percent > 99 {
+		percent = 99
+	}
+	lockedUntil := time.Now().Add(jobTimeout)
+	err := database.DB.Model(&model.Job{}).Where("id = ?", j.Job.ID).Updates(map[string]interface{}{
+		"progress":         percent,
+		"progress_message": message,
+		"locked_until":     lockedUntil,
+	}).Error
+	if err != nil {
+		log.Printf("Failed to update progress of job %s: %v", j.Job.ID, err)
+	}
+}
+
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+// PermanentJobError помечает ошибку, после которой задача сразу завершается без повторов.
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+var (
+	jobHandlers = map[string]JobHandler{}
+	jobTimeout  = 30 * time.Minute
+)
+
+// JobsDir возвращает каталог для файлов задач (JOBS_DIR, по умолчанию ./job-files).
+func JobsDir() string {
+	return utils.Getenv("JOBS_DIR", "./job-files")
+}
+
+// EnqueueJob ставит задачу в очередь. payload сохраняется как JSON и передаётся обработчику.
+// inputPath — загруженный файл, который удаляется вместе с задачей.
+func EnqueueJob(db *gorm.DB, jobType string, payload interface{}, inputPath string, createdBy guuid.UUID) (model.Job, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return model.Job{}, err
+	}
+
+	maxAttempts, err := strconv.Atoi(utils.Getenv("JOB_MAX_ATTEMPTS", "3"))
+	if err != nil || maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	job := model.Job{
+		ID:          guuid.New(),
+		Type:        jobType,
+		Status:      model.JobQueued,
+		Payload:     model.JSON(raw),
+		MaxAttempts: maxAttempts,
+		RunAt:       time.Now(),
+		InputPath:   inputPath,
+		CreatedByID: createdBy,
+	}
+	if err := db.Create(&job).Error; err != nil {
+		return model.Job{}, err
+	}
+	return job, nil
+}
+
+// StartJobWorkers запускает JOB_WORKERS воркеров (по умолчанию 2), которые раз в
+// JOB_POLL_INTERVAL_SECONDS секунд проверяют очередь. Значение 0 отключает воркеры в этом
+// экземпляре: задачи останутся в очереди и будут выполнены другим экземпляром.
+// Завершённые задачи и их файлы удаляются через JOB_RETENTION_HOURS часов.
+func StartJobWorkers(handlers map[string]JobHandler) {
+	jobHandlers = handlers
+
+	if minutes, err := strconv.Atoi(utils.Getenv("JOB_TIMEOUT_MINUTES", "30")); err == nil && minutes > 0 {
+		jobTimeout = time.Duration(minutes) * time.Minute
+	}
+
+	if hours, err := strconv.Atoi(utils.Getenv("JOB_RETENTION_HOURS", "72")); err == nil && hours > 0 {
+		go func() {
+			ticker := time.NewTicker(time.Hour)
+			defer ticker.Stop()
+
+			for {
+				purgeJobs(time.Duration(hours) * time.Hour)
+				<-ticker.C
+			}
+		}()
+	}
+
+	workers, err := strconv.Atoi(utils.Getenv("JOB_WORKERS", "2"))
+	if err != nil || workers <= 0 {
+		log.Println("Job workers are disabled")
+		return
+	}
+	seconds, err := strconv.Atoi(utils.Getenv("JOB_POLL_INTERVAL_SECONDS", "2"))
+	if err != nil || seconds <= 0 {
+		seconds = 2
+	}
+
+	for i := 0; i < workers; i++ {
+		go runJobWorker(time.Duration(seconds) * time.Second)
+	}
+}
+
+func runJobWorker(interval time.Duration) {
+	for {
+		job, err := claimJob(database.DB)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				log.Printf("Failed to claim job: %v", err)
+			}
+			time.Sleep(interval)
+			continue
+		}
+		runJob(job)
+	}
+}
+
+// claimJob берёт одну задачу из очереди. Задачи, воркер которых остановился (блокировка истекла),
+// возвращаются в работу, а если попытки исчерпаны — завершаются с ошибкой.
+func claimJob(db *gorm.DB) (model.Job, error) {
+	var job model.Job
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Model(&model.Job{}).
+			Where("status = ? AND locked_until < ? AND attempts >= max_attempts", model.JobRunning, now).
+			Updates(map[string]interface{}{
+				"status":       model.JobFailed,
+				"error":        "job was interrupted",
+				"finished_at":  now,
+				"locked_until": nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("(status = ? AND run_at <= ?) OR (status = ? AND locked_until < ?)",
+				model.JobQueued, now, model.JobRunning, now).
+			Order("run_at").
+			First(&job).Error
+		if err != nil {
+			return err
+		}
+
+		lockedUntil := now.Add(jobTimeout)
+		job.Status = model.JobRunning
+		job.Attempts++
+		job.StartedAt = &now
+		job.LockedUntil = &lockedUntil
+		job.Progress = 0
+		job.ProgressMessage = ""
+		return tx.Model(&job).
+			Select("status", "attempts", "started_at", "locked_until", "progress", "progress_message").
+			Updates(&job).Error
+	})
+	return job, err
+}
+
+func runJob(job model.Job) {
+	result, err := executeJob(job)
+
+	var path string
+	if err == nil && result.WriteFile != nil {
+		path, job.ResultSize, err = saveJobResult(job, result)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"locked_until": nil,
+	}
+	if result.Data != nil {
+		if raw, marshalErr := json.Marshal(result.Data); marshalErr == nil {
+			updates["result"] = model.JSON(raw)
+		}
+	}
+
+	var permanent *permanentJobError
+	switch {
+	case err == nil:
+		updates["status"] = model.JobSucceeded
+		updates["progress"] = 100
+		updates["error"] = ""
+		updates["finished_at"] = now
+		if path != "" {
+			updates["result_path"] = path
+			updates["result_file_name"] = result.FileName
+			updates["result_content_type"] = result.ContentType
+			updates["result_size"] = job.ResultSize
+		}
+	case errors.As(err, &permanent) || job.Attempts >= job.MaxAttempts:
+		log.Printf("Job %s (%s) failed: %v", job.ID, job.Type, err)
+		updates["status"] = model.JobFailed
+		updates["error"] = err.Error()
+		updates["finished_at"] = now
+	default:
+		// Повтор с нарастающей задержкой: 30 секунд, 2 минуты, 4,5 минуты...
+		log.Printf("Job %s (%s) attempt %d failed, will retry: %v", job.ID, job.Type, job.Attempts, err)
+		updates["status"] = model.JobQueued
+		updates["error"] = err.Error()
+		updates["run_at"] = now.Add(time.Duration(job.Attempts*job.Attempts) * 30 * time.Second)
+	}
+
+	if err := database.DB.Model(&model.Job{}).Where("id = ?", job.ID).Updates(updates).Error; err != nil {
+		log.Printf("Failed to save job %s: %v", job.ID, err)
+	}
+}
+
+// executeJob вызывает обработчик; паника в обработчике не должна останавливать воркер.
+func executeJob(job model.Job) (result JobResult, err error) {
+	handler, ok := jobHandlers[job.Type]
+	if !ok {
+		return JobResult{}, PermanentJobError(fmt.Errorf("unknown job type %q", job.Type))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return handler(&JobContext{Job: job})
+}
+
+func saveJobResult(job model.Job, result JobResult) (string, int64, error) {
+	dir := JobsDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", 0, err
+	}
+
+	path := filepath.Join(dir, job.ID.String()+filepath.Ext(result.FileName))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return "", 0, err
+	}
+
+	err = result.WriteFile(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return "", 0, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", 0, err
+	}
+	return path, info.Size(), nil
+}
+
+// RemoveJobFiles удаляет файл результата и загруженный файл задачи.
+func RemoveJobFiles(job model.Job) {
+	for _, path := range []string{job.ResultPath, job.InputPath} {
+		if path == "" {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Failed to remove job file %s: %v", path, err)
+		}
+	}
+}
+
+func purgeJobs(retention time.Duration) {
+	var jobs []model.Job
+	err := database.DB.Where("status IN ? AND finished_at < ?",
+		[]string{model.JobSucceeded, model.JobFailed}, time.Now().Add(-retention)).
+		Find(&jobs).Error
+	if err != nil {
+		log.Printf("Failed to load finished jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		RemoveJobFiles(job)
+		if err := database.DB.Delete(&job).Error; err != nil {
+			log.Printf("Failed to delete job %s: %v", job.ID, err)
+		}
+	}
+	if len(jobs) > 0 {
+		log.Printf("Purged %d finished jobs", len(jobs))
+	}
+}
@@ -0,0 +1,237 @@
+package tasks
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReportFile — сформированный файл отчёта.
+type ReportFile struct {
+	Extension   string
+	ContentType string
+	Content     []byte
+	Rows        int
+	PeriodFrom  *time.Time
+	PeriodTo    *time.Time
+}
+
+// ReportGenerator строит файл по расписанию на момент now.
+type ReportGenerator func(schedule model.ReportSchedule, now time.Time) (ReportFile, error)
+
+var (
+	reportGenerator ReportGenerator
+	// ReportMailer отправляет сформированные отчёты получателям; nil — доставка отключена
+	ReportMailer utils.Mailer
+)
+
+// ReportsDir возвращает каталог для сформированных отчётов (REPORTS_DIR, по умолчанию ./generated-reports).
+func ReportsDir() string {
+	return utils.Getenv("REPORTS_DIR", "./generated-reports")
+}
+
+// StartReportSchedules раз в REPORT_SCHEDULER_INTERVAL_MINUTES минут запускает отчёты, время которых
+// наступило. Значение 0 отключает планировщик; ручной запуск через API работает и без него.
+func StartReportSchedules(generate ReportGenerator) {
+	reportGenerator = generate
+	ReportMailer = utils.NewMailerFromEnv()
+	if ReportMailer == nil {
+		log.Println("SMTP_HOST is not set, scheduled reports will not be emailed")
+	}
+
+	minutes, err := strconv.Atoi(utils.Getenv("REPORT_SCHEDULER_INTERVAL_MINUTES", "1"))
+	if err != nil || minutes <= 0 {
+		log.Println("Report scheduler is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(minutes) * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			runDueReportSchedules()
+			<-ticker.C
+		}
+	}()
+}
+
+// NextScheduleRun возвращает ближайший запуск по cron-выражению после after в часовом поясе бизнеса.
+func NextScheduleRun(cron string, after time.Time) (*time.Time, error) {
+	schedule, err := utils.ParseCron(cron)
+	if err != nil {
+		return nil, err
+	}
+	next := schedule.Next(after.In(utils.BusinessLocation()))
+	if next.IsZero() {
+		return nil, errors.New("cron expression never fires")
+	}
+	return &next, nil
+}
+
+func runDueReportSchedules() {
+	for {
+		schedule, err := claimDueSchedule(database.DB)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to load report schedules: %v", err)
+			return
+		}
+
+		generated, err := RunReportSchedule(schedule)
+		if err != nil {
+			log.Printf("Failed to run report schedule %s: %v", schedule.ID, err)
+			continue
+		}
+		if generated.Status == model.GeneratedReportFailed {
+			log.Printf("Report schedule %s failed: %s", schedule.ID, generated.Error)
+		}
+	}
+}
+
+// claimDueSchedule берёт одно расписание, время которого наступило, и сразу переносит его следующий
+// запуск. Строка блокируется с SKIP LOCKED, поэтому несколько экземпляров не запустят отчёт дважды.
+func claimDueSchedule(db *gorm.DB) (model.ReportSchedule, error) {
+	var schedule model.ReportSchedule
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("active AND next_run_at <= ?", now).
+			Order("next_run_at").
+			First(&schedule).Error
+		if err != nil {
+			return err
+		}
+
+		next, err := NextScheduleRun(schedule.Cron, now)
+		if err != nil {
+			// Расписание, которое больше не сработает, выключается
+			log.Printf("Disabling report schedule %s: %v", schedule.ID, err)
+			schedule.Active = false
+		}
+		schedule.NextRunAt = next
+		schedule.LastRunAt = &now
+		return tx.Model(&schedule).Select("active", "next_run_at", "last_run_at").Updates(&schedule).Error
+	})
+	return schedule, err
+}
+
+// RunReportSchedule формирует отчёт по расписанию, сохраняет файл и отправляет его получателям.
+// Ошибки формирования и доставки записываются в GeneratedReport; ошибка возвращается,
+// только если не удалось сохранить саму запись.
+func RunReportSchedule(schedule model.ReportSchedule) (model.GeneratedReport, error) {
+	now := utils.BusinessNow()
+	generated := model.GeneratedReport{
+		ID:         guuid.New(),
+		ScheduleID: &schedule.ID,
+		Name:       schedule.Name,
+		Recipients: schedule.Recipients,
+		Status:     model.GeneratedReportSuccess,
+	}
+
+	file, err := generateReportFile(schedule, now)
+	if err == nil {
+		err = saveReportFile(&generated, file, now)
+	}
+	if err != nil {
+		generated.Status = model.GeneratedReportFailed
+		generated.Error = err.Error()
+	} else if len(schedule.Recipients) > 0 {
+		if err := deliverReport(generated, file); err != nil {
+			generated.DeliveryError = err.Error()
+		} else {
+			delivered := time.Now()
+			generated.DeliveredAt = &delivered
+		}
+	}
+
+	if err := database.DB.Create(&generated).Error; err != nil {
+		return generated, err
+	}
+	return generated, nil
+}
+
+func generateReportFile(schedule model.ReportSchedule, now time.Time) (ReportFile, error) {
+	if reportGenerator == nil {
+		return ReportFile{}, errors.New("report generator is not configured")
+	}
+	return reportGenerator(schedule, now)
+}
+
+func saveReportFile(generated *model.GeneratedReport, file ReportFile, now time.Time) error {
+	dir := ReportsDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+
+	path := filepath.Join(dir, generated.ID.String()+"."+file.Extension)
+	if err := os.WriteFile(path, file.Content, 0o640); err != nil {
+		return err
+	}
+
+	generated.Path = path
+	generated.FileName = fmt.Sprintf("%s-%s.%s", reportFileSlug(generated.Name), now.Format("2006-01-02"), file.Extension)
+	generated.ContentType = file.ContentType
+	generated.Size = int64(len(file.Content))
+	generated.Rows = file.Rows
+	generated.PeriodFrom = file.PeriodFrom
+	generated.PeriodTo = file.PeriodTo
+	return nil
+}
+
+func deliverReport(generated model.GeneratedReport, file ReportFile) error {
+	if ReportMailer == nil {
+		return utils.ErrMailDisabled
+	}
+
+	body := fmt.Sprintf("Отчёт «%s» сформирован %s.\nСтрок: %d.", generated.Name,
+		utils.BusinessNow().Format("02.01.2006 15:04"), generated.Rows)
+	if generated.PeriodFrom != nil && generated.PeriodTo != nil {
+		body += fmt.Sprintf("\nПериод: %s — %s.", generated.PeriodFrom.Format("02.01.2006"),
+			generated.PeriodTo.AddDate(0, 0, -1).Format("02.01.2006"))
+	}
+
+	return ReportMailer.Send(utils.Mail{
+		To:      generated.Recipients,
+		Subject: generated.Name,
+		Body:    body,
+		Attachments: []utils.MailAttachment{{
+			Filename:    generated.FileName,
+			ContentType: file.ContentType,
+			Content:     file.Content,
+		}},
+	})
+}
+
+// reportFileSlug оставляет в названии отчёта только латинские буквы, цифры и дефисы,
+// чтобы имя файла без проблем передавалось в заголовках.
+func reportFileSlug(name string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ' || r == '_' || r == '-':
+			return '-'
+		case r >= '0' && r <= '9', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+			return r
+		}
+		return -1
+	}, name)
+	if strings.Trim(slug, "-") == "" {
+		return "report"
+	}
+	return slug
+}
@@ -0,0 +1,87 @@
+package tasks
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"fmt"
+	"log"
+	"time"
+
+	guuid "github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// rollupInsert агрегирует позиции заказов, входящих в выручку, по дню, продукту, продавцу и клиенту.
+// Первые два аргумента — часовой пояс бизнеса и статусы выручки, затем аргументы условия.
+const rollupInsert = `
+	INSERT INTO daily_sales_rollups (day, product_id, salesperson_id, client_id, quantity, revenue, cost, order_count)
+	SELECT (items.created_at AT TIME ZONE ?)::date, items.product_id, items.salesperson_id, items.client_id,
+		SUM(items.quantity), SUM(items.total_price), SUM(items.total_cost),
+		COUNT(*) FILTER (WHERE items.first_item)
+	FROM (
+		SELECT orders.created_at, orders.salesperson_id, orders.client_id,
+			order_items.product_id, order_items.quantity, order_items.total_price, order_items.total_cost,
+			ROW_NUMBER() OVER (PARTITION BY order_items.order_id ORDER BY order_items.product_id) = 1 AS first_item
+		FROM order_items
+		JOIN orders ON orders.id = order_items.order_id
+		WHERE orders.status IN ? AND %s
+	) items
+	GROUP BY 1, 2, 3, 4`
+
+// RefreshSalesRollup пересчитывает итоги продавца по клиенту за день, в который создан заказ.
+// Вызывается в той же транзакции, что и изменение заказа. Пересчёты одного дня продавца и клиента
+// выполняются по очереди под advisory-блокировкой до конца транзакции: иначе параллельные DELETE и
+// INSERT сталкиваются на первичном ключе, а итоги считаются без изменений соседней транзакции.
+func RefreshSalesRollup(tx *gorm.DB, createdAt time.Time, sellerID, clientID guuid.UUID) error {
+	location := utils.BusinessLocation()
+	local := createdAt.In(location)
+	from := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	to := from.AddDate(0, 0, 1)
+	day := from.Format("2006-01-02")
+
+	lockKey := "sales_rollup:" + sellerID.String() + ":" + clientID.String() + ":" + day
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", lockKey).Error; err != nil {
+		return err
+	}
+
+	err := tx.Where("day = ? AND salesperson_id = ? AND client_id = ?", day, sellerID, clientID).
+		Delete(&model.DailySalesRollup{}).Error
+	if err != nil {
+		return err
+	}
+
+	condition := "orders.salesperson_id = ? AND orders.client_id = ? AND orders.created_at >= ? AND orders.created_at < ?"
+	return tx.Exec(fmt.Sprintf(rollupInsert, condition),
+		location.String(), utils.RevenueStatuses(), sellerID, clientID, from, to).Error
+}
+
+// BackfillSalesRollups пересобирает дневные итоги по всем заказам. Нужен после первого
+// развёртывания и после смены REVENUE_RECOGNITION.
+func BackfillSalesRollups(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM daily_sales_rollups").Error; err != nil {
+			return err
+		}
+		return tx.Exec(fmt.Sprintf(rollupInsert, "TRUE"), utils.BusinessLocation().String(), utils.RevenueStatuses()).Error
+	})
+}
+
+// EnsureSalesRollups заполняет дневные итоги при первом запуске, если таблица пуста, а заказы уже есть.
+func EnsureSalesRollups() {
+	db := database.DB
+
+	var rollups, orders int64
+	if err := db.Model(&model.DailySalesRollup{}).Count(&rollups).Error; err != nil || rollups > 0 {
+		return
+	}
+	if err := db.Model(&model.Order{}).Where("status IN ?", utils.RevenueStatuses()).Count(&orders).Error; err != nil || orders == 0 {
+		return
+	}
+
+	if err := BackfillSalesRollups(db); err != nil {
+		log.Printf("Failed to backfill sales rollups: %v", err)
+		return
+	}
+	log.Println("Sales rollups backfilled")
+}
@@ -0,0 +1,190 @@
+package tasks
+
+import (
+	"backend/database"
+	"backend/model"
+	"backend/utils"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	guuid "github.com/google/uuid"
+)
+
+// StartStockAlerts запускает проверку остатков каждые STOCK_ALERT_INTERVAL_MINUTES минут
+// и ежедневную сводку для менеджеров в STOCK_DIGEST_HOUR часов. Значение 0 отключает проверку.
+func StartStockAlerts() {
+	minutes, err := strconv.Atoi(utils.Getenv("STOCK_ALERT_INTERVAL_MINUTES", "15"))
+	if err != nil || minutes <= 0 {
+		log.Println("Stock alerts are disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(minutes) * time.Minute)
+		defer ticker.Stop()
+
+		for {
+			checkStockLevels()
+			<-ticker.C
+		}
+	}()
+
+	hour, err := strconv.Atoi(utils.Getenv("STOCK_DIGEST_HOUR", "8"))
+	if err != nil || hour < 0 || hour > 23 {
+		log.Println("Stock digest is disabled")
+		return
+	}
+
+	go func() {
+		for {
+			time.Sleep(time.Until(nextRun(utils.BusinessNow(), hour)))
+			sendStockDigest()
+		}
+	}()
+}
+
+// nextRun возвращает ближайший момент в указанный час в часовом поясе переданного времени.
+func nextRun(now time.Time, hour int) time.Time {
+	next := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+type stockLevel struct {
+	ID              guuid.UUID
+	Amount          float64
+	Reserved        float64
+	MinStock        float64
+	ReorderQuantity float64
+}
+
+// checkStockLevels открывает сигналы по продуктам, у которых доступный остаток (с учётом
+// резерва под ещё не принятые заказы) ниже минимального, и закрывает восстановившиеся.
+func checkStockLevels() {
+	db := database.DB
+
+	var levels []stockLevel
+	err := db.Model(&model.Product{}).
+		Select("products.id, products.amount, products.min_stock, products.reorder_quantity, COALESCE(SUM(order_items.quantity), 0) AS reserved").
+		Joins("LEFT JOIN order_items ON order_items.product_id = products.id AND order_items.order_id IN (SELECT id FROM orders WHERE status = ?)", "pending").
+		Where("products.min_stock > 0").
+		Group("products.id").
+		Scan(&levels).Error
+	if err != nil {
+		log.Printf("Failed to check stock levels: %v", err)
+		return
+	}
+
+	var openAlerts []model.StockAlert
+	if err := db.Where("status = ?", model.AlertOpen).Find(&openAlerts).Error; err != nil {
+		log.Printf("Failed to load stock alerts: %v", err)
+		return
+	}
+
+	open := make(map[guuid.UUID]model.StockAlert, len(openAlerts))
+	for _, alert := range openAlerts {
+		open[alert.ProductID] = alert
+	}
+
+	now := time.Now()
+	for _, level := range levels {
+		available := level.Amount - level.Reserved
+		alert, exists := open[level.ID]
+		delete(open, level.ID)
+
+		if available >= level.MinStock {
+			if exists {
+				resolveStockAlert(alert, now)
+			}
+			continue
+		}
+
+		if !exists {
+			alert = model.StockAlert{
+				ID:        guuid.New(),
+				ProductID: level.ID,
+				Status:    model.AlertOpen,
+			}
+		}
+		alert.Amount = level.Amount
+		alert.Reserved = level.Reserved
+		alert.Available = available
+		alert.MinStock = level.MinStock
+		alert.ReorderQuantity = level.ReorderQuantity
+
+		if err := db.Save(&alert).Error; err != nil {
+			log.Printf("Failed to save stock alert for product %s: %v", level.ID, err)
+		}
+	}
+
+	// Порог сброшен или продукт удалён — сигнал больше не актуален
+	for _, alert := range open {
+		resolveStockAlert(alert, now)
+	}
+}
+
+func resolveStockAlert(alert model.StockAlert, now time.Time) {
+	err := database.DB.Model(&alert).Updates(map[string]interface{}{
+		"status":      model.AlertResolved,
+		"resolved_at": now,
+	}).Error
+	if err != nil {
+		log.Printf("Failed to resolve stock alert %s: %v", alert.ID, err)
+	}
+}
+
+// sendStockDigest отправляет каждому активному менеджеру сводку по открытым сигналам.
+func sendStockDigest() {
+	db := database.DB
+
+	var alerts []model.StockAlert
+	err := db.Preload("Product").
+		Where("status = ?", model.AlertOpen).
+		Order("available - min_stock").
+		Find(&alerts).Error
+	if err != nil {
+		log.Printf("Failed to load stock alerts for digest: %v", err)
+		return
+	}
+	if len(alerts) == 0 {
+		return
+	}
+
+	var lines []string
+	for _, alert := range alerts {
+		name := alert.ProductID.String()
+		if alert.Product != nil {
+			name = alert.Product.Name
+		}
+		line := fmt.Sprintf("%s: доступно %g (на складе %g, в резерве %g), минимум %g", name, alert.Available, alert.Amount, alert.Reserved, alert.MinStock)
+		if alert.ReorderQuantity > 0 {
+			line += fmt.Sprintf(", рекомендуется заказать %g", alert.ReorderQuantity)
+		}
+		lines = append(lines, line)
+	}
+	body := strings.Join(lines, "\n")
+
+	var managers []model.User
+	if err := db.Where("role = ? AND active = ?", model.Manager, true).Find(&managers).Error; err != nil {
+		log.Printf("Failed to load managers for stock digest: %v", err)
+		return
+	}
+
+	for _, manager := range managers {
+		notification := model.Notification{
+			ID:     guuid.New(),
+			UserID: manager.ID,
+			Type:   model.NotificationStockDigest,
+			Title:  fmt.Sprintf("Низкий остаток: %d продуктов", len(alerts)),
+			Body:   body,
+		}
+		if err := db.Create(&notification).Error; err != nil {
+			log.Printf("Failed to create stock digest for %s: %v", manager.Username, err)
+		}
+	}
+}
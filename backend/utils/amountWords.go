@@ -0,0 +1,194 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Названия валют для суммы прописью: формы для 1, 2–4 и 5+ (в узбекском формы совпадают)
+// и род основной единицы — от него зависит «один/одна» в русском.
+type currencyWords struct {
+	major, minor [3]string
+	majorFemale  bool
+	minorFemale  bool
+}
+
+var amountCurrencies = map[string]map[string]currencyWords{
+	"ru": {
+		"UZS": {major: [3]string{"сум", "сума", "сумов"}, minor: [3]string{"тийин", "тийина", "тийинов"}},
+		"USD": {major: [3]string{"доллар", "доллара", "долларов"}, minor: [3]string{"цент", "цента", "центов"}},
+		"RUB": {major: [3]string{"рубль", "рубля", "рублей"}, minor: [3]string{"копейка", "копейки", "копеек"}, minorFemale: true},
+	},
+	"uz": {
+		"UZS": {major: [3]string{"so'm", "so'm", "so'm"}, minor: [3]string{"tiyin", "tiyin", "tiyin"}},
+		"USD": {major: [3]string{"dollar", "dollar", "dollar"}, minor: [3]string{"sent", "sent", "sent"}},
+		"RUB": {major: [3]string{"rubl", "rubl", "rubl"}, minor: [3]string{"kopeyka", "kopeyka", "kopeyka"}},
+	},
+}
+
+// AmountInWords записывает сумму прописью на русском (ru) или узбекском (uz, латиница):
+// целая часть словами, дробная — двумя цифрами, например
+// «Одна тысяча двести сумов 50 тийинов». Неизвестная валюта выводится кодом.
+func AmountInWords(amount float64, currency, lang string) string {
+	if lang != "uz" {
+		lang = "ru"
+	}
+	words, ok := amountCurrencies[lang][strings.ToUpper(currency)]
+	if !ok {
+		code := strings.ToUpper(currency)
+		words = currencyWords{major: [3]string{code, code, code}, minor: [3]string{"", "", ""}}
+	}
+
+	negative := amount < 0
+	cents := int64(math.Round(math.Abs(amount) * 100))
+	major, minor := cents/100, cents%100
+
+	var text string
+	if lang == "uz" {
+		text = uzbekNumber(major)
+	} else {
+		text = russianNumber(major, words.majorFemale)
+	}
+	text += " " + words.major[russianPluralForm(major)]
+	if words.minor[0] != "" {
+		text += fmt.Sprintf(" %02d %s", minor, words.minor[russianPluralForm(minor)])
+	}
+	if negative {
+		if lang == "uz" {
+			text = "minus " + text
+		} else {
+			text = "минус " + text
+		}
+	}
+	return capitalize(text)
+}
+
+// russianPluralForm возвращает индекс формы: 0 — «один сум», 1 — «два сума», 2 — «пять сумов».
+func russianPluralForm(n int64) int {
+	n %= 100
+	if n >= 11 && n <= 14 {
+		return 2
+	}
+	switch n % 10 {
+	case 1:
+		return 0
+	case 2, 3, 4:
+		return 1
+	}
+	return 2
+}
+
+var (
+	ruOnes     = [10]string{"", "один", "два", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	ruOnesFem  = [10]string{"", "одна", "две", "три", "четыре", "пять", "шесть", "семь", "восемь", "девять"}
+	ruTeens    = [10]string{"десять", "одиннадцать", "двенадцать", "тринадцать", "четырнадцать", "пятнадцать", "шестнадцать", "семнадцать", "восемнадцать", "девятнадцать"}
+	ruTens     = [10]string{"", "", "двадцать", "тридцать", "сорок", "пятьдесят", "шестьдесят", "семьдесят", "восемьдесят", "девяносто"}
+	ruHundreds = [10]string{"", "сто", "двести", "триста", "четыреста", "пятьсот", "шестьсот", "семьсот", "восемьсот", "девятьсот"}
+	ruScales   = []struct {
+		forms  [3]string
+		female bool
+	}{
+		{forms: [3]string{"тысяча", "тысячи", "тысяч"}, female: true},
+		{forms: [3]string{"миллион", "миллиона", "миллионов"}},
+		{forms: [3]string{"миллиард", "миллиарда", "миллиардов"}},
+		{forms: [3]string{"триллион", "триллиона", "триллионов"}},
+	}
+)
+
+func russianNumber(n int64, female bool) string {
+	if n == 0 {
+		return "ноль"
+	}
+
+	var parts []string
+	groups := splitThousands(n)
+	for i := len(groups) - 1; i >= 0; i-- {
+		group := groups[i]
+		if group == 0 {
+			continue
+		}
+		groupFemale := female
+		if i > 0 {
+			groupFemale = ruScales[i-1].female
+		}
+		parts = append(parts, russianTriple(group, groupFemale)...)
+		if i > 0 {
+			parts = append(parts, ruScales[i-1].forms[russianPluralForm(group)])
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func russianTriple(n int64, female bool) []string {
+	var parts []string
+	if n >= 100 {
+		parts = append(parts, ruHundreds[n/100])
+	}
+	n %= 100
+	switch {
+	case n >= 10 && n < 20:
+		return append(parts, ruTeens[n-10])
+	case n >= 20:
+		parts = append(parts, ruTens[n/10])
+	}
+	if n%10 > 0 {
+		if female {
+			parts = append(parts, ruOnesFem[n%10])
+		} else {
+			parts = append(parts, ruOnes[n%10])
+		}
+	}
+	return parts
+}
+
+var (
+	uzOnes   = [10]string{"", "bir", "ikki", "uch", "to'rt", "besh", "olti", "yetti", "sakkiz", "to'qqiz"}
+	uzTens   = [10]string{"", "o'n", "yigirma", "o'ttiz", "qirq", "ellik", "oltmish", "yetmish", "sakson", "to'qson"}
+	uzScales = []string{"ming", "million", "milliard", "trillion"}
+)
+
+func uzbekNumber(n int64) string {
+	if n == 0 {
+		return "nol"
+	}
+
+	var parts []string
+	groups := splitThousands(n)
+	for i := len(groups) - 1; i >= 0; i-- {
+		group := groups[i]
+		if group == 0 {
+			continue
+		}
+		if group >= 100 {
+			parts = append(parts, uzOnes[group/100], "yuz")
+		}
+		if tens := group % 100 / 10; tens > 0 {
+			parts = append(parts, uzTens[tens])
+		}
+		if ones := group % 10; ones > 0 {
+			parts = append(parts, uzOnes[ones])
+		}
+		if i > 0 {
+			parts = append(parts, uzScales[i-1])
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// splitThousands раскладывает число на группы по три цифры, начиная с младшей.
+func splitThousands(n int64) []int64 {
+	var groups []int64
+	for n > 0 && len(groups) <= len(ruScales) {
+		groups = append(groups, n%1000)
+		n /= 1000
+	}
+	return groups
+}
+
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
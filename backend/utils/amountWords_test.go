@@ -0,0 +1,48 @@
+package utils
+
+import "testing"
+
+func TestAmountInWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   float64
+		currency string
+		lang     string
+		want     string
+	}{
+		{"ru one", 1, "UZS", "ru", "Один сум 00 тийинов"},
+		{"ru two", 2, "UZS", "ru", "Два сума 00 тийинов"},
+		{"ru five", 5, "UZS", "ru", "Пять сумов 00 тийинов"},
+		{"ru eleven", 11, "UZS", "ru", "Одиннадцать сумов 00 тийинов"},
+		{"ru twenty one", 21, "UZS", "ru", "Двадцать один сум 00 тийинов"},
+		{"ru hundred twelve", 112, "UZS", "ru", "Сто двенадцать сумов 00 тийинов"},
+		{"ru zero", 0, "UZS", "ru", "Ноль сумов 00 тийинов"},
+		{"ru one thousand", 1200.5, "UZS", "ru", "Одна тысяча двести сумов 50 тийинов"},
+		{"ru thousand and one", 1001.01, "UZS", "ru", "Одна тысяча один сум 01 тийин"},
+		{"ru twenty two thousand", 22000, "UZS", "ru", "Двадцать две тысячи сумов 00 тийинов"},
+		{"ru eleven thousand", 11000, "UZS", "ru", "Одиннадцать тысяч сумов 00 тийинов"},
+		{"ru millions", 2005000, "UZS", "ru", "Два миллиона пять тысяч сумов 00 тийинов"},
+		{"ru billion", 1000000000, "UZS", "ru", "Один миллиард сумов 00 тийинов"},
+		{"ru negative", -5, "UZS", "ru", "Минус пять сумов 00 тийинов"},
+		{"ru rounds to cents", 1.999, "UZS", "ru", "Два сума 00 тийинов"},
+		{"ru female minor unit", 21.21, "RUB", "ru", "Двадцать один рубль 21 копейка"},
+		{"ru two kopecks", 1.02, "RUB", "ru", "Один рубль 02 копейки"},
+		{"ru lowercase currency", 3.5, "usd", "ru", "Три доллара 50 центов"},
+		{"ru unknown currency", 5, "EUR", "ru", "Пять EUR"},
+		{"unknown language falls back to ru", 2, "UZS", "en", "Два сума 00 тийинов"},
+		{"uz basic", 21, "UZS", "uz", "Yigirma bir so'm 00 tiyin"},
+		{"uz thousands", 1200.5, "UZS", "uz", "Bir ming ikki yuz so'm 50 tiyin"},
+		{"uz tens and hundreds", 345678, "UZS", "uz", "Uch yuz qirq besh ming olti yuz yetmish sakkiz so'm 00 tiyin"},
+		{"uz million", 3000000, "USD", "uz", "Uch million dollar 00 sent"},
+		{"uz zero", 0, "UZS", "uz", "Nol so'm 00 tiyin"},
+		{"uz negative", -5, "UZS", "uz", "Minus besh so'm 00 tiyin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AmountInWords(tt.amount, tt.currency, tt.lang); got != tt.want {
+				t.Errorf("AmountInWords(%v, %q, %q) = %q, want %q", tt.amount, tt.currency, tt.lang, got, tt.want)
+			}
+		})
+	}
+}
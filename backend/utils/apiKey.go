@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+)
+
+const apiKeyPrefix = "crm"
+
+// GenerateAPIKey возвращает ключ в открытом виде (показывается один раз), его публичный префикс
+// для поиска в БД и хэш для хранения.
+func GenerateAPIKey() (key, prefix, hash string, err error) {
+	prefixBytes := make([]byte, 4)
+	secretBytes := make([]byte, 24)
+	if _, err = rand.Read(prefixBytes); err != nil {
+		return
+	}
+	if _, err = rand.Read(secretBytes); err != nil {
+		return
+	}
+
+	prefix = hex.EncodeToString(prefixBytes)
+	key = apiKeyPrefix + "_" + prefix + "_" + hex.EncodeToString(secretBytes)
+	hash = HashAPIKey(key)
+	return
+}
+
+// ParseAPIKeyPrefix извлекает публичный префикс из ключа.
+func ParseAPIKeyPrefix(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return "", false
+	}
+	return parts[1], true
+}
+
+// HashAPIKey хэширует ключ. Ключи случайные и длинные, поэтому достаточно SHA-256 без соли.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IPAllowed проверяет адрес по списку IP-адресов и подсетей в нотации CIDR.
+// Пустой список разрешает любой адрес.
+func IPAllowed(ip string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, entry := range allowed {
+		if strings.Contains(entry, "/") {
+			if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(parsed) {
+				return true
+			}
+		} else if allowedIP := net.ParseIP(entry); allowedIP != nil && allowedIP.Equal(parsed) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package utils
+
+import "testing"
+
+func TestIPAllowed(t *testing.T) {
+	tests := []struct {
+		name    string
+		ip      string
+		allowed []string
+		want    bool
+	}{
+		{"empty list allows any", "203.0.113.7", nil, true},
+		{"single ip match", "203.0.113.7", []string{"203.0.113.7"}, true},
+		{"single ip mismatch", "203.0.113.8", []string{"203.0.113.7"}, false},
+		{"cidr contains", "10.1.2.3", []string{"10.0.0.0/8"}, true},
+		{"cidr excludes", "11.1.2.3", []string{"10.0.0.0/8"}, false},
+		{"cidr upper bound", "192.168.1.255", []string{"192.168.1.0/24"}, true},
+		{"second entry matches", "192.168.1.10", []string{"10.0.0.0/8", "192.168.1.10"}, true},
+		{"ipv6 single", "2001:db8::1", []string{"2001:db8::1"}, true},
+		{"ipv6 cidr", "2001:db8::abcd", []string{"2001:db8::/32"}, true},
+		{"ipv4 mapped ipv6", "::ffff:203.0.113.7", []string{"203.0.113.7"}, true},
+		{"invalid entry skipped", "203.0.113.7", []string{"not-an-ip", "10.0.0.0/33", "203.0.113.7"}, true},
+		{"only invalid entries", "203.0.113.7", []string{"not-an-ip"}, false},
+		{"invalid client ip", "unknown", []string{"0.0.0.0/0"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IPAllowed(tt.ip, tt.allowed); got != tt.want {
+				t.Errorf("IPAllowed(%q, %v) = %v, want %v", tt.ip, tt.allowed, got, tt.want)
+			}
+		})
+	}
+}
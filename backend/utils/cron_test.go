@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	after := time.Date(2026, 3, 10, 10, 30, 0, 0, time.UTC) // вторник
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2026, 3, 10, 10, 31, 0, 0, time.UTC)},
+		{"strictly after", "30 10 * * *", time.Date(2026, 3, 11, 10, 30, 0, 0, time.UTC)},
+		{"first of month", "0 8 1 * *", time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC)},
+		{"minute step", "*/15 * * * *", time.Date(2026, 3, 10, 10, 45, 0, 0, time.UTC)},
+		{"range with step", "0 10-20/5 * * *", time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)},
+		{"value with step", "0 9/6 * * *", time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)},
+		{"list", "0,30 * * * *", time.Date(2026, 3, 10, 11, 0, 0, 0, time.UTC)},
+		{"weekdays", "0 9 * * 1-5", time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 0", "0 0 * * 0", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 0 * * 7", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"day or weekday, weekday first", "0 0 20 * 4", time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)},
+		{"day or weekday, day first", "0 0 11 * 5", time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"day and any weekday", "0 0 11 * *", time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{"month restriction", "0 0 * 6 *", time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"weekly macro", "@weekly", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"monthly macro", "@monthly", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"yearly macro", "@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"impossible date", "0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error: %v", tt.expr, err)
+			}
+			if got := schedule.Next(after); !got.Equal(tt.want) {
+				t.Errorf("Next(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNextLocation(t *testing.T) {
+	tashkent := time.FixedZone("UTC+5", 5*60*60)
+	schedule, err := ParseCron("0 8 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 04:00 UTC — уже 09:00 в Ташкенте, поэтому следующий запуск завтра в 08:00 по местному времени
+	got := schedule.Next(time.Date(2026, 3, 10, 4, 0, 0, 0, time.UTC).In(tashkent))
+	want := time.Date(2026, 3, 11, 8, 0, 0, 0, tashkent)
+	if !got.Equal(want) || got.Location() != tashkent {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"too few fields", "* * * *"},
+		{"too many fields", "* * * * * *"},
+		{"unknown macro", "@reboot"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day zero", "0 0 0 * *"},
+		{"month out of range", "0 0 1 13 *"},
+		{"weekday out of range", "0 0 * * 8"},
+		{"zero step", "*/0 * * * *"},
+		{"reversed range", "5-1 * * * *"},
+		{"not a number", "a * * * *"},
+		{"empty list item", "1,,2 * * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); err == nil {
+				t.Errorf("ParseCron(%q) expected error", tt.expr)
+			}
+		})
+	}
+}
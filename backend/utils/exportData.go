@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
 	"reflect"
 	"strings"
@@ -108,3 +110,106 @@ func getNestedFieldValue(structValue reflect.Value, fieldPath string) (reflect.V
 	}
 	return structValue, nil
 }
+
+// ExportRowsToExcel отдаёт строки произвольных значений файлом Excel с колонками в порядке headers.
+func ExportRowsToExcel(ctx *fiber.Ctx, headers []string, rows [][]interface{}, filename string) error {
+	content, err := RowsToExcel(headers, rows)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).SendString("Failed to create Excel file")
+	}
+
+	ctx.Set("Content-Type", ExcelContentType)
+	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Send(content)
+}
+
+// ExportRowsToCSV отдаёт строки произвольных значений файлом CSV с колонками в порядке headers.
+func ExportRowsToCSV(ctx *fiber.Ctx, headers []string, rows [][]interface{}, filename string) error {
+	content, err := RowsToCSV(headers, rows)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).SendString("Failed to create CSV file")
+	}
+
+	ctx.Set("Content-Type", CSVContentType)
+	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	return ctx.Send(content)
+}
+
+const (
+	ExcelContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	CSVContentType   = "text/csv; charset=utf-8"
+)
+
+// RowsToExcel собирает файл Excel из заголовков и строк значений.
+func RowsToExcel(headers []string, rows [][]interface{}) ([]byte, error) {
+	f := excelize.NewFile()
+	sheetName := "Sheet1"
+
+	for i, header := range headers {
+		if err := f.SetCellValue(sheetName, getColumnName(i+1)+"1", header); err != nil {
+			return nil, err
+		}
+	}
+	for rowIndex, row := range rows {
+		for colIndex, value := range row {
+			cell := getColumnName(colIndex+1) + fmt.Sprintf("%d", rowIndex+2)
+			if err := f.SetCellValue(sheetName, cell, value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RowsToCSV собирает файл CSV из заголовков и строк значений; nil записывается пустой строкой.
+func RowsToCSV(headers []string, rows [][]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(headers); err != nil {
+		return nil, err
+	}
+	record := make([]string, len(headers))
+	for _, row := range rows {
+		for i, value := range row {
+			record[i] = csvValue(exportValue(value))
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// StructRows переводит срез структур в строки значений по путям полей, как ExportDataToExcel.
+func StructRows(data interface{}, fields []string) ([][]interface{}, error) {
+	val := reflect.ValueOf(data)
+	if val.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("data must be a slice")
+	}
+
+	rows := make([][]interface{}, val.Len())
+	for rowIndex := range rows {
+		rowVal := reflect.Indirect(val.Index(rowIndex))
+		if rowVal.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("data must be a slice of structs")
+		}
+
+		row := make([]interface{}, len(fields))
+		for colIndex, fieldPath := range fields {
+			fieldVal, err := getNestedFieldValue(rowVal, fieldPath)
+			if err != nil {
+				return nil, err
+			}
+			row[colIndex] = fieldVal.Interface()
+		}
+		rows[rowIndex] = row
+	}
+	return rows, nil
+}
@@ -0,0 +1,172 @@
+package utils
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/xuri/excelize/v2"
+)
+
+// StreamRowsToExcel записывает результат запроса в Excel через StreamWriter: строки по мере чтения
+// сбрасываются во временный файл, а не копятся в памяти. Готовая книга отдаётся потоком. rows закрывается.
+func StreamRowsToExcel(ctx *fiber.Ctx, headers []string, rows *sql.Rows, filename string) error {
+	f, err := rowsToExcelFile(headers, rows)
+	if err != nil {
+		return ctx.Status(fiber.StatusInternalServerError).SendString("Failed to write Excel file: " + err.Error())
+	}
+
+	ctx.Set("Content-Type", ExcelContentType)
+	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer f.Close()
+		if err := f.Write(w); err != nil {
+			log.Printf("Failed to stream %s: %v", filename, err)
+			return
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// StreamRowsToCSV отдаёт результат запроса в CSV, записывая строки в ответ по мере чтения из базы.
+// После начала передачи ошибку уже нельзя вернуть статусом, поэтому она только пишется в лог. rows закрывается.
+func StreamRowsToCSV(ctx *fiber.Ctx, headers []string, rows *sql.Rows, filename string) error {
+	ctx.Set("Content-Type", CSVContentType)
+	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := WriteRowsToCSV(w, headers, rows); err != nil {
+			log.Printf("Failed to stream %s: %v", filename, err)
+			return
+		}
+		w.Flush()
+	})
+	return nil
+}
+
+// WriteRowsToExcel записывает результат запроса в w в формате Excel. rows закрывается.
+func WriteRowsToExcel(w io.Writer, headers []string, rows *sql.Rows) error {
+	f, err := rowsToExcelFile(headers, rows)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return f.Write(w)
+}
+
+// WriteRowsToCSV записывает результат запроса в w в формате CSV. rows закрывается.
+func WriteRowsToCSV(w io.Writer, headers []string, rows *sql.Rows) error {
+	defer rows.Close()
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(headers); err != nil {
+		return err
+	}
+
+	record := make([]string, len(headers))
+	err := scanRows(rows, len(headers), func(values []interface{}) error {
+		for i, value := range values {
+			record[i] = csvValue(exportValue(value))
+		}
+		return writer.Write(record)
+	})
+	writer.Flush()
+	if err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// rowsToExcelFile собирает книгу через StreamWriter. rows закрывается; книгу закрывает вызывающий.
+func rowsToExcelFile(headers []string, rows *sql.Rows) (*excelize.File, error) {
+	defer rows.Close()
+
+	f := excelize.NewFile()
+	writer, err := f.NewStreamWriter("Sheet1")
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	headerRow := make([]interface{}, len(headers))
+	for i, header := range headers {
+		headerRow[i] = header
+	}
+	if err := writer.SetRow("A1", headerRow); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	rowIndex := 2
+	err = scanRows(rows, len(headers), func(values []interface{}) error {
+		if rowIndex > excelize.TotalRows {
+			return fmt.Errorf("more than %d rows, use CSV format", excelize.TotalRows-1)
+		}
+		for i, value := range values {
+			values[i] = exportValue(value)
+		}
+		cell, err := excelize.CoordinatesToCellName(1, rowIndex)
+		if err != nil {
+			return err
+		}
+		rowIndex++
+		return writer.SetRow(cell, values)
+	})
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// scanRows вызывает handle для каждой строки результата. Срез значений переиспользуется между строками.
+func scanRows(rows *sql.Rows, columns int, handle func(values []interface{}) error) error {
+	values := make([]interface{}, columns)
+	pointers := make([]interface{}, columns)
+	for rows.Next() {
+		for i := range values {
+			values[i] = nil
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		if err := handle(values); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// exportValue приводит значение из базы к виду для выгрузки: время — в часовом поясе бизнеса.
+func exportValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.In(BusinessLocation()).Format("2006-01-02 15:04:05")
+	}
+	return value
+}
+
+// csvValue форматирует значение для CSV; дробные числа пишутся без экспоненты.
+func csvValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return fmt.Sprint(value)
+}
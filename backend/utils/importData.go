@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ImportRow — строка загружаемого файла; Number — номер строки в файле (заголовок — строка 1).
+type ImportRow struct {
+	Number int
+	Values map[string]string
+}
+
+// ReadImportFile читает XLSX или CSV (формат определяется по расширению filename) с заголовком
+// в первой строке. Значения строк сопоставляются с headers без учёта регистра; неизвестные колонки
+// игнорируются, пустые строки пропускаются. Если в файле нет колонок из required, возвращается ошибка.
+func ReadImportFile(filename string, src io.Reader, headers, required []string, maxRows int) ([]ImportRow, error) {
+	var (
+		records [][]string
+		err     error
+	)
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".xlsx":
+		records, err = readExcelRecords(src)
+	case ".csv":
+		records, err = readCSVRecords(src)
+	default:
+		return nil, errors.New("unsupported file format, use .xlsx or .csv")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	columns := make(map[int]string)
+	present := make(map[string]bool)
+	for i, cell := range records[0] {
+		for _, header := range headers {
+			if strings.EqualFold(strings.TrimSpace(cell), header) {
+				columns[i] = header
+				present[header] = true
+			}
+		}
+	}
+	for _, header := range required {
+		if !present[header] {
+			return nil, fmt.Errorf("column %q is missing", header)
+		}
+	}
+
+	var rows []ImportRow
+	for i, record := range records[1:] {
+		row := ImportRow{Number: i + 2, Values: make(map[string]string, len(columns))}
+		empty := true
+		for index, header := range columns {
+			if index < len(record) {
+				row.Values[header] = strings.TrimSpace(record[index])
+				empty = empty && row.Values[header] == ""
+			} else {
+				row.Values[header] = ""
+			}
+		}
+		if empty {
+			continue
+		}
+		if len(rows) == maxRows {
+			return nil, fmt.Errorf("file has more than %d rows", maxRows)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Has сообщает, была ли колонка в файле.
+func (r ImportRow) Has(header string) bool {
+	_, ok := r.Values[header]
+	return ok
+}
+
+// Float разбирает число; допускается десятичная запятая и пробелы между разрядами.
+func (r ImportRow) Float(header string) (float64, error) {
+	value := strings.NewReplacer(" ", "", " ", "", ",", ".").Replace(r.Values[header])
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func readExcelRecords(src io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(src)
+	if err != nil {
+		return nil, errors.New("could not read XLSX file")
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("file is empty")
+	}
+	return f.GetRows(sheets[0])
+}
+
+// readCSVRecords читает CSV с разделителем «,» или «;» (так сохраняет Excel с русской локалью).
+func readCSVRecords(src io.Reader) ([][]string, error) {
+	buffered := bufio.NewReader(src)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		buffered.Discard(3)
+	}
+
+	reader := csv.NewReader(buffered)
+	reader.FieldsPerRecord = -1
+	// Peek возвращает меньше данных, если файл короче буфера, — этого достаточно для заголовка
+	head, _ := buffered.Peek(4096)
+	line, _, _ := bytes.Cut(head, []byte("\n"))
+	if bytes.Count(line, []byte(";")) > bytes.Count(line, []byte(",")) {
+		reader.Comma = ';'
+	}
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("could not read CSV file: %w", err)
+	}
+	return records, nil
+}
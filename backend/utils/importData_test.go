@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func xlsxFile(t *testing.T, rows [][]interface{}) []byte {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadImportFile(t *testing.T) {
+	headers := []string{"Name", "Price", "Amount"}
+	required := []string{"Name"}
+
+	xlsx := xlsxFile(t, [][]interface{}{
+		{"name", "PRICE", "Comment"},
+		{"Стол", 1500, "не импортируется"},
+		{},
+		{"Стул", "99,5"},
+	})
+
+	tests := []struct {
+		name     string
+		filename string
+		content  []byte
+		maxRows  int
+		want     []ImportRow
+		wantErr  string
+	}{
+		{
+			name:     "csv with comma",
+			filename: "products.csv",
+			content:  []byte("Name,Price,Amount\nСтол,1500,3\n"),
+			maxRows:  10,
+			want:     []ImportRow{{Number: 2, Values: map[string]string{"Name": "Стол", "Price": "1500", "Amount": "3"}}},
+		},
+		{
+			name:     "csv with semicolon and bom",
+			filename: "PRODUCTS.CSV",
+			content:  []byte("\xef\xbb\xbfName;Price\nСтол;1 500,50\n"),
+			maxRows:  10,
+			want:     []ImportRow{{Number: 2, Values: map[string]string{"Name": "Стол", "Price": "1 500,50"}}},
+		},
+		{
+			name:     "csv headers are case-insensitive, unknown and empty rows skipped",
+			filename: "products.csv",
+			content:  []byte(" name ,Comment,amount\nСтол,x,2\n,,\nСтул\n"),
+			maxRows:  10,
+			want: []ImportRow{
+				{Number: 2, Values: map[string]string{"Name": "Стол", "Amount": "2"}},
+				{Number: 4, Values: map[string]string{"Name": "Стул", "Amount": ""}},
+			},
+		},
+		{
+			name:     "xlsx",
+			filename: "products.xlsx",
+			content:  xlsx,
+			maxRows:  10,
+			want: []ImportRow{
+				{Number: 2, Values: map[string]string{"Name": "Стол", "Price": "1500"}},
+				{Number: 4, Values: map[string]string{"Name": "Стул", "Price": "99,5"}},
+			},
+		},
+		{
+			name:     "missing required column",
+			filename: "products.csv",
+			content:  []byte("Title,Price\nСтол,1500\n"),
+			maxRows:  10,
+			wantErr:  `column "Name" is missing`,
+		},
+		{
+			name:     "too many rows",
+			filename: "products.csv",
+			content:  []byte("Name\nСтол\nСтул\n"),
+			maxRows:  1,
+			wantErr:  "file has more than 1 rows",
+		},
+		{
+			name:     "empty file",
+			filename: "products.csv",
+			content:  nil,
+			maxRows:  10,
+			wantErr:  "file is empty",
+		},
+		{
+			name:     "csv content with xlsx extension",
+			filename: "products.xlsx",
+			content:  []byte("Name\nСтол\n"),
+			maxRows:  10,
+			wantErr:  "could not read XLSX file",
+		},
+		{
+			name:     "unsupported extension",
+			filename: "products.xls",
+			content:  []byte("Name\nСтол\n"),
+			maxRows:  10,
+			wantErr:  "unsupported file format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadImportFile(tt.filename, bytes.NewReader(tt.content), headers, required, tt.maxRows)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ReadImportFile() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadImportFile() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReadImportFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportRowFloat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"1500", 1500, false},
+		{"99,5", 99.5, false},
+		{"1 500,50", 1500.5, false},
+		{"1 500", 1500, false},
+		{"abc", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ImportRow{Values: map[string]string{"Price": tt.value}}.Float("Price")
+			if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+				t.Errorf("Float(%q) = (%v, %v), want %v", tt.value, got, err, tt.want)
+			}
+		})
+	}
+}
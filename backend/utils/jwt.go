@@ -3,6 +3,7 @@ package utils
 import (
 	"backend/auth"
 	"backend/model"
+	"errors"
 	"os"
 	"time"
 
@@ -11,14 +12,29 @@ import (
 
 var jwtSecretKey = []byte(os.Getenv("jwt_secret"))
 
+// Время жизни промежуточного токена между вводом пароля и вводом кода 2FA
+const preAuthTTL = 5 * time.Minute
+
 func GenerateJWT(user model.User) (string, error) {
+	return signJWT(user, "", "", time.Hour*24)
+}
+
+// GeneratePreAuthJWT выдаёт короткоживущий токен, который годится только для завершения входа
+// (auth.ScopeTwoFactorLogin) или подключения 2FA (auth.ScopeTwoFactorEnroll).
+// tokenID записывается в jti, по нему считаются попытки ввода кода.
+func GeneratePreAuthJWT(user model.User, scope, tokenID string) (string, error) {
+	return signJWT(user, scope, tokenID, preAuthTTL)
+}
 
+func signJWT(user model.User, scope, tokenID string, ttl time.Duration) (string, error) {
 	claims := &auth.Claims{
 		ID:       user.ID,
 		Username: user.Username,
 		Role:     user.Role,
+		Scope:    scope,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Hour * 24).Unix(),
+			Id:        tokenID,
+			ExpiresAt: time.Now().Add(ttl).Unix(),
 			IssuedAt:  time.Now().Unix(),
 		},
 	}
@@ -27,3 +43,19 @@ func GenerateJWT(user model.User) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(jwtSecretKey)
 }
+
+// ParseJWT проверяет подпись и срок действия токена и возвращает его claims.
+func ParseJWT(tokenString string) (*auth.Claims, error) {
+	claims := &auth.Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return jwtSecretKey, nil
+	})
+
+	if err != nil || !token.Valid || claims.ExpiresAt < time.Now().Unix() {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
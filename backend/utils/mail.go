@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Mail — письмо с вложениями.
+type Mail struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []MailAttachment
+}
+
+type MailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Mailer отправляет письма. Реализацию можно подменить, например, в тестах.
+type Mailer interface {
+	Send(mail Mail) error
+}
+
+// SMTPMailer отправляет письма через SMTP-сервер. STARTTLS используется, если сервер его поддерживает;
+// авторизация — только если задан Username. Для локальной проверки подходит любой SMTP-стенд
+// (например, MailHog или smtp4dev на localhost:1025).
+type SMTPMailer struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// ErrMailDisabled возвращается, если отправка писем не настроена.
+var ErrMailDisabled = errors.New("email delivery is not configured: set SMTP_HOST")
+
+// NewMailerFromEnv создаёт SMTPMailer из SMTP_HOST, SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD и SMTP_FROM.
+// Если SMTP_HOST не задан, возвращает nil.
+func NewMailerFromEnv() Mailer {
+	host := Getenv("SMTP_HOST", "")
+	if host == "" {
+		return nil
+	}
+	return &SMTPMailer{
+		Host:     host,
+		Port:     Getenv("SMTP_PORT", "587"),
+		Username: Getenv("SMTP_USERNAME", ""),
+		Password: Getenv("SMTP_PASSWORD", ""),
+		From:     Getenv("SMTP_FROM", "crm@localhost"),
+	}
+}
+
+func (m *SMTPMailer) Send(mail Mail) error {
+	if len(mail.To) == 0 {
+		return errors.New("mail has no recipients")
+	}
+
+	message, err := buildMessage(m.From, mail)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.Username != "" {
+		auth = smtp.PlainAuth("", m.Username, m.Password, m.Host)
+	}
+	return smtp.SendMail(m.Host+":"+m.Port, auth, m.From, mail.To, message)
+}
+
+// buildMessage собирает письмо в формате MIME: текст и вложения в base64.
+func buildMessage(from string, mail Mail) ([]byte, error) {
+	for _, address := range append([]string{from}, mail.To...) {
+		if strings.ContainsAny(address, "\r\n") {
+			return nil, errors.New("invalid email address")
+		}
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(mail.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	body, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBase64(body, []byte(mail.Body)); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range mail.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(part, attachment.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeBase64 пишет данные в base64 строками по 76 символов, как требует MIME.
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		if _, err := w.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+	_, err := w.Write([]byte(encoded + "\r\n"))
+	return err
+}
@@ -0,0 +1,10 @@
+package utils
+
+// RevenueStatuses возвращает статусы заказов, которые учитываются в выручке.
+// REVENUE_RECOGNITION=delivered учитывает только доставленные заказы, по умолчанию — все принятые.
+func RevenueStatuses() []string {
+	if Getenv("REVENUE_RECOGNITION", "accepted") == "delivered" {
+		return []string{"delivered"}
+	}
+	return []string{"accepted", "in_production", "ready", "delivered"}
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	// Базы часовых поясов может не быть в контейнере, поэтому встраиваем её в бинарник
+	_ "time/tzdata"
+)
+
+var (
+	businessLocation     *time.Location
+	businessLocationOnce sync.Once
+)
+
+// BusinessLocation возвращает часовой пояс бизнеса из BUSINESS_TIMEZONE (по умолчанию Asia/Tashkent).
+// В нём считаются границы дней, недель и месяцев в статистике и расписаниях.
+func BusinessLocation() *time.Location {
+	businessLocationOnce.Do(func() {
+		name := Getenv("BUSINESS_TIMEZONE", "Asia/Tashkent")
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			log.Printf("Unknown BUSINESS_TIMEZONE %q, falling back to UTC: %v", name, err)
+			location = time.UTC
+		}
+		businessLocation = location
+	})
+	return businessLocation
+}
+
+// BusinessNow возвращает текущее время в часовом поясе бизнеса.
+func BusinessNow() time.Time {
+	return time.Now().In(BusinessLocation())
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"backend/model"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	totpPeriod = 30
+	totpDigits = 6
+	// Допустимое расхождение часов клиента и сервера (в шагах по 30 секунд)
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret создаёт новый случайный секрет в формате base32.
+func GenerateTOTPSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// TOTPProvisioningURI формирует otpauth:// ссылку для QR-кода приложения-аутентификатора.
+func TOTPProvisioningURI(secret, username string) string {
+	issuer := Getenv("TOTP_ISSUER", "CRM")
+	label := url.PathEscape(issuer + ":" + username)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	return fmt.Sprintf("otpauth://totp/%s?%s", label, params.Encode())
+}
+
+// ValidateTOTP проверяет код и возвращает номер временного шага, на котором он совпал.
+// Шаги не больше lastCounter отклоняются, чтобы один и тот же код нельзя было использовать повторно.
+func ValidateTOTP(secret, code string, lastCounter int64, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return 0, false
+	}
+
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := now.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastCounter {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(hotp(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// hotp вычисляет одноразовый код по RFC 4226.
+func hotp(key []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
+
+// GenerateRecoveryCodes возвращает набор резервных кодов в открытом виде и их хэши для хранения в БД.
+func GenerateRecoveryCodes(count int) ([]string, []string, error) {
+	plain := make([]string, 0, count)
+	hashed := make([]string, 0, count)
+
+	for i := 0; i < count; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		code := hex.EncodeToString(buf)
+		code = code[:5] + "-" + code[5:]
+
+		plain = append(plain, code)
+		hashed = append(hashed, HashAndSalt([]byte(code)))
+	}
+	return plain, hashed, nil
+}
+
+// UseRecoveryCode ищет код среди хэшей и возвращает список без использованного кода.
+func UseRecoveryCode(hashed []string, code string) ([]string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for i, h := range hashed {
+		if ComparePasswords(h, []byte(code)) {
+			rest := make([]string, 0, len(hashed)-1)
+			rest = append(rest, hashed[:i]...)
+			rest = append(rest, hashed[i+1:]...)
+			return rest, true
+		}
+	}
+	return hashed, false
+}
+
+// TwoFactorRequired сообщает, обязательна ли двухфакторная аутентификация для роли.
+func TwoFactorRequired(role model.Role) bool {
+	return role == model.AdminRole && Getenv("REQUIRE_ADMIN_2FA", "false") == "true"
+}
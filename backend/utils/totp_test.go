@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// Секрет из RFC 6238 ("12345678901234567890" в base32); коды — младшие 6 цифр тестовых векторов.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP(t *testing.T) {
+	at := time.Unix(1111111109, 0) // шаг 37037036, код 081804
+	step := at.Unix() / totpPeriod
+
+	tests := []struct {
+		name        string
+		secret      string
+		code        string
+		lastCounter int64
+		now         time.Time
+		wantStep    int64
+		wantOK      bool
+	}{
+		{"current step", rfcSecret, "081804", 0, at, step, true},
+		{"rfc vector 59s", rfcSecret, "287082", 0, time.Unix(59, 0), 1, true},
+		{"rfc vector 1234567890", rfcSecret, "005924", 0, time.Unix(1234567890, 0), 1234567890 / totpPeriod, true},
+		{"spaces and lowercase secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", " 081 804 ", 0, at, step, true},
+		{"previous step within skew", rfcSecret, "081804", 0, at.Add(totpPeriod * time.Second), step, true},
+		{"next step within skew", rfcSecret, "081804", 0, at.Add(-totpPeriod * time.Second), step, true},
+		{"two steps late", rfcSecret, "081804", 0, at.Add(2 * totpPeriod * time.Second), 0, false},
+		{"two steps early", rfcSecret, "081804", 0, at.Add(-2 * totpPeriod * time.Second), 0, false},
+		{"replay of used step", rfcSecret, "081804", step, at, 0, false},
+		{"older counter does not block", rfcSecret, "081804", step - 1, at, step, true},
+		{"wrong code", rfcSecret, "081805", 0, at, 0, false},
+		{"short code", rfcSecret, "81804", 0, at, 0, false},
+		{"invalid secret", "not base32!", "081804", 0, at, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOK := ValidateTOTP(tt.secret, tt.code, tt.lastCounter, tt.now)
+			if gotOK != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateTOTP() = (%d, %v), want (%d, %v)", gotStep, gotOK, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}